@@ -1,7 +1,9 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -9,13 +11,14 @@ import (
 
 	"prop-voter/config"
 	"prop-voter/internal/binmgr"
+	"prop-voter/internal/export"
 	"prop-voter/internal/keymgr"
+	"prop-voter/internal/logging"
 	"prop-voter/internal/models"
 	"prop-voter/internal/registry"
 	"prop-voter/internal/wallet"
 
 	"go.uber.org/zap"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -23,11 +26,11 @@ import (
 
 func handleKeyCommand(args []string, cfg *config.Config, logger *zap.Logger) error {
 	if len(args) < 1 {
-		return fmt.Errorf("key command requires a subcommand (list, import, export, backup, validate)")
+		return fmt.Errorf("key command requires a subcommand (list, import, export, backup, validate, rotate, dump, restore)")
 	}
 
 	// Initialize database and wallet manager
-	db, err := gorm.Open(sqlite.Open(cfg.Database.Path), &gorm.Config{})
+	db, err := openDatabase(cfg.Database)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -36,12 +39,12 @@ func handleKeyCommand(args []string, cfg *config.Config, logger *zap.Logger) err
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
 
-	walletManager, err := wallet.NewManager(db, cfg, logger)
+	walletManager, err := wallet.NewManager(db, cfg, logging.ForModule(logger, cfg.Logging, "wallet"))
 	if err != nil {
 		return fmt.Errorf("failed to create wallet manager: %w", err)
 	}
 
-	keyManager := keymgr.NewManager(cfg, logger, walletManager)
+	keyManager := keymgr.NewManager(cfg, logging.ForModule(logger, cfg.Logging, "keymgr"), walletManager)
 
 	switch args[0] {
 	case "list":
@@ -54,6 +57,12 @@ func handleKeyCommand(args []string, cfg *config.Config, logger *zap.Logger) err
 		return handleKeyBackup(args[1:], keyManager)
 	case "validate":
 		return handleKeyValidate(keyManager)
+	case "rotate":
+		return handleKeyRotate(cfg, walletManager)
+	case "dump":
+		return handleKeyDump(args[1:], walletManager)
+	case "restore":
+		return handleKeyRestore(args[1:], walletManager)
 	default:
 		return fmt.Errorf("unknown key command: %s", args[0])
 	}
@@ -61,12 +70,13 @@ func handleKeyCommand(args []string, cfg *config.Config, logger *zap.Logger) err
 
 func handleBinaryCommand(args []string, cfg *config.Config, logger *zap.Logger) error {
 	if len(args) < 1 {
-		return fmt.Errorf("binary command requires a subcommand (list, update, check)")
+		return fmt.Errorf("binary command requires a subcommand (list, update, check, verify, rollback)")
 	}
 
 	// Initialize registry manager for Chain Registry support
-	registryManager := registry.NewManager(logger)
-	binManager := binmgr.NewManager(cfg, logger, registryManager)
+	registryManager := registry.NewManager(logging.ForModule(logger, cfg.Logging, "registry"), cfg.Registry.BaseURL)
+	registryManager.EnableDiskCache(cfg.Registry.CacheDir, cfg.Registry.CacheTTL)
+	binManager := binmgr.NewManager(cfg, logging.ForModule(logger, cfg.Logging, "binmgr"), registryManager)
 
 	switch args[0] {
 	case "list":
@@ -75,21 +85,27 @@ func handleBinaryCommand(args []string, cfg *config.Config, logger *zap.Logger)
 		return handleBinaryUpdate(args[1:], binManager)
 	case "check":
 		return handleBinaryCheck(binManager)
+	case "verify":
+		return handleBinaryVerify(binManager)
+	case "rollback":
+		return handleBinaryRollback(args[1:], binManager)
 	default:
 		return fmt.Errorf("unknown binary command: %s", args[0])
 	}
 }
 
-func handleRegistryCommand(args []string, registryManager *registry.Manager, logger *zap.Logger) error {
+func handleRegistryCommand(args []string, cfg *config.Config, registryManager *registry.Manager, logger *zap.Logger) error {
 	if len(args) < 1 {
-		return fmt.Errorf("registry command requires a subcommand (list, info, clear-cache)")
+		return fmt.Errorf("registry command requires a subcommand (list, info, refresh, clear-cache)")
 	}
 
 	switch args[0] {
 	case "list":
 		return handleRegistryList(registryManager)
 	case "info":
-		return handleRegistryInfo(args[1:], registryManager, logger)
+		return handleRegistryInfo(args[1:], cfg.Registry.BaseURL, logger)
+	case "refresh":
+		return handleRegistryRefresh(args[1:], registryManager)
 	case "clear-cache":
 		return handleRegistryClearCache(registryManager)
 	default:
@@ -120,7 +136,7 @@ func handleKeyList(keyManager *keymgr.Manager) error {
 
 func handleKeyImport(args []string, keyManager *keymgr.Manager) error {
 	if len(args) < 2 {
-		return fmt.Errorf("usage: key import <chain> <key-name> [mnemonic-file]")
+		return fmt.Errorf("usage: key import <chain> <key-name> [mnemonic-or-armored-key-file]")
 	}
 
 	chain := args[0]
@@ -187,6 +203,103 @@ func handleKeyValidate(keyManager *keymgr.Manager) error {
 	return nil
 }
 
+func handleKeyRotate(cfg *config.Config, walletManager *wallet.Manager) error {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("Enter new encryption key: ")
+	newKey, _ := reader.ReadString('\n')
+	newKey = strings.TrimRight(newKey, "\r\n")
+
+	fmt.Print("Confirm new encryption key: ")
+	confirmKey, _ := reader.ReadString('\n')
+	confirmKey = strings.TrimRight(confirmKey, "\r\n")
+
+	if newKey == "" {
+		return fmt.Errorf("new encryption key must not be empty")
+	}
+	if newKey != confirmKey {
+		return fmt.Errorf("encryption keys do not match")
+	}
+	if newKey == cfg.Security.EncryptionKey {
+		return fmt.Errorf("new encryption key is the same as the current one")
+	}
+
+	if err := walletManager.RotateEncryptionKey(cfg.Security.EncryptionKey, newKey); err != nil {
+		return fmt.Errorf("failed to rotate encryption key: %w", err)
+	}
+
+	fmt.Println("✅ Stored wallets re-encrypted with the new key")
+	fmt.Println("⚠️  Update security.encryption_key in your config before restarting prop-voter")
+	return nil
+}
+
+func handleKeyDump(args []string, walletManager *wallet.Manager) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: key dump <file.json>")
+	}
+
+	wallets, err := walletManager.ListWallets()
+	if err != nil {
+		return err
+	}
+
+	exports := make([]map[string]interface{}, 0, len(wallets))
+	for _, w := range wallets {
+		exported, err := walletManager.ExportWallet(w.ChainID)
+		if err != nil {
+			return fmt.Errorf("failed to export wallet %s: %w", w.ChainID, err)
+		}
+		exports = append(exports, exported)
+	}
+
+	data, err := json.MarshalIndent(exports, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode wallets: %w", err)
+	}
+
+	if err := os.WriteFile(args[0], data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", args[0], err)
+	}
+
+	fmt.Printf("⚠️  Wrote %d decrypted wallets to %s - store it somewhere safe\n", len(exports), args[0])
+	return nil
+}
+
+func handleKeyRestore(args []string, walletManager *wallet.Manager) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: key restore <file.json>")
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[0], err)
+	}
+
+	// Accept both a single ExportWallet object and an array of them (as written by dump)
+	var exports []map[string]interface{}
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+		var single map[string]interface{}
+		if err := json.Unmarshal(data, &single); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", args[0], err)
+		}
+		exports = append(exports, single)
+	} else if err := json.Unmarshal(data, &exports); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", args[0], err)
+	}
+
+	if len(exports) == 0 {
+		return fmt.Errorf("no wallets found in %s", args[0])
+	}
+
+	count, err := walletManager.ImportWalletExports(exports)
+	if err != nil {
+		return fmt.Errorf("failed to restore wallets (%d restored): %w", count, err)
+	}
+
+	fmt.Printf("✅ Restored %d wallets from %s\n", count, args[0])
+	return nil
+}
+
 func handleBinaryList(binManager *binmgr.Manager) error {
 	binaries, err := binManager.GetManagedBinaries()
 	if err != nil {
@@ -218,6 +331,30 @@ func handleBinaryList(binManager *binmgr.Manager) error {
 	return nil
 }
 
+func handleBinaryVerify(binManager *binmgr.Manager) error {
+	results := binManager.VerifyBinaries(context.Background())
+	if len(results) == 0 {
+		fmt.Println("No managed binaries found")
+		return nil
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.OK() {
+			fmt.Printf("✅ %s (%s): %s\n", result.Name, result.Chain, result.Version)
+			continue
+		}
+		failed++
+		fmt.Printf("❌ %s (%s): %v\n   %s\n", result.Name, result.Chain, result.Err, result.Path)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d binaries failed verification", failed, len(results))
+	}
+	fmt.Printf("All %d binaries are runnable\n", len(results))
+	return nil
+}
+
 func handleBinaryUpdate(args []string, binManager *binmgr.Manager) error {
 	if len(args) < 1 {
 		return fmt.Errorf("usage: binary update <chain-name>")
@@ -262,20 +399,42 @@ func handleBinaryCheck(binManager *binmgr.Manager) error {
 	return nil
 }
 
+func handleBinaryRollback(args []string, binManager *binmgr.Manager) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: binary rollback <chain-name>")
+	}
+
+	chainName := args[0]
+
+	fmt.Printf("Rolling back binary for %s...\n", chainName)
+
+	before, after, err := binManager.RollbackBinary(chainName)
+	if err != nil {
+		return fmt.Errorf("failed to roll back binary: %w", err)
+	}
+
+	fmt.Printf("✅ Binary for %s rolled back (version: %s -> %s)\n", chainName, before, after)
+	return nil
+}
+
 // Registry command handlers
 
 func handleRegistryList(registryManager *registry.Manager) error {
-	chains := registryManager.ListSupportedChains()
+	var chains, testnets []string
+	for _, chain := range registryManager.ListSupportedChains() {
+		if name, ok := strings.CutPrefix(chain, "testnets/"); ok {
+			testnets = append(testnets, name)
+		} else {
+			chains = append(chains, chain)
+		}
+	}
 
 	fmt.Printf("Supported Chain Registry chains (%d total):\n\n", len(chains))
+	printChainColumns(chains)
 
-	// Group chains for better display
-	const perColumn = 4
-	for i := 0; i < len(chains); i += perColumn {
-		for j := 0; j < perColumn && i+j < len(chains); j++ {
-			fmt.Printf("%-20s", chains[i+j])
-		}
-		fmt.Println()
+	if len(testnets) > 0 {
+		fmt.Printf("\nSupported testnets (%d total, set testnet: true):\n\n", len(testnets))
+		printChainColumns(testnets)
 	}
 
 	fmt.Println("\nUsage in config.yaml:")
@@ -289,13 +448,24 @@ func handleRegistryList(registryManager *registry.Manager) error {
 	return nil
 }
 
-func handleRegistryInfo(args []string, registryManager *registry.Manager, logger *zap.Logger) error {
+// printChainColumns prints chain names in columns for better display
+func printChainColumns(chains []string) {
+	const perColumn = 4
+	for i := 0; i < len(chains); i += perColumn {
+		for j := 0; j < perColumn && i+j < len(chains); j++ {
+			fmt.Printf("%-20s", chains[i+j])
+		}
+		fmt.Println()
+	}
+}
+
+func handleRegistryInfo(args []string, baseURL string, logger *zap.Logger) error {
 	if len(args) < 1 {
 		return fmt.Errorf("info command requires a chain name")
 	}
 
 	chainName := args[0]
-	client := registry.NewClient(logger)
+	client := registry.NewClient(logger, baseURL)
 
 	ctx := context.Background()
 	chainInfo, err := client.GetChainInfo(ctx, chainName)
@@ -341,8 +511,67 @@ func handleRegistryInfo(args []string, registryManager *registry.Manager, logger
 	return nil
 }
 
+func handleRegistryRefresh(args []string, registryManager *registry.Manager) error {
+	if len(args) < 1 {
+		return fmt.Errorf("usage: registry refresh <chain>")
+	}
+
+	chainName := args[0]
+	chainInfo, err := registryManager.RefreshChainInfo(context.Background(), chainName)
+	if err != nil {
+		return fmt.Errorf("failed to refresh chain info for %s: %w", chainName, err)
+	}
+
+	fmt.Printf("Refreshed Chain Registry information for '%s':\n\n", chainName)
+	fmt.Printf("  Pretty Name:     %s\n", chainInfo.PrettyName)
+	fmt.Printf("  Chain ID:        %s\n", chainInfo.ChainID)
+	fmt.Printf("  Bech32 Prefix:   %s\n", chainInfo.Bech32Prefix)
+	fmt.Printf("  Daemon Name:     %s\n", chainInfo.DaemonName)
+	fmt.Printf("  Staking Denom:   %s\n", chainInfo.Denom)
+	fmt.Printf("  Decimals:        %d\n", chainInfo.Decimals)
+	fmt.Printf("  Version:         %s\n", chainInfo.Version)
+	fmt.Printf("  Git Repository:  %s\n", chainInfo.GitRepo)
+
+	if chainInfo.BinaryURL != "" {
+		fmt.Printf("  Binary URL:      %s\n", chainInfo.BinaryURL)
+	}
+	if chainInfo.ExplorerURL != "" {
+		fmt.Printf("  Explorer URL:    %s\n", chainInfo.ExplorerURL)
+	}
+
+	return nil
+}
+
 func handleRegistryClearCache(registryManager *registry.Manager) error {
 	registryManager.ClearCache()
 	fmt.Println("Chain Registry cache cleared successfully.")
 	return nil
 }
+
+func handleExportCommand(path string, args []string, db *gorm.DB) error {
+	format, err := export.FormatFromPath(path)
+	if err != nil {
+		return err
+	}
+
+	var chainID string
+	if len(args) > 0 {
+		chainID = args[0]
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	count, err := export.WriteVotes(db, file, format, chainID)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write export file: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported %d votes to %s\n", count, path)
+	return nil
+}
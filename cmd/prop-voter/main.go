@@ -13,13 +13,17 @@ import (
 	"prop-voter/internal/discord"
 	"prop-voter/internal/health"
 	"prop-voter/internal/keymgr"
+	"prop-voter/internal/logging"
 	"prop-voter/internal/models"
 	"prop-voter/internal/registry"
 	"prop-voter/internal/scanner"
+	"prop-voter/internal/version"
 	"prop-voter/internal/voting"
 	"prop-voter/internal/wallet"
+	"prop-voter/internal/webhook"
 
 	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -28,16 +32,24 @@ func main() {
 	var (
 		configPath  = flag.String("config", "config.yaml", "Path to configuration file")
 		validate    = flag.Bool("validate", false, "Validate configuration and chains then exit")
+		once        = flag.Bool("once", false, "Scan every chain once, send pending notifications, then exit (non-zero if a chain failed)")
 		debug       = flag.Bool("debug", false, "Enable debug logging")
-		keyCmd      = flag.String("key", "", "Key management command (list, import, export, backup, validate)")
-		binaryCmd   = flag.String("binary", "", "Binary management command (list, update, check)")
-		registryCmd = flag.String("registry", "", "Chain Registry command (list, info, clear-cache)")
+		keyCmd      = flag.String("key", "", "Key management command (list, import, export, backup, validate, rotate, dump, restore)")
+		binaryCmd   = flag.String("binary", "", "Binary management command (list, update, check, verify, rollback)")
+		registryCmd = flag.String("registry", "", "Chain Registry command (list, info, refresh, clear-cache)")
+		exportPath  = flag.String("export", "", "Export vote history to a .csv or .json file (optionally for one chain) then exit")
+		showVersion = flag.Bool("version", false, "Print the build version and exit")
 	)
 	flag.Parse()
 
+	if *showVersion {
+		fmt.Printf("prop-voter %s\n", version.String())
+		return
+	}
+
 	args := flag.Args()
 
-	// Initialize logger
+	// Bootstrap logger for configuration errors, replaced once the logging config is loaded
 	var logger *zap.Logger
 	var err error
 
@@ -51,9 +63,6 @@ func main() {
 		fmt.Printf("Failed to initialize logger: %v\n", err)
 		os.Exit(1)
 	}
-	defer logger.Sync()
-
-	logger.Info("Starting Prop-Voter", zap.String("config", *configPath))
 
 	// Load configuration
 	cfg, err := config.LoadConfig(*configPath)
@@ -61,13 +70,34 @@ func main() {
 		logger.Fatal("Failed to load configuration", zap.Error(err))
 	}
 
+	// Catch misconfiguration before any service starts
+	if err := cfg.Validate(); err != nil {
+		logger.Fatal("Invalid configuration", zap.Error(err))
+	}
+
+	configuredLogger, err := logging.NewLogger(cfg.Logging, *debug)
+	if err != nil {
+		logger.Fatal("Failed to initialize logger", zap.Error(err))
+	}
+	logger.Sync()
+	logger = configuredLogger
+	defer logger.Sync()
+
+	logger.Info("Starting Prop-Voter",
+		zap.String("version", version.Version),
+		zap.String("commit", version.Commit),
+		zap.String("build_date", version.BuildDate),
+		zap.String("config", *configPath),
+	)
+
 	logger.Info("Configuration loaded successfully",
 		zap.Int("chains", len(cfg.Chains)),
 		zap.Duration("scan_interval", cfg.Scanning.Interval),
 	)
 
 	// Initialize Chain Registry manager
-	registryManager := registry.NewManager(logger)
+	registryManager := registry.NewManager(logging.ForModule(logger, cfg.Logging, "registry"), cfg.Registry.BaseURL)
+	registryManager.EnableDiskCache(cfg.Registry.CacheDir, cfg.Registry.CacheTTL)
 
 	// Populate Chain Registry information for chains that use it
 	ctx := context.Background()
@@ -75,8 +105,32 @@ func main() {
 		logger.Fatal("Failed to populate chain configurations from Chain Registry", zap.Error(err))
 	}
 
+	// Re-check now that registry chains have their chain IDs and daemon names
+	if err := cfg.Validate(); err != nil {
+		logger.Fatal("Invalid configuration after Chain Registry population", zap.Error(err))
+	}
+
 	logger.Info("Chain Registry integration completed")
 
+	// The test keyring backend stores keys unencrypted on disk
+	var insecureChains []string
+	for i := range cfg.Chains {
+		if cfg.Chains[i].GetKeyringBackend(cfg.KeyManager.KeyringBackend) == config.DefaultKeyringBackend {
+			insecureChains = append(insecureChains, cfg.Chains[i].GetName())
+		}
+	}
+	if len(insecureChains) > 0 {
+		logger.Warn("Using the insecure \"test\" keyring backend, which stores keys unencrypted; set key_manager.keyring_backend (or keyring_backend per chain) to file or os",
+			zap.Strings("chains", insecureChains),
+		)
+	}
+
+	if cfg.AuthEndpoints.IsActive() && cfg.AuthEndpoints.UsesHeader() {
+		logger.Info("Sending the endpoint API key as a header; CLI --node connections cannot send it",
+			zap.String("header", cfg.AuthEndpoints.GetHeaderName()),
+		)
+	}
+
 	// Handle CLI commands
 	if *keyCmd != "" {
 		cmdArgs := append([]string{*keyCmd}, args...)
@@ -96,32 +150,40 @@ func main() {
 
 	if *registryCmd != "" {
 		cmdArgs := append([]string{*registryCmd}, args...)
-		if err := handleRegistryCommand(cmdArgs, registryManager, logger); err != nil {
+		if err := handleRegistryCommand(cmdArgs, cfg, registryManager, logger); err != nil {
 			logger.Fatal("Registry command failed", zap.Error(err))
 		}
 		return
 	}
 
 	// Initialize database
-	db, err := initDatabase(cfg.Database.Path, logger)
+	db, err := initDatabase(cfg.Database, logger)
 	if err != nil {
 		logger.Fatal("Failed to initialize database", zap.Error(err))
 	}
 
+	if *exportPath != "" {
+		if err := handleExportCommand(*exportPath, args, db); err != nil {
+			logger.Fatal("Export command failed", zap.Error(err))
+		}
+		return
+	}
+
 	// Initialize wallet manager
-	walletManager, err := wallet.NewManager(db, cfg, logger)
+	walletManager, err := wallet.NewManager(db, cfg, logging.ForModule(logger, cfg.Logging, "wallet"))
 	if err != nil {
 		logger.Fatal("Failed to initialize wallet manager", zap.Error(err))
 	}
 
 	// Initialize binary manager with Chain Registry support
-	binaryManager := binmgr.NewManager(cfg, logger, registryManager)
+	binaryManager := binmgr.NewManager(cfg, logging.ForModule(logger, cfg.Logging, "binmgr"), registryManager)
 
 	// Initialize key manager
-	keyManager := keymgr.NewManager(cfg, logger, walletManager)
+	keyManager := keymgr.NewManager(cfg, logging.ForModule(logger, cfg.Logging, "keymgr"), walletManager)
 
 	// Initialize voter (use local binaries if managed)
-	voter := voting.NewVoter(cfg, logger)
+	voter := voting.NewVoter(cfg, logging.ForModule(logger, cfg.Logging, "voter"))
+	voter.SetWalletManager(walletManager)
 
 	// Validate chains if requested
 	if *validate {
@@ -132,13 +194,35 @@ func main() {
 			logger.Fatal("Chain validation failed", zap.Error(err))
 		}
 
+		// Validate RPC/REST endpoints
+		unreachable := 0
+		for _, check := range voter.CheckAllEndpoints(context.Background()) {
+			if check.OK() {
+				logger.Info("Endpoints reachable", zap.String("chain", check.Chain))
+				continue
+			}
+
+			unreachable++
+			fields := []zap.Field{zap.String("chain", check.Chain), zap.Bool("api_key_rejected", check.APIKeyRejected)}
+			if check.RESTErr != nil {
+				fields = append(fields, zap.NamedError("rest_error", check.RESTErr))
+			}
+			if check.RPCErr != nil {
+				fields = append(fields, zap.NamedError("rpc_error", check.RPCErr))
+			}
+			logger.Error("Endpoint check failed", fields...)
+		}
+
 		// Validate keys
 		if err := keyManager.ValidateKeys(); err != nil {
 			logger.Warn("Key validation warning", zap.Error(err))
 		}
 
-		// Validate wallet manager
+		// Validate wallet manager (Ledger keys are never stored)
 		for _, chain := range cfg.Chains {
+			if chain.UseLedger {
+				continue
+			}
 			if err := walletManager.ValidateWalletExists(chain.GetChainID()); err != nil {
 				logger.Warn("Wallet validation warning",
 					zap.String("chain", chain.GetName()),
@@ -147,26 +231,82 @@ func main() {
 			}
 		}
 
+		if unreachable > 0 {
+			logger.Fatal("Validation failed: some chain endpoints are unreachable",
+				zap.Int("chains_with_unreachable_endpoints", unreachable),
+				zap.Int("total_chains", len(cfg.Chains)),
+			)
+		}
+
 		logger.Info("Validation completed successfully")
 		return
 	}
 
 	// Initialize Discord bot
-	bot, err := discord.NewBot(db, cfg, logger, voter)
+	bot, err := discord.NewBot(db, cfg, logging.ForModule(logger, cfg.Logging, "discord"), voter)
 	if err != nil {
 		logger.Fatal("Failed to initialize Discord bot", zap.Error(err))
 	}
 
 	// Initialize proposal scanner
-	proposalScanner := scanner.NewScanner(db, cfg, logger)
+	proposalScanner := scanner.NewScanner(db, cfg, logging.ForModule(logger, cfg.Logging, "scanner"))
+
+	bot.SetScanner(proposalScanner)
+	bot.SetBinaryManager(binaryManager)
+
+	// Optional generic webhook notifications alongside Discord
+	if cfg.Webhook.IsEnabled() {
+		bot.SetWebhook(webhook.NewNotifier(cfg.Webhook, logging.ForModule(logger, cfg.Logging, "webhook")))
+		logger.Info("Webhook notifications enabled")
+	}
 
 	// Initialize health server
-	healthServer := health.NewServer(cfg, db, logger)
+	healthServer := health.NewServer(cfg, db, logging.ForModule(logger, cfg.Logging, "health"))
+	proposalScanner.SetHealthStatus(healthServer.Status())
+	bot.SetHealthStatus(healthServer.Status())
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Single scan for cron-style runs: no Discord listener, background loops or health server
+	if *once {
+		scanErr := proposalScanner.ScanOnce(ctx)
+
+		sent, err := bot.FlushNotifications()
+		if err != nil {
+			logger.Error("Failed to send pending notifications", zap.Error(err))
+		}
+		logger.Info("Single scan completed", zap.Int("notifications_sent", sent))
+
+		if scanErr != nil {
+			logger.Fatal("Single scan failed", zap.Error(scanErr))
+		}
+		if err != nil {
+			logger.Fatal("Single scan failed to send notifications", zap.Error(err))
+		}
+		return
+	}
+
+	// Optionally stage binaries for passed software upgrades (never swapped automatically)
+	if cfg.BinaryManager.Enabled && cfg.BinaryManager.StageUpgrades {
+		proposalScanner.SetUpgradeHandler(func(proposal models.Proposal) {
+			if !binaryManager.ManagesChain(proposal.ChainID) {
+				return
+			}
+			path, err := binaryManager.StageUpgrade(ctx, proposal.ChainID, proposal.UpgradeName, proposal.UpgradeInfo)
+			if err != nil {
+				logger.Error("Failed to stage upgrade binary",
+					zap.String("chain_id", proposal.ChainID),
+					zap.String("upgrade", proposal.UpgradeName),
+					zap.Error(err),
+				)
+			}
+			bot.NotifyUpgradeStaged(proposal, path, err)
+		})
+		logger.Info("Upgrade binary staging enabled")
+	}
+
 	// Start services
 	logger.Info("Starting services...")
 
@@ -179,6 +319,8 @@ func main() {
 	if err := binaryManager.SetupBinariesSync(ctx); err != nil {
 		logger.Error("Failed to setup binaries", zap.Error(err))
 	}
+	// Readiness only waits for the attempt: on failure the voter falls back to binaries on PATH
+	healthServer.Status().SetBinariesReady()
 
 	// Setup keys after binaries are ready
 	if err := keyManager.SetupKeys(ctx); err != nil {
@@ -219,10 +361,15 @@ func main() {
 }
 
 // initDatabase initializes the database connection and creates tables
-func initDatabase(dbPath string, logger *zap.Logger) (*gorm.DB, error) {
-	logger.Info("Initializing database", zap.String("path", dbPath))
+func initDatabase(dbConfig config.DatabaseConfig, logger *zap.Logger) (*gorm.DB, error) {
+	// The postgres DSN may hold credentials, so only the SQLite path is logged
+	fields := []zap.Field{zap.String("driver", dbDriver(dbConfig))}
+	if dbDriver(dbConfig) == config.DatabaseDriverSQLite {
+		fields = append(fields, zap.String("path", dbConfig.Path))
+	}
+	logger.Info("Initializing database", fields...)
 
-	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	db, err := openDatabase(dbConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -235,3 +382,47 @@ func initDatabase(dbPath string, logger *zap.Logger) (*gorm.DB, error) {
 	logger.Info("Database initialized successfully")
 	return db, nil
 }
+
+// openDatabase connects to the configured database with the matching gorm driver
+func openDatabase(dbConfig config.DatabaseConfig) (*gorm.DB, error) {
+	driver := dbDriver(dbConfig)
+	maxOpenConns := dbConfig.MaxOpenConns
+
+	var dialector gorm.Dialector
+	switch driver {
+	case config.DatabaseDriverSQLite:
+		dialector = sqlite.Open(dbConfig.SQLiteDSN())
+		// A single connection serializes the scanner's and Discord handlers' writes,
+		// avoiding "database is locked" errors
+		if maxOpenConns == 0 {
+			maxOpenConns = 1
+		}
+	case config.DatabaseDriverPostgres:
+		dialector = postgres.Open(dbConfig.DSN)
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", driver)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to access database connection pool: %w", err)
+	}
+	if maxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(maxOpenConns)
+	}
+
+	return db, nil
+}
+
+// dbDriver returns the configured database driver, defaulting to SQLite
+func dbDriver(dbConfig config.DatabaseConfig) string {
+	if dbConfig.Driver == "" {
+		return config.DatabaseDriverSQLite
+	}
+	return dbConfig.Driver
+}
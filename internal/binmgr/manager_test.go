@@ -0,0 +1,412 @@
+package binmgr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"prop-voter/config"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestSetupBinariesDeduplicatesSharedRepo(t *testing.T) {
+	repo := config.BinaryRepo{Owner: "cosmos", Repo: "gaia", Enabled: true}
+	cfg := &config.Config{
+		BinaryManager: config.BinaryMgrConfig{Enabled: true, BinDir: t.TempDir()},
+		Chains: []config.ChainConfig{
+			{Name: "Cosmos Hub", ChainID: "cosmoshub-4", CLIName: "gaiad", BinaryRepo: repo},
+			{Name: "Cosmos Hub Testnet", ChainID: "theta-testnet-001", CLIName: "gaiad", BinaryRepo: repo},
+			{Name: "Osmosis", ChainID: "osmosis-1", CLIName: "osmosisd", BinaryRepo: config.BinaryRepo{Owner: "osmosis-labs", Repo: "osmosis", Enabled: true}},
+		},
+	}
+
+	manager := NewManager(cfg, zaptest.NewLogger(t), nil)
+
+	var mu sync.Mutex
+	acquired := make(map[string]int)
+	manager.acquire = func(ctx context.Context, chain *config.ChainConfig) error {
+		mu.Lock()
+		defer mu.Unlock()
+		acquired[chain.GetCLIName()]++
+		return nil
+	}
+
+	if err := manager.setupBinaries(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if acquired["gaiad"] != 1 {
+		t.Errorf("Expected gaiad to be acquired once for chains sharing a repo, got %d", acquired["gaiad"])
+	}
+	if acquired["osmosisd"] != 1 {
+		t.Errorf("Expected osmosisd to be acquired once, got %d", acquired["osmosisd"])
+	}
+}
+
+func TestSetupBinariesConcurrency(t *testing.T) {
+	cfg := &config.Config{
+		BinaryManager: config.BinaryMgrConfig{Enabled: true, BinDir: t.TempDir(), SetupConcurrency: 2, SetupTimeout: 50 * time.Millisecond},
+	}
+	for _, cli := range []string{"gaiad", "osmosisd", "junod", "stard", "akashd"} {
+		cfg.Chains = append(cfg.Chains, config.ChainConfig{Name: cli, ChainID: cli + "-1", CLIName: cli,
+			BinaryRepo: config.BinaryRepo{Owner: "org", Repo: cli, Enabled: true}})
+	}
+
+	manager := NewManager(cfg, zaptest.NewLogger(t), nil)
+
+	var mu sync.Mutex
+	running, peak, timedOut := 0, 0, 0
+	manager.acquire = func(ctx context.Context, chain *config.ChainConfig) error {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+
+		// Each acquisition hangs until its per-binary timeout cancels it
+		<-ctx.Done()
+
+		mu.Lock()
+		running--
+		if ctx.Err() == context.DeadlineExceeded {
+			timedOut++
+		}
+		mu.Unlock()
+		return ctx.Err()
+	}
+
+	if err := manager.setupBinaries(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if peak != 2 {
+		t.Errorf("Expected at most 2 concurrent acquisitions, got a peak of %d", peak)
+	}
+	if timedOut != 5 {
+		t.Errorf("Expected all 5 acquisitions to hit the setup timeout, got %d", timedOut)
+	}
+}
+
+func TestCompileFromSourceWaitsForSlot(t *testing.T) {
+	cfg := &config.Config{BinaryManager: config.BinaryMgrConfig{BinDir: t.TempDir()}}
+	manager := NewManager(cfg, zaptest.NewLogger(t), nil)
+
+	// Hold the only compile slot, as a running compilation would
+	manager.compileSlots <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	chain := &config.ChainConfig{Name: "Juno", CLIName: "junod"}
+	if err := manager.compileFromSource(ctx, manager.sourceCompiler, chain); err == nil {
+		t.Fatal("Expected compilation to give up while the compile slot is taken")
+	}
+}
+
+func TestCompileFromSourceSetupTimeoutStartsWithSlot(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" && runtime.GOOS != "freebsd" {
+		t.Skip("free disk space is not measured on " + runtime.GOOS)
+	}
+
+	cfg := &config.Config{BinaryManager: config.BinaryMgrConfig{BinDir: t.TempDir(), MinFreeDiskMB: 1 << 40}}
+	manager := NewManager(cfg, zaptest.NewLogger(t), nil)
+
+	// Another build holds the only slot for longer than the setup timeout
+	manager.compileSlots <- struct{}{}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		<-manager.compileSlots
+	}()
+
+	ctx, cancel := withSetupTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	chain := &config.ChainConfig{Name: "Juno", CLIName: "junod", BinarySource: config.BinarySource{SourceRepo: "https://example.invalid/juno.git"}}
+	err := manager.compileFromSource(ctx, manager.sourceCompiler, chain)
+	if err == nil || !strings.Contains(err.Error(), "not enough disk space") {
+		t.Fatalf("Expected the build to start once the slot was free, got: %v", err)
+	}
+}
+
+func TestCompileFromSourceChecksDiskSpace(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" && runtime.GOOS != "freebsd" {
+		t.Skip("free disk space is not measured on " + runtime.GOOS)
+	}
+
+	buildDir := filepath.Join(t.TempDir(), "build")
+	cfg := &config.Config{BinaryManager: config.BinaryMgrConfig{BinDir: t.TempDir(), BuildDir: buildDir, MinFreeDiskMB: 1 << 40}}
+	manager := NewManager(cfg, zaptest.NewLogger(t), nil)
+
+	chain := &config.ChainConfig{Name: "Juno", CLIName: "junod", BinarySource: config.BinarySource{SourceRepo: "https://example.invalid/juno.git"}}
+	err := manager.compileFromSource(context.Background(), manager.sourceCompiler, chain)
+	if err == nil || !strings.Contains(err.Error(), "not enough disk space") {
+		t.Fatalf("Expected the build to fail fast on disk space, got: %v", err)
+	}
+	if _, err := os.Stat(buildDir); err != nil {
+		t.Errorf("Expected the configured build directory to be created: %v", err)
+	}
+}
+
+func writeFakeBinary(t *testing.T, path, version string) {
+	t.Helper()
+	script := "#!/bin/sh\necho " + version + "\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to write fake binary: %v", err)
+	}
+}
+
+func TestRollbackBinary(t *testing.T) {
+	binDir := t.TempDir()
+	cfg := &config.Config{
+		BinaryManager: config.BinaryMgrConfig{Enabled: true, BinDir: binDir},
+		Chains: []config.ChainConfig{
+			{Name: "Cosmos Hub", ChainID: "cosmoshub-4", CLIName: "gaiad"},
+		},
+	}
+	manager := NewManager(cfg, zaptest.NewLogger(t), nil)
+
+	binaryPath := filepath.Join(binDir, "gaiad")
+	writeFakeBinary(t, binaryPath, "v16.0.0")
+
+	// No backup present
+	if _, _, err := manager.RollbackBinary("Cosmos Hub"); err == nil {
+		t.Fatal("Expected error when no backup is present")
+	}
+
+	// Backup present but not executable
+	if err := os.WriteFile(binaryPath+".backup", []byte("not a binary"), 0644); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+	if _, _, err := manager.RollbackBinary("Cosmos Hub"); err == nil {
+		t.Fatal("Expected error when backup is not executable")
+	}
+
+	writeFakeBinary(t, binaryPath+".backup", "v15.2.0")
+	if err := os.Chmod(binaryPath+".backup", 0755); err != nil {
+		t.Fatalf("Failed to chmod backup: %v", err)
+	}
+
+	before, after, err := manager.RollbackBinary("Cosmos Hub")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if before != "v16.0.0" || after != "v15.2.0" {
+		t.Errorf("Expected v16.0.0 -> v15.2.0, got %s -> %s", before, after)
+	}
+	if _, err := os.Stat(binaryPath + ".backup"); !os.IsNotExist(err) {
+		t.Error("Expected backup to be consumed by rollback")
+	}
+}
+
+func TestGetManagedBinariesVersions(t *testing.T) {
+	binDir := t.TempDir()
+	cfg := &config.Config{
+		BinaryManager: config.BinaryMgrConfig{Enabled: true, BinDir: binDir},
+		Chains: []config.ChainConfig{
+			{Name: "Cosmos Hub", ChainID: "cosmoshub-4", CLIName: "gaiad", BinaryRepo: config.BinaryRepo{Owner: "cosmos", Repo: "gaia", Enabled: true}},
+			{Name: "Osmosis", ChainID: "osmosis-1", CLIName: "osmosisd", BinaryRepo: config.BinaryRepo{Owner: "osmosis-labs", Repo: "osmosis", Enabled: true}},
+			{Name: "Unmanaged", ChainID: "other-1", CLIName: "otherd"},
+		},
+	}
+	manager := NewManager(cfg, zaptest.NewLogger(t), nil)
+	writeFakeBinary(t, filepath.Join(binDir, "gaiad"), "v16.0.0")
+
+	binaries, err := manager.GetManagedBinaries()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(binaries) != 2 {
+		t.Fatalf("Expected the two managed binaries, got %+v", binaries)
+	}
+	if binaries[0].ChainID != "cosmoshub-4" || binaries[0].Version != "v16.0.0" || binaries[0].LastUpdated.IsZero() {
+		t.Errorf("Expected gaiad v16.0.0 with a modification time, got %+v", binaries[0])
+	}
+	if binaries[1].Version != "not installed" {
+		t.Errorf("Expected osmosisd to be reported as not installed, got %q", binaries[1].Version)
+	}
+}
+
+func TestVerifyBinaries(t *testing.T) {
+	binDir := t.TempDir()
+	repo := config.BinaryRepo{Owner: "org", Repo: "chain", Enabled: true}
+	cfg := &config.Config{
+		BinaryManager: config.BinaryMgrConfig{BinDir: binDir},
+		Chains: []config.ChainConfig{
+			{Name: "Good", CLIName: "goodd", BinaryRepo: repo},
+			{Name: "Missing", CLIName: "missingd", BinaryRepo: repo},
+			{Name: "Wrong Arch", CLIName: "armd", BinaryRepo: repo},
+			{Name: "Failing", CLIName: "faild", BinaryRepo: repo},
+			{Name: "Unmanaged", CLIName: "unmanagedd"},
+		},
+	}
+	writeFakeBinary(t, filepath.Join(binDir, "goodd"), "v1.2.3")
+	// Not a script or a native executable, as a binary for another architecture looks to exec
+	if err := os.WriteFile(filepath.Join(binDir, "armd"), []byte{0x7f, 'E', 'L', 'F', 0x01, 0x02}, 0755); err != nil {
+		t.Fatalf("Failed to write binary: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(binDir, "faild"), []byte("#!/bin/sh\necho broken config\nexit 1\n"), 0755); err != nil {
+		t.Fatalf("Failed to write binary: %v", err)
+	}
+
+	manager := NewManager(cfg, zaptest.NewLogger(t), nil)
+	results := manager.VerifyBinaries(context.Background())
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 managed binaries, got %d", len(results))
+	}
+
+	if !results[0].OK() || results[0].Version != "v1.2.3" {
+		t.Errorf("Expected goodd to verify with v1.2.3, got %q, %v", results[0].Version, results[0].Err)
+	}
+	if results[1].OK() || !strings.Contains(results[1].Err.Error(), "not installed") {
+		t.Errorf("Expected missingd to be reported as not installed, got %v", results[1].Err)
+	}
+	if results[2].OK() || !strings.Contains(results[2].Err.Error(), "exec format error") {
+		t.Errorf("Expected armd to be reported as an exec format error, got %v", results[2].Err)
+	}
+	if results[3].OK() || !strings.Contains(results[3].Err.Error(), "broken config") {
+		t.Errorf("Expected faild's output in its error, got %v", results[3].Err)
+	}
+}
+
+func TestUpdateBinaryBacksUpOld(t *testing.T) {
+	binDir := t.TempDir()
+	cfg := &config.Config{
+		BinaryManager: config.BinaryMgrConfig{Enabled: true, BinDir: binDir, BackupOld: true},
+		Chains: []config.ChainConfig{
+			{Name: "Cosmos Hub", ChainID: "cosmoshub-4", CLIName: "gaiad", BinaryRepo: config.BinaryRepo{Owner: "cosmos", Repo: "gaia", Enabled: true}},
+		},
+	}
+	manager := NewManager(cfg, zaptest.NewLogger(t), nil)
+
+	binaryPath := filepath.Join(binDir, "gaiad")
+	writeFakeBinary(t, binaryPath, "v15.2.0")
+
+	manager.acquire = func(ctx context.Context, chain *config.ChainConfig) error {
+		writeFakeBinary(t, binaryPath, "v16.0.0")
+		return nil
+	}
+
+	if err := manager.UpdateBinary(context.Background(), "Cosmos Hub"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if version := manager.binaryVersion(binaryPath + ".backup"); version != "v15.2.0" {
+		t.Errorf("Expected backup to hold v15.2.0, got %s", version)
+	}
+}
+
+func TestUpgradeBinaryURL(t *testing.T) {
+	info := `{"binaries":{"linux/amd64":"https://example.com/gaiad-v19-linux-amd64?checksum=sha256:abc","any":"https://example.com/gaiad-v19"}}`
+
+	tests := []struct {
+		name         string
+		info         string
+		platform     string
+		want         string
+		wantChecksum string
+	}{
+		{"platform match splits off checksum", info, "linux/amd64", "https://example.com/gaiad-v19-linux-amd64", "sha256:abc"},
+		{"falls back to any", info, "darwin/arm64", "https://example.com/gaiad-v19", ""},
+		{"plain text info", "v19 release", "linux/amd64", "", ""},
+		{"empty info", "", "linux/amd64", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, checksum := upgradeBinaryURL(tt.info, tt.platform)
+			if got != tt.want || checksum != tt.wantChecksum {
+				t.Errorf("upgradeBinaryURL() = %q, %q, want %q, %q", got, checksum, tt.want, tt.wantChecksum)
+			}
+		})
+	}
+}
+
+func TestStageUpgrade(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("#!/bin/sh\necho v19.0.0\n"))
+	}))
+	defer server.Close()
+
+	binDir := t.TempDir()
+	cfg := &config.Config{
+		BinaryManager: config.BinaryMgrConfig{Enabled: true, BinDir: binDir},
+		Chains: []config.ChainConfig{
+			{Name: "Cosmos Hub", ChainID: "cosmoshub-4", CLIName: "gaiad", BinaryRepo: config.BinaryRepo{Owner: "cosmos", Repo: "gaia", Enabled: true}},
+			{Name: "Unmanaged", ChainID: "other-1", CLIName: "otherd"},
+		},
+	}
+	manager := NewManager(cfg, zaptest.NewLogger(t), nil)
+
+	if manager.ManagesChain("other-1") {
+		t.Error("Expected chain without a binary source not to be managed")
+	}
+	if _, err := manager.StageUpgrade(context.Background(), "other-1", "v2", ""); err == nil {
+		t.Error("Expected staging to fail for an unmanaged chain")
+	}
+	if _, err := manager.StageUpgrade(context.Background(), "cosmoshub-4", "../v19", ""); err == nil {
+		t.Error("Expected staging to reject a version containing a path separator")
+	}
+
+	info := `{"binaries":{"any":"` + server.URL + `/gaiad"}}`
+	path, err := manager.StageUpgrade(context.Background(), "cosmoshub-4", "v19", info)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := filepath.Join(binDir, "staged", "v19", "gaiad")
+	if path != want {
+		t.Errorf("Expected staged binary at %s, got %s", want, path)
+	}
+	if _, err := os.Stat(filepath.Join(binDir, "gaiad")); !os.IsNotExist(err) {
+		t.Error("Expected the active binary to be left untouched")
+	}
+}
+
+func TestStageUpgradeChecksum(t *testing.T) {
+	const binary = "#!/bin/sh\necho v20.0.0\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("checksum") {
+			t.Error("Expected the checksum parameter not to be sent to the server")
+		}
+		w.Write([]byte(binary))
+	}))
+	defer server.Close()
+
+	binDir := t.TempDir()
+	cfg := &config.Config{
+		BinaryManager: config.BinaryMgrConfig{Enabled: true, BinDir: binDir},
+		Chains: []config.ChainConfig{
+			{Name: "Cosmos Hub", ChainID: "cosmoshub-4", CLIName: "gaiad", BinaryRepo: config.BinaryRepo{Owner: "cosmos", Repo: "gaia", Enabled: true}},
+		},
+	}
+	manager := NewManager(cfg, zaptest.NewLogger(t), nil)
+
+	sum := sha256.Sum256([]byte(binary))
+	wrong := sha256.Sum256([]byte("tampered"))
+
+	info := `{"binaries":{"any":"` + server.URL + `/gaiad?checksum=sha256:` + hex.EncodeToString(wrong[:]) + `"}}`
+	if _, err := manager.StageUpgrade(context.Background(), "cosmoshub-4", "v20", info); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("Expected a checksum mismatch, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(binDir, "staged", "v20", "gaiad")); !os.IsNotExist(err) {
+		t.Error("Expected nothing to be staged after a checksum mismatch")
+	}
+
+	info = `{"binaries":{"any":"` + server.URL + `/gaiad?checksum=sha256:` + hex.EncodeToString(sum[:]) + `"}}`
+	path, err := manager.StageUpgrade(context.Background(), "cosmoshub-4", "v20", info)
+	if err != nil {
+		t.Fatalf("Expected a matching checksum to stage, got %v", err)
+	}
+	if version := manager.binaryVersion(path); version != "v20.0.0" {
+		t.Errorf("Expected the staged binary to report v20.0.0, got %s", version)
+	}
+}
@@ -0,0 +1,156 @@
+package modules
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"prop-voter/config"
+	"prop-voter/internal/redact"
+
+	"go.uber.org/zap"
+)
+
+// makefileNames are the files GNU make reads, in its lookup order
+var makefileNames = []string{"GNUmakefile", "makefile", "Makefile"}
+
+// makeTargetPattern matches a rule line ("install: go.sum"), but not a variable assignment
+// ("VERSION := 1.0" or "LDFLAGS ?= ...")
+var makeTargetPattern = regexp.MustCompile(`^([A-Za-z0-9_.-]+)\s*:([^=]|$)`)
+
+// majorVersionSuffix matches the /vN suffix of a versioned Go module path
+var majorVersionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// detectBuild chooses the build command and target for a cloned repository. Configured values
+// win; otherwise the command comes from the Makefile's install or build target (falling back
+// to go install of the daemon's cmd/ package), and the target from cmd/ and go.mod
+func (s *SourceCompiler) detectBuild(chain *config.ChainConfig, cloneDir string) (buildCmd, buildTarget string) {
+	buildTarget = chain.BinarySource.BuildTarget
+	targetSource := "build_target"
+	if buildTarget == "" {
+		buildTarget, targetSource = detectDaemonName(cloneDir, chain.GetCLIName())
+	}
+
+	buildCmd = chain.BinarySource.BuildCommand
+	cmdSource := "build_command"
+	if buildCmd == "" {
+		buildCmd, cmdSource = detectBuildCommand(cloneDir, buildTarget)
+	} else if targetSource == "build_target" {
+		return buildCmd, buildTarget
+	}
+
+	s.logger.Info("Detected build settings (set binary_source.build_command or build_target to override)",
+		zap.String("chain", chain.GetName()),
+		zap.String("command", redact.String(buildCmd, s.secrets...)),
+		zap.String("command_source", cmdSource),
+		zap.String("target", buildTarget),
+		zap.String("target_source", targetSource),
+	)
+	return buildCmd, buildTarget
+}
+
+// detectBuildCommand prefers `make install`, then `make build`, then installing the daemon's
+// cmd/ package directly; it returns the command and where it came from
+func detectBuildCommand(cloneDir, daemon string) (string, string) {
+	for _, name := range makefileNames {
+		makefile := filepath.Join(cloneDir, name)
+		if _, err := os.Stat(makefile); err != nil {
+			continue
+		}
+		// make only reads the first makefile it finds
+		targets := makefileTargets(makefile)
+		for _, target := range []string{"install", "build"} {
+			if targets[target] {
+				return "make " + target, name + " " + target + " target"
+			}
+		}
+		break
+	}
+
+	if isDir(filepath.Join(cloneDir, "cmd", daemon)) {
+		return "go install ./cmd/" + daemon, "cmd/" + daemon
+	}
+
+	// Nothing recognisable; keep the historical default and let the build report the problem
+	return "make install", "default"
+}
+
+// makefileTargets returns the rule names defined in a Makefile (empty if it can't be read)
+func makefileTargets(path string) map[string]bool {
+	targets := make(map[string]bool)
+	file, err := os.Open(path)
+	if err != nil {
+		return targets
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if match := makeTargetPattern.FindStringSubmatch(scanner.Text()); match != nil {
+			targets[match[1]] = true
+		}
+	}
+	return targets
+}
+
+// detectDaemonName finds the daemon a repository builds: cli when cmd/<cli> exists, else the
+// only cmd/ directory, else the go.mod module name with a "d" suffix when cmd/ has it. It
+// falls back to cli and returns where the name came from
+func detectDaemonName(cloneDir, cli string) (string, string) {
+	cmdDir := filepath.Join(cloneDir, "cmd")
+	if cli != "" && isDir(filepath.Join(cmdDir, cli)) {
+		return cli, "cmd/" + cli
+	}
+
+	var dirs []string
+	if entries, err := os.ReadDir(cmdDir); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				dirs = append(dirs, entry.Name())
+			}
+		}
+	}
+	if len(dirs) == 1 {
+		return dirs[0], "cmd/" + dirs[0]
+	}
+
+	if module := goModuleName(filepath.Join(cloneDir, "go.mod")); module != "" {
+		for _, candidate := range []string{module + "d", module} {
+			if isDir(filepath.Join(cmdDir, candidate)) {
+				return candidate, "go.mod module " + module
+			}
+		}
+	}
+
+	return cli, "cli_name"
+}
+
+// goModuleName returns the last element of go.mod's module path, ignoring a /vN suffix
+// (github.com/osmosis-labs/osmosis/v25 gives "osmosis")
+func goModuleName(goModPath string) string {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "module" {
+			continue
+		}
+		module := strings.Trim(fields[1], `"`)
+		if name := path.Base(module); majorVersionSuffix.MatchString(name) {
+			module = path.Dir(module)
+		}
+		return path.Base(module)
+	}
+	return ""
+}
+
+// isDir reports whether path is an existing directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
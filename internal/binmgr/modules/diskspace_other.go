@@ -0,0 +1,8 @@
+//go:build !(linux || darwin || freebsd)
+
+package modules
+
+// freeDiskSpace cannot measure free space on this platform, so the pre-build check is skipped
+func freeDiskSpace(path string) (free uint64, ok bool, err error) {
+	return 0, false, nil
+}
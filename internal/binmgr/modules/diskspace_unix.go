@@ -0,0 +1,15 @@
+//go:build linux || darwin || freebsd
+
+package modules
+
+import "syscall"
+
+// freeDiskSpace returns the bytes available to unprivileged users on the filesystem holding
+// path; ok is false when the platform cannot report it
+func freeDiskSpace(path string) (free uint64, ok bool, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, false, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), true, nil
+}
@@ -3,20 +3,29 @@ package modules
 import (
 	"archive/tar"
 	"archive/zip"
+	"compress/bzip2"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"prop-voter/config"
+	"prop-voter/internal/redact"
 	"prop-voter/internal/registry"
 
+	"github.com/ulikunitz/xz"
 	"go.uber.org/zap"
 )
 
@@ -40,6 +49,10 @@ type BinaryDownloader struct {
 	client           *http.Client
 	platformDetector *PlatformDetector
 	binDir           string
+	rosettaFallback  bool     // Accept amd64 assets on arm64 macOS when no native one exists
+	libc             string   // Preferred Linux C library when a release has both variants
+	maxDownloadSize  int64    // Largest download or extracted binary in bytes (0 is unlimited)
+	secrets          []string // Configured secret values masked in logged URLs
 }
 
 // NewBinaryDownloader creates a new binary downloader
@@ -52,6 +65,24 @@ func NewBinaryDownloader(logger *zap.Logger, platformDetector *PlatformDetector,
 	}
 }
 
+// SetAssetPreferences sets whether amd64 assets may be used under Rosetta on arm64 macOS and
+// which C library (config.LibcGlibc or config.LibcMusl) Linux assets should target
+func (d *BinaryDownloader) SetAssetPreferences(rosettaFallback bool, libc string) {
+	d.rosettaFallback = rosettaFallback
+	d.libc = libc
+}
+
+// SetMaxDownloadSize caps, in MB, how much a single download or extracted binary may write
+// to disk; 0 removes the limit
+func (d *BinaryDownloader) SetMaxDownloadSize(maxMB int) {
+	d.maxDownloadSize = int64(maxMB) << 20
+}
+
+// SetSecrets sets the configured secret values to mask in logged URLs
+func (d *BinaryDownloader) SetSecrets(secrets ...string) {
+	d.secrets = secrets
+}
+
 // DownloadFromCustomURL downloads a binary from a custom URL
 func (d *BinaryDownloader) DownloadFromCustomURL(ctx context.Context, chain *config.ChainConfig) error {
 	if !chain.HasCustomBinaryURL() {
@@ -61,7 +92,7 @@ func (d *BinaryDownloader) DownloadFromCustomURL(ctx context.Context, chain *con
 	customURL := chain.GetCustomBinaryURL()
 	d.logger.Info("Downloading binary from custom URL",
 		zap.String("chain", chain.GetName()),
-		zap.String("url", customURL),
+		zap.String("url", redact.String(customURL, d.secrets...)),
 	)
 
 	return d.DownloadBinaryFromURL(ctx, chain, customURL, "custom")
@@ -105,10 +136,27 @@ func (d *BinaryDownloader) DownloadFromGitHub(ctx context.Context, chain *config
 
 // DownloadBinaryFromURL downloads a binary from a direct URL (public method)
 func (d *BinaryDownloader) DownloadBinaryFromURL(ctx context.Context, chain *config.ChainConfig, binaryURL, version string) error {
+	return d.DownloadVerifiedBinaryFromURL(ctx, chain, binaryURL, version, "")
+}
+
+// DownloadVerifiedBinaryFromURL downloads a binary from a direct URL published with a
+// go-getter style checksum ("sha256:<hex>" or "sha512:<hex>"), refusing to install it unless
+// the downloaded file matches. An empty checksum skips the check
+func (d *BinaryDownloader) DownloadVerifiedBinaryFromURL(ctx context.Context, chain *config.ChainConfig, binaryURL, version, checksum string) error {
+	var newHash func() hash.Hash
+	var wantSum string
+	if checksum != "" {
+		var err error
+		if newHash, wantSum, err = parseChecksum(checksum); err != nil {
+			return err
+		}
+	}
+
 	d.logger.Info("Downloading binary from URL",
 		zap.String("chain", chain.GetName()),
 		zap.String("version", version),
-		zap.String("url", binaryURL),
+		zap.String("url", redact.String(binaryURL, d.secrets...)),
+		zap.Bool("checksum", checksum != ""),
 	)
 
 	// Create HTTP request
@@ -127,19 +175,84 @@ func (d *BinaryDownloader) DownloadBinaryFromURL(ctx context.Context, chain *con
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("HTTP %d when downloading binary", resp.StatusCode)
 	}
+	if err := d.checkDownloadSize(resp.ContentLength); err != nil {
+		return err
+	}
+
+	body := io.Reader(resp.Body)
+	if newHash != nil {
+		verified, err := d.verifyDownload(resp.Body, newHash, wantSum)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(verified.Name())
+		defer verified.Close()
+		body = verified
+	}
 
 	// Determine file extension and extraction method
 	binaryPath := filepath.Join(d.binDir, chain.GetCLIName())
 
 	// Handle different archive formats
 	if strings.HasSuffix(binaryURL, ".zip") {
-		return d.extractZipBinary(resp.Body, binaryPath, chain.GetCLIName())
-	} else if strings.HasSuffix(binaryURL, ".tar.gz") {
-		return d.extractTarGzBinary(resp.Body, binaryPath, chain.GetCLIName())
+		return d.extractZipBinary(body, binaryPath, chain.GetCLIName())
+	} else if decompress := tarDecompressor(binaryURL); decompress != nil {
+		return d.extractTarBinary(body, binaryPath, chain.GetCLIName(), decompress)
 	} else {
 		// Direct binary download
-		return d.saveBinary(resp.Body, binaryPath)
+		return d.saveBinary(body, binaryPath)
+	}
+}
+
+// parseChecksum splits a go-getter checksum ("sha256:<hex>") into its hash and hex digest
+func parseChecksum(checksum string) (func() hash.Hash, string, error) {
+	algorithm, sum, ok := strings.Cut(checksum, ":")
+	if !ok || sum == "" {
+		return nil, "", fmt.Errorf("invalid checksum %q: expected <type>:<hex>", checksum)
+	}
+	sum = strings.ToLower(sum)
+
+	var newHash func() hash.Hash
+	switch strings.ToLower(algorithm) {
+	case "sha256":
+		newHash = sha256.New
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return nil, "", fmt.Errorf("unsupported checksum type %q (use sha256 or sha512)", algorithm)
+	}
+	if decoded, err := hex.DecodeString(sum); err != nil || len(decoded) != newHash().Size() {
+		return nil, "", fmt.Errorf("invalid %s checksum %q", algorithm, sum)
+	}
+	return newHash, sum, nil
+}
+
+// verifyDownload saves src to a temp file, checking its digest against wantSum, and returns
+// the file rewound for reading; the caller closes and removes it
+func (d *BinaryDownloader) verifyDownload(src io.Reader, newHash func() hash.Hash, wantSum string) (*os.File, error) {
+	tmpFile, err := os.CreateTemp("", "binary-download-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	discard := func() {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+	}
+
+	digest := newHash()
+	if _, err := d.copyLimited(io.MultiWriter(tmpFile, digest), src); err != nil {
+		discard()
+		return nil, fmt.Errorf("failed to save download: %w", err)
 	}
+	if got := hex.EncodeToString(digest.Sum(nil)); got != wantSum {
+		discard()
+		return nil, fmt.Errorf("checksum mismatch: expected %s, downloaded file has %s", wantSum, got)
+	}
+	if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
+		discard()
+		return nil, fmt.Errorf("failed to read download: %w", err)
+	}
+	return tmpFile, nil
 }
 
 // downloadBinaryFromRelease downloads a binary from a specific release
@@ -150,6 +263,10 @@ func (d *BinaryDownloader) downloadBinaryFromRelease(ctx context.Context, chain
 		return fmt.Errorf("failed to find asset: %w", err)
 	}
 
+	if err := d.checkDownloadSize(asset.Size); err != nil {
+		return fmt.Errorf("asset %s: %w", asset.Name, err)
+	}
+
 	d.logger.Info("Downloading binary",
 		zap.String("chain", chain.GetName()),
 		zap.String("version", release.TagName),
@@ -182,7 +299,7 @@ func (d *BinaryDownloader) downloadBinaryFromRelease(ctx context.Context, chain
 	defer tmpFile.Close()
 
 	// Download to temp file
-	if _, err := io.Copy(tmpFile, resp.Body); err != nil {
+	if _, err := d.copyLimited(tmpFile, resp.Body); err != nil {
 		return fmt.Errorf("failed to save download: %w", err)
 	}
 
@@ -195,9 +312,11 @@ func (d *BinaryDownloader) downloadBinaryFromRelease(ctx context.Context, chain
 		return fmt.Errorf("failed to extract binary: %w", err)
 	}
 
-	// Make executable
-	if err := os.Chmod(binaryPath, 0755); err != nil {
-		return fmt.Errorf("failed to make binary executable: %w", err)
+	// Make executable, keeping the archive's permissions when they already allow it
+	if stat, err := os.Stat(binaryPath); err != nil || stat.Mode()&0100 == 0 {
+		if err := os.Chmod(binaryPath, 0755); err != nil {
+			return fmt.Errorf("failed to make binary executable: %w", err)
+		}
 	}
 
 	d.logger.Info("Binary updated successfully",
@@ -209,11 +328,29 @@ func (d *BinaryDownloader) downloadBinaryFromRelease(ctx context.Context, chain
 	return nil
 }
 
+// DownloadFromGitHubTag downloads this platform's asset from the GitHub release tagged tag
+func (d *BinaryDownloader) DownloadFromGitHubTag(ctx context.Context, chain *config.ChainConfig, owner, repo, tag string) error {
+	release, err := d.getReleaseByTag(ctx, owner, repo, tag)
+	if err != nil {
+		return fmt.Errorf("failed to get release %s: %w", tag, err)
+	}
+
+	return d.downloadBinaryFromRelease(ctx, chain, release)
+}
+
 // getLatestRelease gets the latest release from GitHub
 func (d *BinaryDownloader) getLatestRelease(ctx context.Context, repo config.BinaryRepo) (*GitHubRelease, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", repo.Owner, repo.Repo)
+	return d.fetchRelease(ctx, fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", repo.Owner, repo.Repo))
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+// getReleaseByTag gets a specific tagged release from GitHub
+func (d *BinaryDownloader) getReleaseByTag(ctx context.Context, owner, repo, tag string) (*GitHubRelease, error) {
+	return d.fetchRelease(ctx, fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/tags/%s", owner, repo, url.PathEscape(tag)))
+}
+
+// fetchRelease fetches and decodes a GitHub release from the API
+func (d *BinaryDownloader) fetchRelease(ctx context.Context, releaseURL string) (*GitHubRelease, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", releaseURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -238,13 +375,19 @@ func (d *BinaryDownloader) getLatestRelease(ctx context.Context, repo config.Bin
 
 // findAssetForPlatform finds the appropriate asset for the current platform
 func (d *BinaryDownloader) findAssetForPlatform(assets []Asset, pattern string) (*Asset, error) {
+	return d.selectAsset(assets, pattern, d.platformDetector.GetCurrentPlatform())
+}
+
+// selectAsset picks the release asset for platform: a native OS/arch match (of the preferred
+// libc on Linux), then an amd64 build under Rosetta on arm64 macOS, then a pattern match, then
+// any asset for the OS
+func (d *BinaryDownloader) selectAsset(assets []Asset, pattern string, platform *PlatformInfo) (*Asset, error) {
 	// Check if release has no assets at all
 	if len(assets) == 0 {
 		return nil, fmt.Errorf("no binary assets found in release - this chain may not provide pre-compiled binaries")
 	}
 
-	platform := d.platformDetector.GetCurrentPlatform()
-
+	var native []Asset
 	for _, asset := range assets {
 		name := strings.ToLower(asset.Name)
 
@@ -258,33 +401,39 @@ func (d *BinaryDownloader) findAssetForPlatform(assets []Asset, pattern string)
 			return &asset, nil
 		}
 
-		// Check if asset matches our platform using variants
-		osMatch := false
-		archMatch := false
-
-		// Check all OS variants
-		for _, osVariant := range platform.OSVariants {
-			if strings.Contains(name, strings.ToLower(osVariant)) {
-				osMatch = true
-				break
-			}
+		if containsVariant(name, platform.OSVariants) && containsVariant(name, platform.ArchVariants) {
+			native = append(native, asset)
 		}
+	}
 
-		// Check all architecture variants
-		for _, archVariant := range platform.ArchVariants {
-			if strings.Contains(name, strings.ToLower(archVariant)) {
-				archMatch = true
-				break
-			}
+	if len(native) > 0 {
+		asset := native[0]
+		if platform.OS == "linux" {
+			asset = d.preferLibc(native)
 		}
-
-		if osMatch && archMatch {
-			d.logger.Debug("Found platform-specific asset",
-				zap.String("asset", asset.Name),
-				zap.String("os", platform.OS),
-				zap.String("arch", platform.Arch),
-			)
-			return &asset, nil
+		d.logger.Debug("Found platform-specific asset",
+			zap.String("asset", asset.Name),
+			zap.String("os", platform.OS),
+			zap.String("arch", platform.Arch),
+		)
+		return &asset, nil
+	}
+
+	// Apple Silicon runs amd64 macOS binaries through Rosetta 2
+	rosetta := platform.OS == "darwin" && platform.Arch == "arm64"
+	amd64Variants := platformInfo("darwin", "amd64").ArchVariants
+	if rosetta && d.rosettaFallback {
+		for _, asset := range assets {
+			name := strings.ToLower(asset.Name)
+			if pattern != "" && !d.matchesPattern(name, pattern) {
+				continue
+			}
+			if containsVariant(name, platform.OSVariants) && containsVariant(name, amd64Variants) {
+				d.logger.Info("No native arm64 asset, selected amd64 asset to run under Rosetta 2",
+					zap.String("asset", asset.Name),
+				)
+				return &asset, nil
+			}
 		}
 	}
 
@@ -305,11 +454,15 @@ func (d *BinaryDownloader) findAssetForPlatform(assets []Asset, pattern string)
 	// Final fallback: try to find any asset that contains any OS variant
 	for _, asset := range assets {
 		name := strings.ToLower(asset.Name)
+		if rosetta && !d.rosettaFallback && containsVariant(name, amd64Variants) {
+			continue
+		}
 		for _, osVariant := range platform.OSVariants {
 			if strings.Contains(name, strings.ToLower(osVariant)) {
-				d.logger.Debug("Using OS-matched asset without architecture check",
+				d.logger.Warn("Using OS-matched asset without architecture check; it may not be native to this platform",
 					zap.String("asset", asset.Name),
 					zap.String("os_variant", osVariant),
+					zap.String("arch", platform.Arch),
 				)
 				return &asset, nil
 			}
@@ -331,6 +484,32 @@ func (d *BinaryDownloader) findAssetForPlatform(assets []Asset, pattern string)
 		platform.OS, platform.Arch, assetNames)
 }
 
+// preferLibc picks the first Linux asset built against the preferred C library (glibc unless
+// musl is configured), falling back to the first asset when none is
+func (d *BinaryDownloader) preferLibc(assets []Asset) Asset {
+	wantMusl := d.libc == config.LibcMusl
+	for _, asset := range assets {
+		if strings.Contains(strings.ToLower(asset.Name), "musl") == wantMusl {
+			return asset
+		}
+	}
+	d.logger.Info("No asset for the preferred libc, using another variant",
+		zap.String("asset", assets[0].Name),
+		zap.Bool("prefer_musl", wantMusl),
+	)
+	return assets[0]
+}
+
+// containsVariant reports whether name contains any of the variants (case-insensitively)
+func containsVariant(name string, variants []string) bool {
+	for _, variant := range variants {
+		if strings.Contains(name, strings.ToLower(variant)) {
+			return true
+		}
+	}
+	return false
+}
+
 // matchesPattern checks if a string matches a simple pattern (supports * wildcards)
 func (d *BinaryDownloader) matchesPattern(s, pattern string) bool {
 	pattern = strings.ToLower(pattern)
@@ -371,11 +550,35 @@ func (d *BinaryDownloader) matchesPattern(s, pattern string) bool {
 
 // Helper methods for file extraction and handling
 
+// tarballs maps tarball suffixes to the decompressor for their contents
+var tarballs = []struct {
+	suffixes   []string
+	decompress func(io.Reader) (io.Reader, error)
+}{
+	{[]string{".tar.gz", ".tgz"}, func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+	{[]string{".tar.bz2", ".tbz2", ".tbz"}, func(r io.Reader) (io.Reader, error) { return bzip2.NewReader(r), nil }},
+	{[]string{".tar.xz", ".txz"}, func(r io.Reader) (io.Reader, error) { return xz.NewReader(r) }},
+}
+
+// tarDecompressor returns the decompressor for a tarball asset name or URL, or nil if it
+// doesn't name a tarball
+func tarDecompressor(name string) func(io.Reader) (io.Reader, error) {
+	name = strings.ToLower(name)
+	for _, tarball := range tarballs {
+		for _, suffix := range tarball.suffixes {
+			if strings.HasSuffix(name, suffix) {
+				return tarball.decompress
+			}
+		}
+	}
+	return nil
+}
+
 // extractBinary extracts a binary from an archive or copies it if it's not archived
 func (d *BinaryDownloader) extractBinary(srcPath, destPath, binaryName, assetName string) error {
-	if strings.HasSuffix(assetName, ".tar.gz") || strings.HasSuffix(assetName, ".tgz") {
-		return d.extractFromTarGz(srcPath, destPath, binaryName)
-	} else if strings.HasSuffix(assetName, ".zip") {
+	if decompress := tarDecompressor(assetName); decompress != nil {
+		return d.extractFromTar(srcPath, destPath, binaryName, decompress)
+	} else if strings.HasSuffix(strings.ToLower(assetName), ".zip") {
 		return d.extractFromZip(srcPath, destPath, binaryName)
 	} else {
 		// Assume it's a raw binary
@@ -383,21 +586,20 @@ func (d *BinaryDownloader) extractBinary(srcPath, destPath, binaryName, assetNam
 	}
 }
 
-// extractFromTarGz extracts a binary from a tar.gz archive
-func (d *BinaryDownloader) extractFromTarGz(srcPath, destPath, binaryName string) error {
+// extractFromTar extracts a binary from a tarball compressed as decompress expects
+func (d *BinaryDownloader) extractFromTar(srcPath, destPath, binaryName string, decompress func(io.Reader) (io.Reader, error)) error {
 	file, err := os.Open(srcPath)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	gzr, err := gzip.NewReader(file)
+	decompressed, err := decompress(file)
 	if err != nil {
 		return err
 	}
-	defer gzr.Close()
 
-	tr := tar.NewReader(gzr)
+	tr := tar.NewReader(decompressed)
 
 	for {
 		header, err := tr.Next()
@@ -408,17 +610,14 @@ func (d *BinaryDownloader) extractFromTarGz(srcPath, destPath, binaryName string
 			return err
 		}
 
-		// Look for the binary (it might be in a subdirectory)
-		if strings.HasSuffix(header.Name, binaryName) || strings.HasSuffix(header.Name, binaryName+".exe") {
-			outFile, err := os.Create(destPath)
-			if err != nil {
-				return err
-			}
-			defer outFile.Close()
-
-			_, err = io.Copy(outFile, tr)
+		if err := checkArchiveEntry(header.Name); err != nil {
 			return err
 		}
+
+		// Look for the binary (it might be in a subdirectory)
+		if header.Typeflag == tar.TypeReg && isArchivedBinary(header.Name, binaryName) {
+			return d.writeExtractedBinary(tr, destPath, header.FileInfo().Mode())
+		}
 	}
 
 	return fmt.Errorf("binary %s not found in archive", binaryName)
@@ -433,28 +632,94 @@ func (d *BinaryDownloader) extractFromZip(srcPath, destPath, binaryName string)
 	defer r.Close()
 
 	for _, f := range r.File {
+		if err := checkArchiveEntry(f.Name); err != nil {
+			return err
+		}
+
 		// Look for the binary (it might be in a subdirectory)
-		if strings.HasSuffix(f.Name, binaryName) || strings.HasSuffix(f.Name, binaryName+".exe") {
+		if f.Mode().IsRegular() && isArchivedBinary(f.Name, binaryName) {
 			rc, err := f.Open()
 			if err != nil {
 				return err
 			}
 			defer rc.Close()
 
-			outFile, err := os.Create(destPath)
-			if err != nil {
-				return err
-			}
-			defer outFile.Close()
-
-			_, err = io.Copy(outFile, rc)
-			return err
+			return d.writeExtractedBinary(rc, destPath, f.Mode())
 		}
 	}
 
 	return fmt.Errorf("binary %s not found in archive", binaryName)
 }
 
+// checkArchiveEntry rejects an archive entry whose name would escape the directory it is
+// extracted into (an absolute path, a drive letter or ".." climbing above the root), which
+// only a crafted archive contains
+func checkArchiveEntry(name string) error {
+	clean := path.Clean(strings.ReplaceAll(name, `\`, "/"))
+	if path.IsAbs(clean) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" ||
+		clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("refusing to extract archive with unsafe entry %q", name)
+	}
+	return nil
+}
+
+// isArchivedBinary reports whether an archive entry is the binary itself, in any directory,
+// rather than a file that merely ends with its name (a checksum, "notes-gaiad.txt")
+func isArchivedBinary(entryName, binaryName string) bool {
+	base := filepath.Base(entryName)
+	return base == binaryName || base == binaryName+".exe"
+}
+
+// writeExtractedBinary writes an archived binary to destPath with its archived permissions,
+// keeping it at least readable, writable and executable by the owner
+func (d *BinaryDownloader) writeExtractedBinary(r io.Reader, destPath string, mode os.FileMode) error {
+	perm := mode.Perm() | 0700
+	if mode.Perm() == 0 {
+		perm = 0755
+	}
+
+	outFile, err := os.OpenFile(destPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	// A small archive can still decompress to far more than the download limit
+	if _, err := d.copyLimited(outFile, r); err != nil {
+		outFile.Close()
+		os.Remove(destPath)
+		return err
+	}
+	// OpenFile leaves an existing file's mode alone
+	return os.Chmod(destPath, perm)
+}
+
+// checkDownloadSize rejects a download whose advertised size (negative when unknown) is over
+// the limit before any of it is fetched
+func (d *BinaryDownloader) checkDownloadSize(size int64) error {
+	if d.maxDownloadSize > 0 && size > d.maxDownloadSize {
+		return fmt.Errorf("download is %d MB, over the %d MB limit (binary_manager.max_binary_size_mb)", size>>20, d.maxDownloadSize>>20)
+	}
+	return nil
+}
+
+// copyLimited copies src to dst like io.Copy, failing once more than the download limit has
+// been written
+func (d *BinaryDownloader) copyLimited(dst io.Writer, src io.Reader) (int64, error) {
+	if d.maxDownloadSize <= 0 {
+		return io.Copy(dst, src)
+	}
+
+	n, err := io.Copy(dst, io.LimitReader(src, d.maxDownloadSize+1))
+	if err != nil {
+		return n, err
+	}
+	if n > d.maxDownloadSize {
+		return n, fmt.Errorf("download exceeds the %d MB limit (binary_manager.max_binary_size_mb)", d.maxDownloadSize>>20)
+	}
+	return n, nil
+}
+
 // copyFile copies a file from src to dest
 func (d *BinaryDownloader) copyFile(src, dest string) error {
 	srcFile, err := os.Open(src)
@@ -485,7 +750,7 @@ func (d *BinaryDownloader) saveBinary(reader io.Reader, binaryPath string) error
 	defer outFile.Close()
 
 	// Copy the binary data
-	size, err := io.Copy(outFile, reader)
+	size, err := d.copyLimited(outFile, reader)
 	if err != nil {
 		os.Remove(tempPath)
 		return fmt.Errorf("failed to write binary: %w", err)
@@ -524,7 +789,7 @@ func (d *BinaryDownloader) extractZipBinary(reader io.Reader, binaryPath, binary
 	}
 	defer os.Remove(tempPath)
 
-	if _, err := io.Copy(tempFile, reader); err != nil {
+	if _, err := d.copyLimited(tempFile, reader); err != nil {
 		tempFile.Close()
 		return fmt.Errorf("failed to save archive: %w", err)
 	}
@@ -534,22 +799,22 @@ func (d *BinaryDownloader) extractZipBinary(reader io.Reader, binaryPath, binary
 	return d.extractFromZip(tempPath, binaryPath, binaryName)
 }
 
-// extractTarGzBinary extracts a binary from a tar.gz archive stream
-func (d *BinaryDownloader) extractTarGzBinary(reader io.Reader, binaryPath, binaryName string) error {
+// extractTarBinary extracts a binary from a tarball stream compressed as decompress expects
+func (d *BinaryDownloader) extractTarBinary(reader io.Reader, binaryPath, binaryName string, decompress func(io.Reader) (io.Reader, error)) error {
 	// Save to temp file first
-	tempPath := binaryPath + ".tar.gz.tmp"
+	tempPath := binaryPath + ".tar.tmp"
 	tempFile, err := os.Create(tempPath)
 	if err != nil {
 		return fmt.Errorf("failed to create temp file: %w", err)
 	}
 	defer os.Remove(tempPath)
 
-	if _, err := io.Copy(tempFile, reader); err != nil {
+	if _, err := d.copyLimited(tempFile, reader); err != nil {
 		tempFile.Close()
 		return fmt.Errorf("failed to save archive: %w", err)
 	}
 	tempFile.Close()
 
 	// Extract from temp file
-	return d.extractFromTarGz(tempPath, binaryPath, binaryName)
+	return d.extractFromTar(tempPath, binaryPath, binaryName, decompress)
 }
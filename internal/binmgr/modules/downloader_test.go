@@ -0,0 +1,263 @@
+package modules
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"prop-voter/config"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func assetsNamed(names ...string) []Asset {
+	assets := make([]Asset, len(names))
+	for i, name := range names {
+		assets[i] = Asset{Name: name}
+	}
+	return assets
+}
+
+func TestSelectAssetRosettaFallback(t *testing.T) {
+	assets := assetsNamed("gaiad-v1.0.0-darwin-amd64", "gaiad-v1.0.0-linux-amd64")
+	darwinARM := platformInfo("darwin", "arm64")
+
+	downloader := NewBinaryDownloader(zaptest.NewLogger(t), NewPlatformDetector(zaptest.NewLogger(t)), t.TempDir())
+	downloader.SetAssetPreferences(true, "")
+	asset, err := downloader.selectAsset(assets, "", darwinARM)
+	if err != nil || asset.Name != "gaiad-v1.0.0-darwin-amd64" {
+		t.Errorf("Expected the amd64 macOS asset under Rosetta, got %v, %v", asset, err)
+	}
+
+	// A native asset always wins
+	native := append(assetsNamed("gaiad-v1.0.0-darwin-arm64"), assets...)
+	if asset, err := downloader.selectAsset(native, "", darwinARM); err != nil || asset.Name != "gaiad-v1.0.0-darwin-arm64" {
+		t.Errorf("Expected the native arm64 asset, got %v, %v", asset, err)
+	}
+
+	downloader.SetAssetPreferences(false, "")
+	if asset, err := downloader.selectAsset(assets, "", darwinARM); err == nil {
+		t.Errorf("Expected no asset with the Rosetta fallback disabled, got %s", asset.Name)
+	}
+}
+
+func TestSelectAssetLibcPreference(t *testing.T) {
+	assets := assetsNamed("osmosisd-linux-amd64-musl", "osmosisd-linux-amd64", "osmosisd-darwin-amd64")
+	linuxAMD := platformInfo("linux", "amd64")
+	downloader := NewBinaryDownloader(zaptest.NewLogger(t), NewPlatformDetector(zaptest.NewLogger(t)), t.TempDir())
+
+	for _, tt := range []struct {
+		libc string
+		want string
+	}{
+		{"", "osmosisd-linux-amd64"},
+		{config.LibcGlibc, "osmosisd-linux-amd64"},
+		{config.LibcMusl, "osmosisd-linux-amd64-musl"},
+	} {
+		downloader.SetAssetPreferences(true, tt.libc)
+		if asset, err := downloader.selectAsset(assets, "", linuxAMD); err != nil || asset.Name != tt.want {
+			t.Errorf("libc %q: expected %s, got %v, %v", tt.libc, tt.want, asset, err)
+		}
+	}
+
+	// With only one variant available, it is used whatever the preference
+	downloader.SetAssetPreferences(true, config.LibcMusl)
+	if asset, err := downloader.selectAsset(assets[1:], "", linuxAMD); err != nil || asset.Name != "osmosisd-linux-amd64" {
+		t.Errorf("Expected the glibc asset as the only Linux build, got %v, %v", asset, err)
+	}
+}
+
+func TestExtractBinaryTarballs(t *testing.T) {
+	downloader := NewBinaryDownloader(zaptest.NewLogger(t), NewPlatformDetector(zaptest.NewLogger(t)), t.TempDir())
+
+	// Each fixture holds release/README.md and release/gaiad
+	for _, fixture := range []string{"gaiad.tar.gz", "gaiad.tar.bz2", "gaiad.tar.xz"} {
+		t.Run(fixture, func(t *testing.T) {
+			dest := filepath.Join(t.TempDir(), "gaiad")
+			if err := downloader.extractBinary(filepath.Join("testdata", fixture), dest, "gaiad", "gaiad-v1.0.0-linux-amd64"+fixture[len("gaiad"):]); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			content, err := os.ReadFile(dest)
+			if err != nil || string(content) != "#!/bin/sh\necho v1.0.0\n" {
+				t.Errorf("Expected the binary extracted from the archive, got %q, %v", content, err)
+			}
+		})
+	}
+
+	if err := downloader.extractBinary(filepath.Join("testdata", "gaiad.tar.xz"), filepath.Join(t.TempDir(), "osmosisd"), "osmosisd", "osmosisd.tar.xz"); err == nil {
+		t.Error("Expected a missing binary to be reported")
+	}
+}
+
+func TestDownloadBinaryFromURLTarball(t *testing.T) {
+	archive, err := os.ReadFile(filepath.Join("testdata", "gaiad.tar.bz2"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	binDir := t.TempDir()
+	downloader := NewBinaryDownloader(zaptest.NewLogger(t), NewPlatformDetector(zaptest.NewLogger(t)), binDir)
+	chain := &config.ChainConfig{Name: "Cosmos Hub", CLIName: "gaiad"}
+	if err := downloader.DownloadBinaryFromURL(context.Background(), chain, server.URL+"/gaiad-linux-amd64.tar.bz2", "v1.0.0"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if content, err := os.ReadFile(filepath.Join(binDir, "gaiad")); err != nil || string(content) != "#!/bin/sh\necho v1.0.0\n" {
+		t.Errorf("Expected the binary extracted from the downloaded tarball, got %q, %v", content, err)
+	}
+}
+
+func TestExtractBinaryExactNameAndMode(t *testing.T) {
+	// A checksum and a look-alike precede the binary, which sits in a nested directory
+	entries := []struct {
+		name string
+		mode int64
+		body string
+	}{
+		{"osmosisd.sha256", 0644, "checksum"},
+		{"docs/something-osmosisd", 0644, "not the binary"},
+		{"osmosis-v25/bin/osmosisd", 0750, "binary"},
+	}
+
+	var tarball bytes.Buffer
+	gz := gzip.NewWriter(&tarball)
+	tw := tar.NewWriter(gz)
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	for _, entry := range entries {
+		if err := tw.WriteHeader(&tar.Header{Name: entry.name, Mode: entry.mode, Size: int64(len(entry.body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("Failed to write tar header: %v", err)
+		}
+		tw.Write([]byte(entry.body))
+
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Store}
+		header.SetMode(os.FileMode(entry.mode))
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			t.Fatalf("Failed to write zip header: %v", err)
+		}
+		w.Write([]byte(entry.body))
+	}
+	tw.Close()
+	gz.Close()
+	zw.Close()
+
+	dir := t.TempDir()
+	downloader := NewBinaryDownloader(zaptest.NewLogger(t), NewPlatformDetector(zaptest.NewLogger(t)), dir)
+	for name, archive := range map[string][]byte{"osmosisd.tar.gz": tarball.Bytes(), "osmosisd.zip": zipped.Bytes()} {
+		src := filepath.Join(dir, name)
+		if err := os.WriteFile(src, archive, 0644); err != nil {
+			t.Fatalf("Failed to write archive: %v", err)
+		}
+		dest := filepath.Join(dir, name+".out")
+		if err := downloader.extractBinary(src, dest, "osmosisd", name); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		content, _ := os.ReadFile(dest)
+		if string(content) != "binary" {
+			t.Errorf("%s: expected the nested binary to be extracted, got %q", name, content)
+		}
+		if stat, err := os.Stat(dest); err != nil || stat.Mode().Perm() != 0750 {
+			t.Errorf("%s: expected the archived mode 0750 to be kept, got %v", name, stat.Mode().Perm())
+		}
+	}
+}
+
+func TestCheckArchiveEntry(t *testing.T) {
+	for _, name := range []string{"gaiad", "bin/gaiad", "./release/gaiad", "a/../gaiad"} {
+		if err := checkArchiveEntry(name); err != nil {
+			t.Errorf("Expected %q to be accepted, got: %v", name, err)
+		}
+	}
+	for _, name := range []string{"../gaiad", "bin/../../gaiad", "/usr/local/bin/gaiad", `..\..\gaiad`, ".."} {
+		if err := checkArchiveEntry(name); err == nil {
+			t.Errorf("Expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestExtractBinaryRejectsPathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	downloader := NewBinaryDownloader(zaptest.NewLogger(t), NewPlatformDetector(zaptest.NewLogger(t)), dir)
+
+	// The malicious entry is named like the binary, so a name-only match would pick it
+	var tarball bytes.Buffer
+	gz := gzip.NewWriter(&tarball)
+	tw := tar.NewWriter(gz)
+	tw.WriteHeader(&tar.Header{Name: "../../tmp/gaiad", Mode: 0755, Size: 4, Typeflag: tar.TypeReg})
+	tw.Write([]byte("evil"))
+	tw.Close()
+	gz.Close()
+
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	w, _ := zw.Create("/etc/gaiad")
+	w.Write([]byte("evil"))
+	zw.Close()
+
+	for name, archive := range map[string][]byte{"evil.tar.gz": tarball.Bytes(), "evil.zip": zipped.Bytes()} {
+		src := filepath.Join(dir, name)
+		if err := os.WriteFile(src, archive, 0644); err != nil {
+			t.Fatalf("Failed to write archive: %v", err)
+		}
+		dest := filepath.Join(dir, "gaiad")
+		err := downloader.extractBinary(src, dest, "gaiad", name)
+		if err == nil || !strings.Contains(err.Error(), "unsafe entry") {
+			t.Errorf("%s: expected the traversal entry to be rejected, got: %v", name, err)
+		}
+		if _, err := os.Stat(dest); !os.IsNotExist(err) {
+			t.Errorf("%s: expected nothing to be extracted", name)
+		}
+	}
+}
+
+func TestDownloadSizeLimit(t *testing.T) {
+	body := bytes.Repeat([]byte{'x'}, 2<<20)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			// No Content-Length, so only the copy itself can enforce the limit
+			w.(http.Flusher).Flush()
+		}
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	binDir := t.TempDir()
+	downloader := NewBinaryDownloader(zaptest.NewLogger(t), NewPlatformDetector(zaptest.NewLogger(t)), binDir)
+	downloader.SetMaxDownloadSize(1)
+	chain := &config.ChainConfig{Name: "Cosmos Hub", CLIName: "gaiad"}
+
+	for _, path := range []string{"/gaiad", "/chunked"} {
+		err := downloader.DownloadBinaryFromURL(context.Background(), chain, server.URL+path, "v1.0.0")
+		if err == nil || !strings.Contains(err.Error(), "1 MB limit") {
+			t.Errorf("%s: expected the download to exceed the limit, got: %v", path, err)
+		}
+		if _, err := os.Stat(filepath.Join(binDir, "gaiad")); !os.IsNotExist(err) {
+			t.Errorf("%s: expected no binary to be installed", path)
+		}
+	}
+
+	// A release asset advertising a size over the limit is never fetched
+	release := &GitHubRelease{TagName: "v1.0.0", Assets: []Asset{{Name: "gaiad", BrowserDownloadURL: server.URL + "/gaiad", Size: 2 << 20}}}
+	chain.BinaryRepo.AssetPattern = "gaiad"
+	if err := downloader.downloadBinaryFromRelease(context.Background(), chain, release); err == nil || !strings.Contains(err.Error(), "over the 1 MB limit") {
+		t.Errorf("Expected the oversized asset to be rejected, got: %v", err)
+	}
+
+	downloader.SetMaxDownloadSize(4)
+	if err := downloader.DownloadBinaryFromURL(context.Background(), chain, server.URL+"/chunked", "v1.0.0"); err != nil {
+		t.Errorf("Expected a download under the limit to succeed, got: %v", err)
+	}
+}
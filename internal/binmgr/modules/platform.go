@@ -32,9 +32,11 @@ func NewPlatformDetector(logger *zap.Logger) *PlatformDetector {
 
 // GetCurrentPlatform returns detailed platform information with variants
 func (p *PlatformDetector) GetCurrentPlatform() *PlatformInfo {
-	os := runtime.GOOS
-	arch := runtime.GOARCH
+	return platformInfo(runtime.GOOS, runtime.GOARCH)
+}
 
+// platformInfo returns the platform information and name variants for an OS and architecture
+func platformInfo(os, arch string) *PlatformInfo {
 	osVariants := []string{os}
 	archVariants := []string{arch}
 
@@ -8,9 +8,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"prop-voter/config"
+	"prop-voter/internal/redact"
 
 	"go.uber.org/zap"
 )
@@ -22,6 +24,9 @@ type SourceCompiler struct {
 	binaryFinder     *BinaryFinder
 	goVersionManager *GoVersionManager
 	binDir           string
+	buildDir         string   // Parent of the temporary clone directory (TMPDIR or the system default when empty)
+	minFreeDiskMB    int      // Free space required in buildDir and binDir before cloning (0 disables the check)
+	secrets          []string // Configured secret values masked in logged commands
 }
 
 // NewSourceCompiler creates a new source compiler
@@ -35,6 +40,18 @@ func NewSourceCompiler(logger *zap.Logger, platformDetector *PlatformDetector, b
 	}
 }
 
+// ConfigureBuild sets where sources are cloned and built, and how much free disk space (in
+// MB) that location and the bin directory need before a build starts
+func (s *SourceCompiler) ConfigureBuild(buildDir string, minFreeDiskMB int) {
+	s.buildDir = buildDir
+	s.minFreeDiskMB = minFreeDiskMB
+}
+
+// SetSecrets sets the configured secret values to mask in logged build commands
+func (s *SourceCompiler) SetSecrets(secrets ...string) {
+	s.secrets = secrets
+}
+
 // CompileFromSource compiles a binary from source code
 func (s *SourceCompiler) CompileFromSource(ctx context.Context, chain *config.ChainConfig) error {
 	sourceRepo := chain.GetSourceRepo()
@@ -48,8 +65,17 @@ func (s *SourceCompiler) CompileFromSource(ctx context.Context, chain *config.Ch
 		zap.String("branch", chain.GetSourceBranch()),
 	)
 
-	// Create temporary directory for cloning
-	tempDir, err := os.MkdirTemp("", "prop-voter-build-*")
+	if s.buildDir != "" {
+		if err := os.MkdirAll(s.buildDir, 0755); err != nil {
+			return fmt.Errorf("failed to create build directory: %w", err)
+		}
+	}
+	if err := s.checkDiskSpace(chain); err != nil {
+		return err
+	}
+
+	// Create temporary directory for cloning (os.MkdirTemp honors TMPDIR when buildDir is unset)
+	tempDir, err := os.MkdirTemp(s.buildDir, "prop-voter-build-*")
 	if err != nil {
 		return fmt.Errorf("failed to create temp directory: %w", err)
 	}
@@ -59,13 +85,12 @@ func (s *SourceCompiler) CompileFromSource(ctx context.Context, chain *config.Ch
 	cloneDir := filepath.Join(tempDir, "source")
 	branch := chain.GetSourceBranch()
 
-	if err := s.cloneRepository(ctx, sourceRepo, branch, cloneDir); err != nil {
+	if err := s.cloneRepository(ctx, sourceRepo, branch, cloneDir, chain.BinarySource.Submodules); err != nil {
 		return err
 	}
 
 	// Build the binary
-	buildCmd := chain.GetBuildCommand()
-	buildTarget := chain.GetBuildTarget()
+	buildCmd, buildTarget := s.detectBuild(chain, cloneDir)
 
 	if err := s.buildBinary(ctx, chain, cloneDir, buildCmd, buildTarget); err != nil {
 		return err
@@ -74,41 +99,157 @@ func (s *SourceCompiler) CompileFromSource(ctx context.Context, chain *config.Ch
 	return nil
 }
 
-// cloneRepository clones a git repository
-func (s *SourceCompiler) cloneRepository(ctx context.Context, sourceRepo, branch, cloneDir string) error {
+// checkDiskSpace fails fast when the build directory or bin directory has less free space than
+// the configured minimum, rather than letting the clone or build fail partway through
+func (s *SourceCompiler) checkDiskSpace(chain *config.ChainConfig) error {
+	if s.minFreeDiskMB <= 0 {
+		return nil
+	}
+
+	buildDir := s.buildDir
+	if buildDir == "" {
+		buildDir = os.TempDir()
+	}
+	required := uint64(s.minFreeDiskMB) << 20
+
+	for _, dir := range []string{buildDir, s.binDir} {
+		if dir == "" {
+			continue
+		}
+		free, ok, err := freeDiskSpace(existingParent(dir))
+		if err != nil {
+			s.logger.Warn("Could not check free disk space, building anyway",
+				zap.String("dir", dir),
+				zap.Error(err),
+			)
+			continue
+		}
+		if !ok {
+			return nil
+		}
+		if free < required {
+			return fmt.Errorf("not enough disk space to build %s: %s has %d MB free, need at least %d MB (free up space, point binary_manager.build_dir elsewhere or lower min_free_disk_mb)",
+				chain.GetCLIName(), dir, free>>20, s.minFreeDiskMB)
+		}
+		s.logger.Debug("Disk space check passed",
+			zap.String("dir", dir),
+			zap.Uint64("free_mb", free>>20),
+			zap.Int("required_mb", s.minFreeDiskMB),
+		)
+	}
+
+	return nil
+}
+
+// existingParent returns dir, or its nearest ancestor that exists
+func existingParent(dir string) string {
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// commitSHAPattern matches an abbreviated or full git commit SHA
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
+// cloneRepository shallow-clones ref (a branch or tag) of a git repository, falling back to
+// fetching and checking out ref as a commit SHA when git cannot clone it as a branch
+func (s *SourceCompiler) cloneRepository(ctx context.Context, sourceRepo, ref, cloneDir string, submodules bool) error {
 	s.logger.Info("Cloning repository",
 		zap.String("repo", sourceRepo),
-		zap.String("branch", branch),
+		zap.String("ref", ref),
 		zap.String("dir", cloneDir),
+		zap.Bool("submodules", submodules),
 	)
 
-	cloneCmd := exec.CommandContext(ctx, "git", "clone", "--depth", "1", "--branch", branch, sourceRepo, cloneDir)
-	cloneCmd.Env = os.Environ() // Inherit environment for git as well
-	if output, err := cloneCmd.CombinedOutput(); err != nil {
+	args := []string{"clone", "--depth", "1", "--branch", ref}
+	if submodules {
+		args = append(args, "--recurse-submodules", "--shallow-submodules")
+	}
+	output, err := s.runGit(ctx, "", append(args, sourceRepo, cloneDir)...)
+	if err == nil {
+		return nil
+	}
+	if !commitSHAPattern.MatchString(ref) {
 		return fmt.Errorf("failed to clone repository: %w\nOutput: %s", err, output)
 	}
 
+	s.logger.Info("Ref is not a branch or tag, checking it out as a commit",
+		zap.String("repo", sourceRepo),
+		zap.String("commit", ref),
+	)
+	// A failed clone may leave a partial directory behind
+	if err := os.RemoveAll(cloneDir); err != nil {
+		return fmt.Errorf("failed to clean up clone directory: %w", err)
+	}
+	return s.checkoutCommit(ctx, sourceRepo, ref, cloneDir, submodules)
+}
+
+// checkoutCommit checks out a single commit of a repository, fetching just that commit when
+// the server allows it and the full history otherwise (e.g. for abbreviated SHAs)
+func (s *SourceCompiler) checkoutCommit(ctx context.Context, sourceRepo, commit, cloneDir string, submodules bool) error {
+	if err := os.MkdirAll(cloneDir, 0755); err != nil {
+		return fmt.Errorf("failed to create clone directory: %w", err)
+	}
+
+	for _, args := range [][]string{{"init", "--quiet"}, {"remote", "add", "origin", sourceRepo}} {
+		if output, err := s.runGit(ctx, cloneDir, args...); err != nil {
+			return fmt.Errorf("failed to prepare repository: %w\nOutput: %s", err, output)
+		}
+	}
+
+	target := "FETCH_HEAD"
+	if _, err := s.runGit(ctx, cloneDir, "fetch", "--depth", "1", "origin", commit); err != nil {
+		s.logger.Debug("Shallow fetch of commit failed, fetching full history",
+			zap.String("commit", commit),
+			zap.Error(err),
+		)
+		if output, err := s.runGit(ctx, cloneDir, "fetch", "--tags", "origin"); err != nil {
+			return fmt.Errorf("failed to fetch repository: %w\nOutput: %s", err, output)
+		}
+		target = commit
+	}
+
+	if output, err := s.runGit(ctx, cloneDir, "checkout", "--quiet", target); err != nil {
+		return fmt.Errorf("failed to check out commit %s: %w\nOutput: %s", commit, err, output)
+	}
+
+	if submodules {
+		if output, err := s.runGit(ctx, cloneDir, "submodule", "update", "--init", "--recursive", "--depth", "1"); err != nil {
+			return fmt.Errorf("failed to update submodules: %w\nOutput: %s", err, output)
+		}
+	}
+
 	return nil
 }
 
+// runGit runs a git command in dir (the current directory when empty) and returns its output
+func (s *SourceCompiler) runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = os.Environ() // Inherit environment for git as well
+	return cmd.CombinedOutput()
+}
+
 // buildBinary builds the binary using the specified build command
 func (s *SourceCompiler) buildBinary(ctx context.Context, chain *config.ChainConfig, cloneDir, buildCmd, buildTarget string) error {
 	// If ignoring Go version, use a build command that bypasses version checks
 	if chain.BinarySource.IgnoreGoVersion {
+		originalCmd := buildCmd
 		buildCmd = s.getBuildCommandForIgnoreGoVersion(cloneDir, buildTarget, buildCmd)
 		s.logger.Info("Using Go version bypass build command",
 			zap.String("chain", chain.GetName()),
-			zap.String("original_command", chain.GetBuildCommand()),
-			zap.String("bypass_command", buildCmd),
+			zap.String("original_command", redact.String(originalCmd, s.secrets...)),
+			zap.String("bypass_command", redact.String(buildCmd, s.secrets...)),
 		)
 	}
 
-	s.logger.Info("Building binary",
-		zap.String("command", buildCmd),
-		zap.String("target", buildTarget),
-		zap.String("dir", cloneDir),
-	)
-
 	// Parse command and environment variables
 	envVars, cleanCmd := s.parseEnvVarsFromCommand(buildCmd)
 
@@ -126,21 +267,33 @@ func (s *SourceCompiler) buildBinary(ctx context.Context, chain *config.ChainCon
 		return err
 	}
 
-	// Add any command-specific environment variables
-	for key, value := range envVars {
+	// Layer the chain's build_env, then variables set in the command itself, then build tags
+	overrides := s.buildEnvOverrides(chain, buildExecCmd.Env, envVars)
+	keys := make([]string, 0, len(overrides))
+	for key, value := range overrides {
 		buildExecCmd.Env = s.platformDetector.UpdateEnvVar(buildExecCmd.Env, key, value)
-		s.logger.Debug("Added environment variable for build",
-			zap.String("key", key),
-			zap.String("value", value),
-		)
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	loggedEnv := make([]string, 0, len(keys))
+	for _, key := range keys {
+		loggedEnv = append(loggedEnv, key+"="+redactEnvValue(key, overrides[key], s.secrets...))
+	}
+
+	s.logger.Info("Building binary",
+		zap.String("command", redact.String(cleanCmd, s.secrets...)),
+		zap.Strings("env", loggedEnv),
+		zap.Strings("build_tags", chain.GetBuildTags()),
+		zap.String("target", buildTarget),
+		zap.String("dir", cloneDir),
+	)
 
 	// Execute build and capture output
 	output, err := buildExecCmd.CombinedOutput()
 
 	if err != nil {
 		s.logger.Error("Build failed",
-			zap.String("command", buildCmd),
+			zap.String("command", redact.String(buildCmd, s.secrets...)),
 			zap.Error(err),
 			zap.String("output", string(output)),
 		)
@@ -167,7 +320,7 @@ func (s *SourceCompiler) buildBinary(ctx context.Context, chain *config.ChainCon
 	}
 
 	s.logger.Info("Build completed successfully",
-		zap.String("command", buildCmd),
+		zap.String("command", redact.String(buildCmd, s.secrets...)),
 		zap.Int("output_length", len(output)),
 	)
 
@@ -183,6 +336,46 @@ func (s *SourceCompiler) buildBinary(ctx context.Context, chain *config.ChainCon
 	return s.findAndInstallBinary(chain, cloneDir, buildTarget)
 }
 
+// buildEnvOverrides returns the variables a build sets on top of env: the chain's build_env
+// (names upper-cased, since config keys are read lower-case), then inlineVars from the build
+// command, then the chain's build tags merged into GOFLAGS and exported as BUILD_TAGS, which
+// Cosmos SDK Makefiles append to their own tags
+func (s *SourceCompiler) buildEnvOverrides(chain *config.ChainConfig, env []string, inlineVars map[string]string) map[string]string {
+	overrides := make(map[string]string)
+	for key, value := range chain.BinarySource.BuildEnv {
+		overrides[strings.ToUpper(key)] = value
+	}
+	for key, value := range inlineVars {
+		overrides[key] = value
+	}
+
+	if tags := chain.GetBuildTags(); len(tags) > 0 {
+		goFlags, ok := overrides["GOFLAGS"]
+		if !ok {
+			goFlags = s.platformDetector.GetEnvVar(env, "GOFLAGS")
+		}
+		overrides["GOFLAGS"] = strings.TrimSpace(goFlags + " -tags=" + strings.Join(tags, ","))
+		buildTags := strings.Join(tags, " ")
+		if existing, ok := overrides["BUILD_TAGS"]; ok && existing != "" {
+			buildTags = existing + " " + buildTags
+		}
+		overrides["BUILD_TAGS"] = buildTags
+	}
+
+	return overrides
+}
+
+// sensitiveEnvKey matches environment variable names whose values should never be logged
+var sensitiveEnvKey = regexp.MustCompile(`(?i)(token|secret|password|passphrase|key|auth)`)
+
+// redactEnvValue masks a build environment value for logging
+func redactEnvValue(key, value string, secrets ...string) string {
+	if sensitiveEnvKey.MatchString(key) {
+		return redact.Mask
+	}
+	return redact.String(value, secrets...)
+}
+
 // setupBuildEnvironment sets up the build environment with proper Go paths
 func (s *SourceCompiler) setupBuildEnvironment(buildExecCmd *exec.Cmd) error {
 	// Start with inherited environment
@@ -217,7 +410,7 @@ func (s *SourceCompiler) setupBuildEnvironment(buildExecCmd *exec.Cmd) error {
 	)
 
 	s.logger.Info("Starting build process",
-		zap.String("command", strings.Join(buildExecCmd.Args, " ")),
+		zap.String("command", redact.Command(buildExecCmd.Args, s.secrets...)),
 		zap.String("working_dir", buildExecCmd.Dir),
 	)
 
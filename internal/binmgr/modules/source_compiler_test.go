@@ -0,0 +1,229 @@
+package modules
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"prop-voter/config"
+	"prop-voter/internal/redact"
+
+	"go.uber.org/zap/zaptest"
+)
+
+// testRepo creates a local git repository with a tagged first commit (v1.0.0) and a second
+// commit on main, returning its file:// URL and the first commit's SHA
+func testRepo(t *testing.T) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	git("init", "--quiet", "--initial-branch", "main")
+	write("v1")
+	git("add", "VERSION")
+	git("commit", "--quiet", "-m", "first")
+	git("tag", "v1.0.0")
+	first := git("rev-parse", "HEAD")
+	write("v2")
+	git("commit", "--quiet", "-am", "second")
+
+	return "file://" + dir, first
+}
+
+func readVersion(t *testing.T, cloneDir string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(cloneDir, "VERSION"))
+	if err != nil {
+		t.Fatalf("Failed to read cloned file: %v", err)
+	}
+	return string(content)
+}
+
+func TestCloneRepositoryRefs(t *testing.T) {
+	repo, first := testRepo(t)
+	compiler := NewSourceCompiler(zaptest.NewLogger(t), NewPlatformDetector(zaptest.NewLogger(t)), nil, t.TempDir())
+
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{"branch", "main", "v2"},
+		{"tag", "v1.0.0", "v1"},
+		{"commit", first, "v1"},
+		{"abbreviated commit", first[:10], "v1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cloneDir := filepath.Join(t.TempDir(), "source")
+			if err := compiler.cloneRepository(context.Background(), repo, tt.ref, cloneDir, false); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := readVersion(t, cloneDir); got != tt.want {
+				t.Errorf("Expected %s checked out, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCloneRepositoryUnknownRef(t *testing.T) {
+	repo, _ := testRepo(t)
+	compiler := NewSourceCompiler(zaptest.NewLogger(t), NewPlatformDetector(zaptest.NewLogger(t)), nil, t.TempDir())
+
+	cloneDir := filepath.Join(t.TempDir(), "source")
+	if err := compiler.cloneRepository(context.Background(), repo, "no-such-branch", cloneDir, false); err == nil {
+		t.Error("Expected an unknown branch to fail to clone")
+	}
+	if err := compiler.cloneRepository(context.Background(), repo, "0123456789abcdef", cloneDir, false); err == nil {
+		t.Error("Expected an unknown commit to fail to check out")
+	}
+}
+
+func TestBuildEnvOverrides(t *testing.T) {
+	compiler := NewSourceCompiler(zaptest.NewLogger(t), NewPlatformDetector(zaptest.NewLogger(t)), nil, t.TempDir())
+	chain := &config.ChainConfig{BinarySource: config.BinarySource{
+		BuildTags: []string{"netgo ledger", "pebbledb"},
+		BuildEnv:  map[string]string{"cgo_enabled": "1", "LEDGER_ENABLED": "true"},
+	}}
+
+	overrides := compiler.buildEnvOverrides(chain, []string{"GOFLAGS=-mod=readonly"}, map[string]string{"LEDGER_ENABLED": "false"})
+
+	want := map[string]string{
+		"CGO_ENABLED":    "1",
+		"LEDGER_ENABLED": "false",
+		"GOFLAGS":        "-mod=readonly -tags=netgo,ledger,pebbledb",
+		"BUILD_TAGS":     "netgo ledger pebbledb",
+	}
+	for key, value := range want {
+		if overrides[key] != value {
+			t.Errorf("Expected %s=%q, got %q", key, value, overrides[key])
+		}
+	}
+	if len(overrides) != len(want) {
+		t.Errorf("Expected %d overrides, got %v", len(want), overrides)
+	}
+
+	// Without tags, only the configured variables are set
+	chain.BinarySource.BuildTags = nil
+	if overrides := compiler.buildEnvOverrides(chain, nil, nil); overrides["GOFLAGS"] != "" || len(overrides) != 2 {
+		t.Errorf("Expected just build_env without tags, got %v", overrides)
+	}
+}
+
+func TestRedactEnvValue(t *testing.T) {
+	if got := redactEnvValue("GITHUB_TOKEN", "ghp_abc123"); got != redact.Mask {
+		t.Errorf("Expected a token variable to be masked, got %q", got)
+	}
+	if got := redactEnvValue("CGO_ENABLED", "1"); got != "1" {
+		t.Errorf("Expected CGO_ENABLED to be logged as is, got %q", got)
+	}
+	// Configured secrets are masked wherever they appear
+	if got := redactEnvValue("LDFLAGS", "-X main.webhook=hunter2", "hunter2"); strings.Contains(got, "hunter2") {
+		t.Errorf("Expected a configured secret to be masked, got %q", got)
+	}
+}
+
+// writeRepoFiles creates files (relative path to content) under dir; paths ending in "/" are
+// created as directories
+func writeRepoFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if strings.HasSuffix(name, "/") {
+			if err := os.MkdirAll(path, 0755); err != nil {
+				t.Fatalf("Failed to create %s: %v", name, err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestDetectBuild(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		source     config.BinarySource
+		wantCmd    string
+		wantTarget string
+	}{
+		{
+			name:       "make install target",
+			files:      map[string]string{"Makefile": "VERSION := 1.0\nbuild: go.sum\n\tgo build ./...\ninstall:\n\tgo install ./cmd/gaiad\n", "cmd/gaiad/": ""},
+			wantCmd:    "make install",
+			wantTarget: "gaiad",
+		},
+		{
+			name:       "make build only",
+			files:      map[string]string{"Makefile": "install_deps := yes\nbuild:\n\tgo build -o build/ ./...\n"},
+			wantCmd:    "make build",
+			wantTarget: "gaiad",
+		},
+		{
+			name:       "no Makefile, single cmd directory",
+			files:      map[string]string{"go.mod": "module github.com/org/chain\n", "cmd/chaind/": ""},
+			wantCmd:    "go install ./cmd/chaind",
+			wantTarget: "chaind",
+		},
+		{
+			name:       "daemon from versioned go.mod module",
+			files:      map[string]string{"go.mod": "module github.com/osmosis-labs/osmosis/v25\n", "cmd/osmosisd/": "", "cmd/querygen/": ""},
+			wantCmd:    "go install ./cmd/osmosisd",
+			wantTarget: "osmosisd",
+		},
+		{
+			name:       "configured values win",
+			files:      map[string]string{"Makefile": "install:\n", "cmd/chaind/": ""},
+			source:     config.BinarySource{BuildCommand: "make build-linux", BuildTarget: "other"},
+			wantCmd:    "make build-linux",
+			wantTarget: "other",
+		},
+		{
+			name:       "nothing recognisable",
+			files:      map[string]string{"README.md": "hi"},
+			wantCmd:    "make install",
+			wantTarget: "gaiad",
+		},
+	}
+
+	compiler := NewSourceCompiler(zaptest.NewLogger(t), NewPlatformDetector(zaptest.NewLogger(t)), nil, t.TempDir())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeRepoFiles(t, dir, tt.files)
+			chain := &config.ChainConfig{Name: "Test", CLIName: "gaiad", BinarySource: tt.source}
+
+			cmd, target := compiler.detectBuild(chain, dir)
+			if cmd != tt.wantCmd || target != tt.wantTarget {
+				t.Errorf("Expected %q building %s, got %q building %s", tt.wantCmd, tt.wantTarget, cmd, target)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// descriptors are the @-shorthands accepted in place of the five fields
+var descriptors = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// dayNames lets the weekday field use names (sun-sat) as well as 0-7
+var dayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+
+// field describes one position of a cron expression
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7, names: dayNames},
+}
+
+// Schedule is a parsed five-field cron expression (minute hour day-of-month month
+// day-of-week) evaluated in a fixed location
+type Schedule struct {
+	minutes, hours, days, months, weekdays map[int]bool
+	// As in cron, when both day fields are restricted (don't start with *) a time matches
+	// either of them
+	daysRestricted, weekdaysRestricted bool
+	location                           *time.Location
+}
+
+// Parse parses a cron expression such as "0 9 * * 1-5" or a descriptor such as "@daily".
+// Fields accept *, numbers, ranges (a-b), lists (a,b) and steps (*/n, a-b/n); the weekday
+// field also accepts sun-sat, and both 0 and 7 mean Sunday. A nil location means UTC
+func Parse(expr string, location *time.Location) (*Schedule, error) {
+	if location == nil {
+		location = time.UTC
+	}
+	spec := strings.TrimSpace(expr)
+	if descriptor, ok := descriptors[strings.ToLower(spec)]; ok {
+		spec = descriptor
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields (minute hour day month weekday)", expr)
+	}
+
+	sets := make([]map[int]bool, len(fields))
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", expr, err)
+		}
+		sets[i] = set
+	}
+	if sets[4][7] {
+		sets[4][0] = true
+	}
+
+	return &Schedule{
+		minutes:            sets[0],
+		hours:              sets[1],
+		days:               sets[2],
+		months:             sets[3],
+		weekdays:           sets[4],
+		daysRestricted:     !strings.HasPrefix(parts[2], "*"),
+		weekdaysRestricted: !strings.HasPrefix(parts[4], "*"),
+		location:           location,
+	}, nil
+}
+
+// parseField expands one comma-separated field into the values it matches
+func parseField(part string, f field) (map[int]bool, error) {
+	set := make(map[int]bool)
+	for _, item := range strings.Split(part, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step in %s field %q", f.name, item)
+			}
+			rangePart, step = item[:i], n
+		}
+
+		low, high := f.min, f.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = f.value(bounds[0]); err != nil {
+				return nil, err
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = f.value(bounds[1]); err != nil {
+					return nil, err
+				}
+			} else if step > 1 {
+				// "5/15" means from 5 to the end of the range
+				high = f.max
+			}
+			if high < low {
+				return nil, fmt.Errorf("invalid range in %s field %q", f.name, item)
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// value parses a single number or name, checking it is within the field's range
+func (f field) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s value %q must be between %d and %d", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// maxSearch bounds Next for expressions that can never match, e.g. "0 0 31 2 *"
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first matching minute strictly after t, or the zero time if the
+// expression never matches
+func (s *Schedule) Next(t time.Time) time.Time {
+	next := t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := next.Add(maxSearch)
+
+	for next.Before(limit) {
+		switch {
+		case !s.months[int(next.Month())]:
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, s.location)
+		case !s.dayMatches(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, s.location)
+		case !s.hours[next.Hour()]:
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, s.location)
+		case !s.minutes[next.Minute()]:
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches applies cron's day-of-month / day-of-week rule
+func (s *Schedule) dayMatches(t time.Time) bool {
+	day, weekday := s.days[t.Day()], s.weekdays[int(t.Weekday())]
+	if s.daysRestricted && s.weekdaysRestricted {
+		return day || weekday
+	}
+	return day && weekday
+}
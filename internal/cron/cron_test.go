@@ -0,0 +1,71 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// Wednesday 2024-01-10 08:30 UTC
+	from := time.Date(2024, 1, 10, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"0 9 * * *", time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)},
+		{"30 8 * * *", time.Date(2024, 1, 11, 8, 30, 0, 0, time.UTC)}, // strictly after
+		{"0 9 * * mon", time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 0", time.Date(2024, 1, 14, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 7", time.Date(2024, 1, 14, 9, 0, 0, 0, time.UTC)},
+		{"*/20 * * * *", time.Date(2024, 1, 10, 8, 40, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1,15 * *", time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either matches (the 20th, or a Friday)
+		{"0 9 20 * fri", time.Date(2024, 1, 12, 9, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		schedule, err := Parse(tt.expr, nil)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %v", tt.expr, err)
+			continue
+		}
+		if got := schedule.Next(from); !got.Equal(tt.want) {
+			t.Errorf("%q: expected %s, got %s", tt.expr, tt.want, got)
+		}
+	}
+}
+
+func TestScheduleLocation(t *testing.T) {
+	location := time.FixedZone("UTC+2", 2*60*60)
+	schedule, err := Parse("0 9 * * *", location)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// 09:00 at UTC+2 is 07:00 UTC
+	got := schedule.Next(time.Date(2024, 1, 10, 6, 0, 0, 0, time.UTC))
+	if want := time.Date(2024, 1, 10, 7, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Expected %s, got %s", want, got.UTC())
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, expr := range []string{"", "0 9 * *", "60 * * * *", "0 24 * * *", "0 9 0 * *", "0 9 * 13 *", "0 9 * * 8", "*/0 * * * *", "5-1 * * * *", "0 9 * * funday", "@yearly"} {
+		if _, err := Parse(expr, nil); err == nil {
+			t.Errorf("Expected %q to be rejected", expr)
+		}
+	}
+
+	// A valid expression that never matches yields the zero time
+	schedule, err := Parse("0 0 31 2 *", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if next := schedule.Next(time.Now()); !next.IsZero() {
+		t.Errorf("Expected no match for February 31st, got %s", next)
+	}
+}
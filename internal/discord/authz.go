@@ -0,0 +1,183 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"prop-voter/config"
+	"prop-voter/internal/models"
+	"prop-voter/internal/voting"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// authzExpiryWarning is a grant problem on one chain that should be reported
+type authzExpiryWarning struct {
+	Expiration time.Time // Zero when the bot holds no vote grant at all
+	Expired    bool
+}
+
+// hasAuthzChains reports whether any configured chain votes via authz
+func (b *Bot) hasAuthzChains() bool {
+	for i := range b.config.Chains {
+		if b.config.Chains[i].IsAuthzEnabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAuthzGrantExpiry periodically warns about authz grants that expire within the
+// configured window, checking once at startup so a lapsed grant is reported straight away
+func (b *Bot) checkAuthzGrantExpiry(ctx context.Context) {
+	ticker := time.NewTicker(b.config.AuthzExpiry.Interval)
+	defer ticker.Stop()
+
+	for {
+		b.checkAuthzGrants(time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkAuthzGrants queries the vote grant from every granter of each authz-enabled chain and
+// sends any warnings not already sent for the same grant
+func (b *Bot) checkAuthzGrants(now time.Time) {
+	for i := range b.config.Chains {
+		chainConfig := &b.config.Chains[i]
+		if !chainConfig.IsAuthzEnabled() {
+			continue
+		}
+
+		for _, granter := range chainConfig.GetGranters() {
+			b.checkAuthzGrant(chainConfig, granter, now)
+		}
+	}
+}
+
+// checkAuthzGrant sends a warning about one granter's vote grant unless it was already sent
+func (b *Bot) checkAuthzGrant(chainConfig *config.ChainConfig, granter config.AuthzGranter, now time.Time) {
+	status, err := b.voter.QueryAuthzGrants(chainConfig.GetChainID(), granter.Address)
+	if err != nil {
+		b.logger.Warn("Failed to check authz grant expiry",
+			zap.String("chain", chainConfig.GetChainID()),
+			zap.String("granter", granter.Address),
+			zap.Error(err),
+		)
+		return
+	}
+
+	warning, err := b.pendingAuthzWarning(chainConfig.GetChainID(), status, now)
+	if err != nil {
+		b.logger.Error("Failed to load authz warning state",
+			zap.String("chain", chainConfig.GetChainID()),
+			zap.String("granter", granter.Address),
+			zap.Error(err),
+		)
+		return
+	}
+	if warning == nil {
+		return
+	}
+
+	b.sendEmbed(b.notificationChannel(chainConfig.GetChainID()), b.buildAuthzExpiryEmbed(chainConfig, status, *warning, now))
+
+	if err := b.recordAuthzWarning(chainConfig.GetChainID(), status.Granter, *warning, now); err != nil {
+		b.logger.Error("Failed to record authz warning",
+			zap.String("chain", chainConfig.GetChainID()),
+			zap.String("granter", granter.Address),
+			zap.Error(err),
+		)
+	}
+}
+
+// pendingAuthzWarning returns the warning status calls for, or nil when the grant outlasts
+// the window or the same warning was already sent
+func (b *Bot) pendingAuthzWarning(chainID string, status *voting.AuthzGrantStatus, now time.Time) (*authzExpiryWarning, error) {
+	var warning authzExpiryWarning
+	if active := status.ActiveGrant(now); active != nil {
+		if active.Expiration == nil || active.Expiration.After(now.Add(b.config.AuthzExpiry.Window)) {
+			return nil, nil
+		}
+		warning.Expiration = *active.Expiration
+	} else {
+		// Report the most recently lapsed grant, if the chain still lists one
+		warning.Expired = true
+		for _, grant := range status.Grants {
+			if grant.Expiration != nil && grant.Expiration.After(warning.Expiration) {
+				warning.Expiration = *grant.Expiration
+			}
+		}
+	}
+
+	var last models.AuthzGrantWarning
+	err := b.db.Where("chain_id = ? AND granter = ?", chainID, status.Granter).First(&last).Error
+	if err == gorm.ErrRecordNotFound {
+		return &warning, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if last.Expiration.Equal(warning.Expiration) && last.Expired == warning.Expired {
+		return nil, nil
+	}
+	return &warning, nil
+}
+
+// recordAuthzWarning stores the warning just sent for a chain's granter, replacing the
+// previous one
+func (b *Bot) recordAuthzWarning(chainID, granter string, warning authzExpiryWarning, now time.Time) error {
+	record := models.AuthzGrantWarning{ChainID: chainID, Granter: granter}
+	if err := b.db.Where("chain_id = ? AND granter = ?", chainID, granter).FirstOrInit(&record).Error; err != nil {
+		return err
+	}
+
+	record.Expiration = warning.Expiration
+	record.Expired = warning.Expired
+	record.WarnedAt = now
+	return b.db.Save(&record).Error
+}
+
+// buildAuthzExpiryEmbed renders a warning for a grant that is expiring, lapsed or missing
+func (b *Bot) buildAuthzExpiryEmbed(chainConfig *config.ChainConfig, status *voting.AuthzGrantStatus, warning authzExpiryWarning, now time.Time) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("⚠️ Authz Grant Expiring - %s", chainConfig.GetName()),
+		Color: 0xff9900, // Orange
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "🏛️ Granter", Value: fmt.Sprintf("%s\n`%s`", chainConfig.GranterDisplayName(status.Granter), status.Granter), Inline: false},
+			{Name: "🤖 Grantee (bot wallet)", Value: fmt.Sprintf("`%s`", status.Grantee), Inline: false},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Check with: !authz-status %s", chainConfig.GetChainID()),
+		},
+		Timestamp: now.Format(time.RFC3339),
+	}
+
+	switch {
+	case !warning.Expired:
+		embed.Description = fmt.Sprintf("The gov vote grant expires <t:%d:R>. Renew it before then or authz votes will fail.", warning.Expiration.Unix())
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "⏰ Expires",
+			Value:  fmt.Sprintf("%s (in %s)", warning.Expiration.UTC().Format("2006-01-02 15:04 UTC"), formatDuration(warning.Expiration.Sub(now))),
+			Inline: false,
+		})
+	case !warning.Expiration.IsZero():
+		embed.Title = fmt.Sprintf("❌ Authz Grant Expired - %s", chainConfig.GetName())
+		embed.Color = 0xff0000 // Red
+		embed.Description = fmt.Sprintf("The gov vote grant expired <t:%d:R>. Authz votes will fail until the granter renews it.", warning.Expiration.Unix())
+	default:
+		embed.Title = fmt.Sprintf("❌ No Authz Grant - %s", chainConfig.GetName())
+		embed.Color = 0xff0000 // Red
+		embed.Description = "The bot's wallet holds no gov vote grant from the granter. Authz votes will fail until one is granted."
+	}
+
+	return embed
+}
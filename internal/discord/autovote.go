@@ -0,0 +1,407 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"prop-voter/config"
+	"prop-voter/internal/models"
+	"prop-voter/internal/voting"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// vetoEmoji is the reaction that vetoes a scheduled auto-vote
+const vetoEmoji = "🛑"
+
+// autoVoteVoter is the Vote.VotedBy of votes cast by an auto-vote rule
+const autoVoteVoter = "auto-vote"
+
+// autoVoteMargin is how long before the end of voting an auto-vote is cast at the latest,
+// when the configured delay would run past it
+const autoVoteMargin = 10 * time.Minute
+
+// runAutoVotes schedules auto-votes for newly announced proposals at the notification
+// interval, and casts each one when its veto window closes. Pending auto-votes are stored, so
+// ones that fell due while the bot was down are cast (or found vetoed) straight after a restart
+func (b *Bot) runAutoVotes(ctx context.Context) {
+	var pending int64
+	if err := b.db.Model(&models.AutoVote{}).Where("status = ?", models.AutoVotePending).Count(&pending).Error; err == nil && pending > 0 {
+		b.logger.Info("Resuming pending auto-votes", zap.Int64("pending", pending))
+	}
+
+	for {
+		now := time.Now()
+		b.scheduleAutoVotes(now)
+		b.castDueAutoVotes(now)
+
+		timer := time.NewTimer(b.nextAutoVoteCheck(time.Now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// nextAutoVoteCheck returns how long to wait before the next check: the notification
+// interval, or less when a pending auto-vote falls due sooner
+func (b *Bot) nextAutoVoteCheck(now time.Time) time.Duration {
+	wait := b.notificationInterval()
+
+	var next models.AutoVote
+	err := b.db.Where("status = ?", models.AutoVotePending).Order("due_at ASC").First(&next).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			b.logger.Error("Failed to fetch the next auto-vote", zap.Error(err))
+		}
+		return wait
+	}
+	if untilDue := next.DueAt.Sub(now); untilDue < wait {
+		// Never spin: a due auto-vote whose veto check failed is retried a second later
+		return max(untilDue, time.Second)
+	}
+	return wait
+}
+
+// autoVoteChains returns the IDs of chains with auto-vote rules
+func (b *Bot) autoVoteChains() []string {
+	var chainIDs []string
+	for chainID, chain := range b.chains {
+		if len(chain.AutoVote) > 0 {
+			chainIDs = append(chainIDs, chainID)
+		}
+	}
+	sort.Strings(chainIDs)
+	return chainIDs
+}
+
+// scheduleAutoVotes announces an auto-vote for every announced, unvoted proposal in its voting
+// period that matches one of its chain's rules. Announced means a Discord notification was
+// posted: notification_sent alone is also set for proposals the notification filter hid and
+// for history stored on the first scan. Each proposal is considered once: a proposal whose
+// voting ends too soon for a veto window is recorded as skipped
+func (b *Bot) scheduleAutoVotes(now time.Time) {
+	chainIDs := b.autoVoteChains()
+	if len(chainIDs) == 0 {
+		return
+	}
+
+	var candidates []models.Proposal
+	err := b.db.Preload("Vote").Scopes(models.NotArchived, models.InVotingPeriod).
+		Where("notification_sent = ? AND notification_message_id <> '' AND chain_id IN ?", true, chainIDs).
+		Where("NOT EXISTS (SELECT 1 FROM auto_votes WHERE auto_votes.chain_id = proposals.chain_id AND auto_votes.proposal_id = proposals.proposal_id)").
+		Find(&candidates).Error
+	if err != nil {
+		b.logger.Error("Failed to fetch proposals for auto-voting", zap.Error(err))
+		return
+	}
+
+	for i := range candidates {
+		proposal := &candidates[i]
+		if proposal.Vote != nil {
+			continue
+		}
+		rule := b.chains[proposal.ChainID].MatchAutoVote(proposal.Types()...)
+		if rule == nil {
+			continue
+		}
+		b.scheduleAutoVote(proposal, rule, now)
+	}
+}
+
+// scheduleAutoVote records an auto-vote for a proposal and announces it in reply to the
+// proposal's notification
+func (b *Bot) scheduleAutoVote(proposal *models.Proposal, rule *config.AutoVoteRule, now time.Time) {
+	autoVote := models.AutoVote{
+		ChainID:    proposal.ChainID,
+		ProposalID: proposal.ProposalID,
+		Option:     rule.Option,
+		Rule:       rule.String(),
+		DueAt:      now.Add(b.config.AutoVote.Delay),
+		Status:     models.AutoVotePending,
+	}
+	if proposal.VotingEnd != nil && autoVote.DueAt.After(proposal.VotingEnd.Add(-autoVoteMargin)) {
+		autoVote.DueAt = proposal.VotingEnd.Add(-autoVoteMargin)
+	}
+
+	logFields := []zap.Field{
+		zap.String("chain", proposal.ChainID),
+		zap.String("proposal", proposal.ProposalID),
+		zap.String("option", autoVote.Option),
+		zap.String("rule", autoVote.Rule),
+	}
+
+	if !autoVote.DueAt.After(now) {
+		autoVote.Status = models.AutoVoteSkipped
+		autoVote.Detail = "voting ends too soon to allow a veto"
+		if err := b.db.Create(&autoVote).Error; err != nil {
+			b.logger.Error("Failed to record skipped auto-vote", append(logFields, zap.Error(err))...)
+		}
+		b.logger.Info("Auto-vote skipped, voting ends too soon to allow a veto", logFields...)
+		return
+	}
+
+	message, err := b.sendProposalUpdate(proposal, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{buildAutoVoteEmbed(proposal, &autoVote, now)},
+	})
+	if err != nil {
+		// Without the announcement nobody could veto; try again on the next check
+		b.logger.Error("Failed to announce auto-vote", append(logFields, zap.Error(err))...)
+		return
+	}
+	autoVote.ChannelID, autoVote.MessageID = message.ChannelID, message.ID
+
+	// Pre-add the veto reaction so vetoing is one click; the bot's own reaction doesn't count
+	if err := b.session.MessageReactionAdd(message.ChannelID, message.ID, vetoEmoji); err != nil {
+		b.logger.Debug("Failed to add the veto reaction", append(logFields, zap.Error(err))...)
+	}
+
+	if err := b.db.Create(&autoVote).Error; err != nil {
+		// Unrecorded, the proposal is picked up again on the next check; remove this
+		// announcement so it isn't posted twice and no veto lands on a vote that won't happen
+		b.logger.Error("Failed to record auto-vote", append(logFields, zap.Error(err))...)
+		if err := b.session.ChannelMessageDelete(message.ChannelID, message.ID); err != nil {
+			b.logger.Warn("Failed to delete unrecorded auto-vote announcement", append(logFields, zap.Error(err))...)
+		}
+		return
+	}
+	b.logger.Info("Auto-vote scheduled", append(logFields, zap.Time("due_at", autoVote.DueAt))...)
+}
+
+// buildAutoVoteEmbed announces a scheduled auto-vote and how to veto it
+func buildAutoVoteEmbed(proposal *models.Proposal, autoVote *models.AutoVote, now time.Time) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("🤖 Auto-vote - %s Proposal #%s", proposal.ChainID, proposal.ProposalID),
+		Description: fmt.Sprintf("Auto-voting **%s** in %s unless vetoed",
+			strings.ToUpper(autoVote.Option), formatDuration(autoVote.DueAt.Sub(now).Round(time.Minute))),
+		Color:     0x9b59b6, // Purple
+		Timestamp: now.Format(time.RFC3339),
+	}
+	if proposal.Title != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "📋 Proposal", Value: proposal.Title, Inline: false})
+	}
+	embed.Fields = append(embed.Fields,
+		&discordgo.MessageEmbedField{Name: "📏 Rule", Value: autoVote.Rule, Inline: true},
+		&discordgo.MessageEmbedField{Name: "⏰ Votes", Value: fmt.Sprintf("<t:%d:R>", autoVote.DueAt.Unix()), Inline: true},
+		&discordgo.MessageEmbedField{Name: "🛑 Veto", Value: fmt.Sprintf("An authorized user can react %s to this message before then to cancel the vote", vetoEmoji), Inline: false},
+	)
+	return embed
+}
+
+// castDueAutoVotes casts every pending auto-vote whose veto window has passed, unless it was
+// vetoed or no longer applies
+func (b *Bot) castDueAutoVotes(now time.Time) {
+	var due []models.AutoVote
+	err := b.db.Where("status = ? AND due_at <= ?", models.AutoVotePending, now).Order("due_at ASC").Find(&due).Error
+	if err != nil {
+		b.logger.Error("Failed to fetch due auto-votes", zap.Error(err))
+		return
+	}
+
+	for i := range due {
+		b.castAutoVote(&due[i])
+	}
+}
+
+// castAutoVote makes the final checks on a due auto-vote and casts it. It holds autoVoteMu
+// throughout so a veto arriving mid-vote waits and then finds the vote already cast
+func (b *Bot) castAutoVote(autoVote *models.AutoVote) {
+	b.autoVoteMu.Lock()
+	defer b.autoVoteMu.Unlock()
+
+	// A veto may have landed since the due auto-votes were fetched
+	if err := b.db.First(autoVote, autoVote.ID).Error; err != nil || autoVote.Status != models.AutoVotePending {
+		return
+	}
+
+	logFields := []zap.Field{
+		zap.String("chain", autoVote.ChainID),
+		zap.String("proposal", autoVote.ProposalID),
+		zap.String("option", autoVote.Option),
+		zap.String("rule", autoVote.Rule),
+	}
+
+	// Reactions missed while the gateway was down count as well
+	vetoedBy, err := b.findVeto(autoVote)
+	if err != nil {
+		b.logger.Warn("Failed to check auto-vote for a veto, retrying on the next check", append(logFields, zap.Error(err))...)
+		return
+	}
+	if vetoedBy != "" {
+		b.vetoAutoVote(autoVote, vetoedBy)
+		return
+	}
+
+	var proposal models.Proposal
+	if err := b.db.Preload("Vote").Where("chain_id = ? AND proposal_id = ?", autoVote.ChainID, autoVote.ProposalID).First(&proposal).Error; err != nil {
+		b.finishAutoVote(autoVote, models.AutoVoteSkipped, fmt.Sprintf("proposal not found: %v", err))
+		return
+	}
+	switch {
+	case proposal.Vote != nil:
+		b.finishAutoVote(autoVote, models.AutoVoteSkipped, fmt.Sprintf("already voted %s", proposal.Vote.Option))
+		return
+	case proposal.Status != "PROPOSAL_STATUS_VOTING_PERIOD":
+		b.finishAutoVote(autoVote, models.AutoVoteSkipped, fmt.Sprintf("proposal is %s", proposal.Status))
+		return
+	}
+
+	// The rules may have changed since the vote was announced (e.g. edited before a restart);
+	// casting under a different rule would skip that rule's veto window
+	if !b.autoVoteRuleApplies(autoVote, &proposal) {
+		b.finishAutoVote(autoVote, models.AutoVoteSkipped, fmt.Sprintf("rule %q no longer applies", autoVote.Rule))
+		b.replyToAutoVote(autoVote, fmt.Sprintf("⏭️ Auto-vote **%s** on %s proposal **#%s** not cast: the rule (%s) is no longer configured. Vote manually if you want a vote cast.",
+			autoVote.Option, autoVote.ChainID, autoVote.ProposalID, autoVote.Rule))
+		return
+	}
+
+	b.logger.Info("Casting auto-vote", logFields...)
+	result, err := b.voter.Vote(autoVote.ChainID, autoVote.ProposalID, autoVote.Option, voting.TxOptions{})
+	if err != nil {
+		b.logger.Error("Auto-vote failed", append(logFields, zap.Error(err))...)
+		b.finishAutoVote(autoVote, models.AutoVoteFailed, err.Error())
+		details := err.Error()
+		if len(details) > 1500 {
+			details = details[:1500] + "...\n[Error truncated - check server logs for full details]"
+		}
+		b.replyToAutoVote(autoVote, fmt.Sprintf("❌ **Auto-vote failed** on %s proposal **#%s** - vote manually if needed:\n```\n%s\n```",
+			autoVote.ChainID, autoVote.ProposalID, details))
+		return
+	}
+
+	vote := models.Vote{
+		ChainID:    autoVote.ChainID,
+		ProposalID: autoVote.ProposalID,
+		Option:     autoVote.Option,
+		TxHash:     result.TxHash,
+		VotedAt:    time.Now(),
+		VotedBy:    autoVoteVoter,
+		Rationale:  "Auto-vote rule: " + autoVote.Rule,
+	}
+	if err := b.db.Create(&vote).Error; err != nil {
+		b.logger.Error("Failed to store vote", zap.Error(err))
+	}
+	b.finishAutoVote(autoVote, models.AutoVoteVoted, "tx "+result.TxHash)
+	b.logger.Info("Auto-vote cast", append(logFields, zap.String("tx_hash", result.TxHash))...)
+
+	b.replyToAutoVote(autoVote, fmt.Sprintf("🤖 Auto-voted **%s** on %s proposal **#%s** - tx `%s`",
+		autoVote.Option, autoVote.ChainID, autoVote.ProposalID, result.TxHash))
+}
+
+// autoVoteRuleApplies reports whether the chain's current rules still pick the rule and option
+// the auto-vote was announced under
+func (b *Bot) autoVoteRuleApplies(autoVote *models.AutoVote, proposal *models.Proposal) bool {
+	chainConfig := b.chains[autoVote.ChainID]
+	if chainConfig == nil {
+		return false
+	}
+	rule := chainConfig.MatchAutoVote(proposal.Types()...)
+	return rule != nil && rule.String() == autoVote.Rule && rule.Option == autoVote.Option
+}
+
+// findVeto returns the first authorized user who reacted 🛑 to the auto-vote's announcement.
+// A deleted announcement counts as a veto, and is forgotten so nothing replies to it
+func (b *Bot) findVeto(autoVote *models.AutoVote) (string, error) {
+	users, err := b.session.MessageReactions(autoVote.ChannelID, autoVote.MessageID, vetoEmoji, 100, "", "")
+	if err != nil {
+		var restErr *discordgo.RESTError
+		if errors.As(err, &restErr) && restErr.Message != nil && restErr.Message.Code == discordgo.ErrCodeUnknownMessage {
+			autoVote.MessageID = ""
+			return "announcement deleted", nil
+		}
+		return "", err
+	}
+
+	for _, user := range users {
+		if !user.Bot && b.authorizeUser(b.session, user.ID, "", nil) {
+			return fmt.Sprintf("<@%s>", user.ID), nil
+		}
+	}
+	return "", nil
+}
+
+// messageReactionAddHandler vetoes a pending auto-vote as soon as an authorized user reacts
+// 🛑 to its announcement
+func (b *Bot) messageReactionAddHandler(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+	if r.Emoji.Name != vetoEmoji || (s.State != nil && s.State.User != nil && r.UserID == s.State.User.ID) {
+		return
+	}
+
+	b.autoVoteMu.Lock()
+	defer b.autoVoteMu.Unlock()
+
+	var autoVote models.AutoVote
+	err := b.db.Where("message_id = ? AND status = ?", r.MessageID, models.AutoVotePending).First(&autoVote).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			b.logger.Error("Failed to look up auto-vote for reaction", zap.Error(err))
+		}
+		return
+	}
+
+	if !b.authorizeUser(s, r.UserID, r.GuildID, r.Member) {
+		b.logger.Warn("Ignoring auto-vote veto from unauthorized user",
+			zap.String("user_id", r.UserID),
+			zap.String("chain", autoVote.ChainID),
+			zap.String("proposal", autoVote.ProposalID),
+		)
+		return
+	}
+	b.vetoAutoVote(&autoVote, fmt.Sprintf("<@%s>", r.UserID))
+}
+
+// vetoAutoVote cancels a pending auto-vote and says so in reply to its announcement
+func (b *Bot) vetoAutoVote(autoVote *models.AutoVote, vetoedBy string) {
+	b.finishAutoVote(autoVote, models.AutoVoteVetoed, "vetoed by "+vetoedBy)
+	b.logger.Info("Auto-vote vetoed",
+		zap.String("chain", autoVote.ChainID),
+		zap.String("proposal", autoVote.ProposalID),
+		zap.String("option", autoVote.Option),
+		zap.String("vetoed_by", vetoedBy),
+	)
+	b.replyToAutoVote(autoVote, fmt.Sprintf("🛑 Auto-vote **%s** on %s proposal **#%s** vetoed (%s). Vote manually if you want a vote cast.",
+		autoVote.Option, autoVote.ChainID, autoVote.ProposalID, vetoedBy))
+}
+
+// finishAutoVote moves an auto-vote out of pending
+func (b *Bot) finishAutoVote(autoVote *models.AutoVote, status, detail string) {
+	if status == models.AutoVoteSkipped {
+		b.logger.Info("Auto-vote skipped",
+			zap.String("chain", autoVote.ChainID),
+			zap.String("proposal", autoVote.ProposalID),
+			zap.String("reason", detail),
+		)
+	}
+
+	autoVote.Status, autoVote.Detail = status, detail
+	if err := b.db.Save(autoVote).Error; err != nil {
+		b.logger.Error("Failed to update auto-vote",
+			zap.String("chain", autoVote.ChainID),
+			zap.String("proposal", autoVote.ProposalID),
+			zap.Error(err),
+		)
+	}
+}
+
+// replyToAutoVote posts a message in reply to an auto-vote's announcement
+func (b *Bot) replyToAutoVote(autoVote *models.AutoVote, content string) {
+	message := &discordgo.MessageSend{Content: content}
+	if autoVote.MessageID != "" {
+		message.Reference = &discordgo.MessageReference{MessageID: autoVote.MessageID, ChannelID: autoVote.ChannelID}
+	}
+	channelID := autoVote.ChannelID
+	if channelID == "" {
+		channelID = b.notificationChannel(autoVote.ChainID)
+	}
+	if _, err := b.session.ChannelMessageSendComplex(channelID, message); err != nil {
+		b.logger.Error("Failed to send auto-vote update", zap.Error(err))
+	}
+}
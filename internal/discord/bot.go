@@ -3,16 +3,28 @@ package discord
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"prop-voter/config"
+	"prop-voter/internal/binmgr"
+	"prop-voter/internal/health"
 	"prop-voter/internal/models"
+	"prop-voter/internal/redact"
+	"prop-voter/internal/scanner"
+	"prop-voter/internal/version"
 	"prop-voter/internal/voting"
+	"prop-voter/internal/webhook"
 
 	"github.com/bwmarrin/discordgo"
 	"go.uber.org/zap"
@@ -26,9 +38,46 @@ type Bot struct {
 	config     *config.Config
 	logger     *zap.Logger
 	voter      *voting.Voter
+	scanner    *scanner.Scanner
+	webhook    *webhook.Notifier
+	binaries   *binmgr.Manager
+	health     *health.Status
 	notifyChan chan models.Proposal
+	chains     map[string]*config.ChainConfig // Chains keyed by chain ID
+	webhooks   sync.WaitGroup                 // In-flight webhook deliveries
+
+	pendingMu    sync.Mutex
+	pendingVotes map[string]pendingVote
+
+	voteSecret secretHash  // Salted hash of the configured vote secret
+	secrets    secretGuard // Failed vote secret attempts and lockouts
+
+	tallyParamsMu sync.Mutex
+	tallyParams   map[string]*tallyParams // Gov tallying params keyed by chain ID
+
+	autoVoteMu sync.Mutex // Serializes casting and vetoing auto-votes
 }
 
+// pendingVote is a vote, authz vote or deposit awaiting confirmation by the user who requested it
+type pendingVote struct {
+	Action      string // What is being confirmed, e.g. "Vote" or "Deposit"
+	ChainID     string
+	ProposalID  string
+	Option      string
+	UserID      string
+	ChannelID   string
+	TxOptions   voting.TxOptions
+	Rationale   string
+	Submit      func() // Broadcasts the request once confirmed
+	RequestedAt time.Time
+}
+
+// pendingVoteTTL is how long a confirmation prompt stays valid
+const pendingVoteTTL = 5 * time.Minute
+
+// defaultNotificationInterval is used when discord.notification_interval is unset
+const defaultNotificationInterval = 1 * time.Minute
+
 // NewBot creates a new Discord bot instance
 func NewBot(db *gorm.DB, config *config.Config, logger *zap.Logger, voter *voting.Voter) (*Bot, error) {
 	session, err := discordgo.New("Bot " + config.Discord.Token)
@@ -36,22 +85,140 @@ func NewBot(db *gorm.DB, config *config.Config, logger *zap.Logger, voter *votin
 		return nil, fmt.Errorf("failed to create Discord session: %w", err)
 	}
 
+	// Commands are checked against the hash; the config keeps the plaintext, which the
+	// voter and binary manager need to redact it from logs
+	voteSecret, err := newSecretHash(config.Security.VoteSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash vote secret: %w", err)
+	}
+
 	bot := &Bot{
-		session:    session,
-		db:         db,
-		config:     config,
-		logger:     logger,
-		voter:      voter,
-		notifyChan: make(chan models.Proposal, 100),
+		session:      session,
+		db:           db,
+		config:       config,
+		logger:       logger,
+		voter:        voter,
+		notifyChan:   make(chan models.Proposal, 100),
+		chains:       config.ChainsByID(),
+		pendingVotes: make(map[string]pendingVote),
+		voteSecret:   voteSecret,
 	}
 
 	// Register message and interaction handlers
 	session.AddHandler(bot.messageHandler)
 	session.AddHandler(bot.interactionHandler)
+	session.AddHandler(bot.connectHandler)
+	session.AddHandler(bot.disconnectHandler)
+	session.AddHandler(bot.resumedHandler)
+	session.AddHandler(bot.messageReactionAddHandler)
 
 	return bot, nil
 }
 
+// SetScanner attaches the proposal scanner so commands can report scan status
+func (b *Bot) SetScanner(s *scanner.Scanner) {
+	b.scanner = s
+}
+
+// notificationChannel returns the channel a chain's notifications go to: its own channel_id
+// when set, otherwise the global channel
+func (b *Bot) notificationChannel(chainID string) string {
+	if chain := b.chains[chainID]; chain != nil && chain.ChannelID != "" {
+		return chain.ChannelID
+	}
+	return b.config.Discord.ChannelID
+}
+
+// isCommandChannel reports whether commands are accepted in channelID: the global channel or
+// any chain's notification channel
+func (b *Bot) isCommandChannel(channelID string) bool {
+	if channelID == b.config.Discord.ChannelID {
+		return true
+	}
+	for _, chain := range b.chains {
+		if chain.ChannelID != "" && chain.ChannelID == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifyUpgradeStaged reports the outcome of staging the binary for a passed software upgrade
+func (b *Bot) NotifyUpgradeStaged(proposal models.Proposal, path string, err error) {
+	target := fmt.Sprintf("**%s** (proposal #%s)", proposal.UpgradeName, proposal.ProposalID)
+	if proposal.UpgradeHeight > 0 {
+		target += fmt.Sprintf(" at height **%d**", proposal.UpgradeHeight)
+	}
+
+	if err != nil {
+		b.sendMessage(b.notificationChannel(proposal.ChainID), fmt.Sprintf("⚠️ **Upgrade binary staging failed** for %s upgrade %s\n```\n%v\n```",
+			proposal.ChainID, target, err))
+		return
+	}
+
+	b.sendMessage(b.notificationChannel(proposal.ChainID), fmt.Sprintf("📦 **Upgrade binary staged** for %s upgrade %s\nPath: `%s`\nThe running binary was not replaced - swap it in at the upgrade height.",
+		proposal.ChainID, target, path))
+}
+
+// SetBinaryManager attaches the binary manager so commands can report installed binary versions
+func (b *Bot) SetBinaryManager(m *binmgr.Manager) {
+	b.binaries = m
+}
+
+// SetHealthStatus publishes the Discord connection state to the health endpoint
+func (b *Bot) SetHealthStatus(status *health.Status) {
+	b.health = status
+}
+
+// connectHandler records that the gateway connection is up
+func (b *Bot) connectHandler(s *discordgo.Session, c *discordgo.Connect) {
+	b.logConnected("Connected to Discord")
+	if b.health != nil {
+		b.health.SetDiscordConnected(true, time.Now())
+	}
+}
+
+// resumedHandler records that a dropped gateway session was resumed
+func (b *Bot) resumedHandler(s *discordgo.Session, r *discordgo.Resumed) {
+	b.logConnected("Discord session resumed")
+	if b.health != nil {
+		b.health.SetDiscordConnected(true, time.Now())
+	}
+}
+
+// logConnected logs a (re)connection, with how long the bot was offline when it had dropped
+func (b *Bot) logConnected(message string) {
+	if b.health != nil {
+		if connected, known, changedAt := b.health.Discord(); known && !connected {
+			b.logger.Info(message, zap.Duration("offline_for", time.Since(changedAt).Round(time.Second)))
+			return
+		}
+	}
+	b.logger.Info(message)
+}
+
+// disconnectHandler records that the gateway connection dropped; discordgo reconnects on its
+// own, and the connection watchdog reopens the session if it doesn't
+func (b *Bot) disconnectHandler(s *discordgo.Session, d *discordgo.Disconnect) {
+	b.logger.Warn("Disconnected from Discord")
+	b.markDisconnected()
+}
+
+// markDisconnected records the connection as down, keeping the time it first dropped
+func (b *Bot) markDisconnected() {
+	if b.health == nil {
+		return
+	}
+	if connected, known, _ := b.health.Discord(); connected || !known {
+		b.health.SetDiscordConnected(false, time.Now())
+	}
+}
+
+// SetWebhook attaches a webhook notifier that receives every proposal notification
+func (b *Bot) SetWebhook(n *webhook.Notifier) {
+	b.webhook = n
+}
+
 // Start starts the Discord bot
 func (b *Bot) Start(ctx context.Context) error {
 	b.logger.Info("Starting Discord bot")
@@ -60,12 +227,50 @@ func (b *Bot) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to open Discord session: %w", err)
 	}
 
+	// Register slash commands (legacy ! prefix commands keep working)
+	b.registerSlashCommands()
+
 	// Start notification goroutine
 	go b.handleNotifications(ctx)
 
 	// Start periodic notification check
 	go b.checkForNewProposals(ctx)
 
+	// Start reminders for proposals ending without a vote
+	if b.config.Reminders.Enabled {
+		go b.checkForEndingProposals(ctx)
+	}
+
+	// Start the scheduled digest of proposals in voting
+	if b.config.Digest.Enabled {
+		if schedule, err := b.config.Digest.ParseSchedule(); err != nil {
+			b.logger.Error("Invalid digest schedule, digest disabled", zap.Error(err))
+		} else {
+			go b.runDigest(ctx, schedule)
+		}
+	}
+
+	// Start rule-based auto-voting; auto_vote.enabled is the kill switch for every chain's rules
+	if b.config.AutoVote.Enabled {
+		if chainIDs := b.autoVoteChains(); len(chainIDs) > 0 {
+			b.logger.Warn("Auto-voting is enabled",
+				zap.Strings("chains", chainIDs),
+				zap.Duration("veto_window", b.config.AutoVote.Delay),
+			)
+			go b.runAutoVotes(ctx)
+		}
+	}
+
+	// Reopen the session if the gateway connection dies silently
+	if interval := b.config.Discord.WatchdogInterval; interval > 0 {
+		go b.watchConnection(ctx, interval)
+	}
+
+	// Start warnings for authz grants about to lapse
+	if b.config.AuthzExpiry.Enabled && b.hasAuthzChains() {
+		go b.checkAuthzGrantExpiry(ctx)
+	}
+
 	return nil
 }
 
@@ -83,24 +288,25 @@ func (b *Bot) messageHandler(s *discordgo.Session, m *discordgo.MessageCreate) {
 		return
 	}
 
-	// Only respond to messages from the allowed user
-	if m.Author.ID != b.config.Discord.AllowedUser {
-		b.logger.Warn("Unauthorized user attempted to use bot",
-			zap.String("user_id", m.Author.ID),
-			zap.String("username", m.Author.Username),
-		)
-		return
-	}
-
-	// Only respond to messages in the configured channel
-	if m.ChannelID != b.config.Discord.ChannelID {
+	// Only respond to commands in the configured channels; checked before authorization,
+	// which may need a Discord API call to look up the author's roles
+	if !b.isCommandChannel(m.ChannelID) {
 		return
 	}
 
 	content := strings.TrimSpace(m.Content)
 	parts := strings.Fields(content)
 
-	if len(parts) == 0 {
+	if len(parts) == 0 || !strings.HasPrefix(parts[0], "!") {
+		return
+	}
+
+	// Only respond to messages from allowed users or members holding the allowed role
+	if !b.isAuthorized(s, m) {
+		b.logger.Warn("Unauthorized user attempted to use bot",
+			zap.String("user_id", m.Author.ID),
+			zap.String("username", m.Author.Username),
+		)
 		return
 	}
 
@@ -112,11 +318,39 @@ func (b *Bot) messageHandler(s *discordgo.Session, m *discordgo.MessageCreate) {
 	case "!prop-proposals", "!pproposals":
 		b.listProposals(m.ChannelID, parts[1:])
 	case "!prop-vote", "!pvote":
-		b.handleVoteCommand(m.ChannelID, parts[1:])
+		b.handleVoteCommand(m.ChannelID, m.Author.ID, parts[1:])
 	case "!prop-authz-vote", "!pavote":
-		b.handleAuthzVoteCommand(m.ChannelID, parts[1:])
+		b.handleAuthzVoteCommand(m.ChannelID, m.Author.ID, parts[1:])
+	case "!vote-authz":
+		b.handleVoteAuthzCommand(m.ChannelID, m.Author.ID, parts[1:])
+	case "!prop-simulate", "!psimulate", "!simulate":
+		b.handleSimulateCommand(m.ChannelID, m.Author.ID, parts[1:])
+	case "!prop-deposit", "!pdeposit", "!deposit":
+		b.handleDepositCommand(m.ChannelID, m.Author.ID, parts[1:])
+	case "!prop-cancel", "!pcancel", "!cancel":
+		b.handleCancelCommand(m.ChannelID, m.Author.ID, parts[1:])
 	case "!prop-status", "!pstatus":
 		b.showStatus(m.ChannelID, parts[1:])
+	case "!prop-info", "!pinfo", "!info":
+		b.showInfo(m.ChannelID, parts[1:])
+	case "!prop-balance", "!pbalance", "!balance":
+		b.showBalance(m.ChannelID, parts[1:])
+	case "!prop-selftest", "!pselftest", "!selftest":
+		b.runSelfTest(m.ChannelID, parts[1:])
+	case "!prop-chains", "!pchains", "!chains":
+		b.listChains(m.ChannelID)
+	case "!prop-history", "!phistory", "!history":
+		b.showHistory(m.ChannelID, parts[1:])
+	case "!prop-export", "!pexport", "!export":
+		b.exportVotes(m.ChannelID, parts[1:])
+	case "!prop-authz-status", "!pastatus", "!authz-status":
+		b.showAuthzStatus(m.ChannelID, parts[1:])
+	case "!prop-pending", "!ppending", "!pending":
+		b.showPending(m.ChannelID, parts[1:])
+	case "!prop-version", "!pversion", "!version":
+		b.showVersion(m.ChannelID)
+	case "!prop-notify", "!pnotify", "!notify":
+		b.handleNotifyCommand(m.ChannelID, m.Author.ID, parts[1:])
 	default:
 		if strings.HasPrefix(content, "!prop-") || strings.HasPrefix(content, "!p") {
 			b.sendMessage(m.ChannelID, "Unknown prop-voter command. Type `!prop-help` for available commands.")
@@ -124,52 +358,170 @@ func (b *Bot) messageHandler(s *discordgo.Session, m *discordgo.MessageCreate) {
 	}
 }
 
+// isAuthorized checks whether the author of a message may use the bot
+func (b *Bot) isAuthorized(s *discordgo.Session, m *discordgo.MessageCreate) bool {
+	return b.authorizeUser(s, m.Author.ID, m.GuildID, m.Member)
+}
+
+// authorizeUser checks the user allowlist first, then the configured role (if any)
+func (b *Bot) authorizeUser(s *discordgo.Session, userID, guildID string, member *discordgo.Member) bool {
+	if b.config.Discord.IsUserAllowed(userID) {
+		return true
+	}
+
+	if !b.config.Discord.UsesRoleAuthorization() {
+		return false
+	}
+
+	// Guild messages and interactions usually carry the member's roles; otherwise use the
+	// state cache, and only then ask the Discord API
+	var roles []string
+	if member != nil && guildID == b.config.Discord.GuildID {
+		roles = member.Roles
+	} else if cached, err := s.State.Member(b.config.Discord.GuildID, userID); err == nil {
+		roles = cached.Roles
+	} else {
+		fetched, err := s.GuildMember(b.config.Discord.GuildID, userID)
+		if err != nil {
+			b.logger.Warn("Failed to fetch guild member for role check",
+				zap.String("user_id", userID),
+				zap.String("guild_id", b.config.Discord.GuildID),
+				zap.Error(err),
+			)
+			return false
+		}
+		roles = fetched.Roles
+	}
+
+	return b.config.Discord.HasAllowedRole(roles)
+}
+
 // sendHelp sends help information
 func (b *Bot) sendHelp(channelID string) {
 	help := `**Prop-Voter Bot Commands:**
 
 ` + "`" + `!prop-help` + "`" + ` (or ` + "`" + `!phelp` + "`" + `) - Show this help message
-` + "`" + `!prop-proposals [chain]` + "`" + ` (or ` + "`" + `!pproposals` + "`" + `) - List recent proposals (optionally filter by chain)
-` + "`" + `!prop-vote <chain> <proposal_id> <vote> <secret>` + "`" + ` (or ` + "`" + `!pvote` + "`" + `) - Vote on a proposal
+` + "`" + `!prop-proposals [chain] [page]` + "`" + ` (or ` + "`" + `!pproposals` + "`" + `) - List recent proposals, 10 per page (optionally filter by chain)
+` + "`" + `!prop-vote <chain> <proposal_id> <vote> <secret> [gas] [memo]` + "`" + ` (or ` + "`" + `!pvote` + "`" + `) - Vote on a proposal
   - vote options: yes, no, abstain, no_with_veto
   - secret: your configured vote secret
-` + "`" + `!prop-authz-vote <chain> <proposal_id> <vote> <secret>` + "`" + ` (or ` + "`" + `!pavote` + "`" + `) - Vote on behalf of another wallet (requires authz)
+  - gas (optional): fixed gas limit, or ` + "`" + `fixed` + "`" + ` to use the chain's gas_limit instead of simulating
+  - memo (optional): note attached to the vote tx (defaults to the chain's default_memo)
+  - --reason "..." (optional, last): why you voted this way; stored locally and shown in status and history, never sent on-chain
+` + "`" + `!prop-authz-vote <chain> <proposal_id> <vote> <secret> [gas] [memo]` + "`" + ` (or ` + "`" + `!pavote` + "`" + `) - Vote on behalf of another wallet (requires authz)
   - vote options: yes, no, abstain, no_with_veto
   - secret: your configured vote secret
   - note: chain must have authz enabled in config
+` + "`" + `!vote-authz <chain> <proposal_id> <vote> <granter|all> <secret> [gas] [memo]` + "`" + ` - Vote on behalf of one configured granter (name or address), or all of them
+` + "`" + `!prop-authz-status <chain> [granter]` + "`" + ` (or ` + "`" + `!authz-status` + "`" + `) - Check that the bot's wallet holds an unexpired gov vote grant from each configured granter
+` + "`" + `!prop-simulate <chain> <proposal_id> <vote> <secret> [gas] [memo]` + "`" + ` (or ` + "`" + `!psimulate` + "`" + ` / ` + "`" + `!simulate` + "`" + `) - Dry-run a vote and report estimated gas without broadcasting
+` + "`" + `!prop-deposit <chain> <proposal_id> <amount> <secret>` + "`" + ` (or ` + "`" + `!deposit` + "`" + `) - Deposit to a proposal in its deposit period
+  - amount: base units with the chain denom, e.g. 1000000uatom
+` + "`" + `!prop-cancel <chain> <proposal_id> <secret>` + "`" + ` (or ` + "`" + `!cancel` + "`" + `) - Cancel a proposal the voting wallet submitted (Cosmos SDK v0.50+)
 ` + "`" + `!prop-status [chain] [proposal_id]` + "`" + ` (or ` + "`" + `!pstatus` + "`" + `) - Show voting status
+` + "`" + `!prop-info <chain> <proposal_id>` + "`" + ` (or ` + "`" + `!info` + "`" + `) - Fetch live status, tally and voting end from the chain
+` + "`" + `!prop-balance <chain>` + "`" + ` (or ` + "`" + `!balance` + "`" + `) - Show the voting wallet's balance and warn if it is low on fees
+` + "`" + `!prop-selftest <chain>` + "`" + ` (or ` + "`" + `!selftest` + "`" + `) - Check the chain's CLI, wallet key, endpoints and gov queries
+` + "`" + `!prop-chains` + "`" + ` (or ` + "`" + `!chains` + "`" + `) - List configured chains, their IDs and health
+` + "`" + `!prop-history [chain]` + "`" + ` (or ` + "`" + `!history` + "`" + `) - Show votes cast by the bot (optionally filter by chain)
+` + "`" + `!prop-export [csv|json] [chain]` + "`" + ` (or ` + "`" + `!export` + "`" + `) - Attach the full vote history as a CSV (default) or JSON file
+` + "`" + `!prop-pending [chain]` + "`" + ` (or ` + "`" + `!pending` + "`" + `) - List proposals the notifier hasn't announced yet, and why
+` + "`" + `!prop-notify <chain> <proposal_id>` + "`" + ` (or ` + "`" + `!notify` + "`" + `) - Re-post a proposal's notification on the next notifier check
+` + "`" + `!prop-version` + "`" + ` (or ` + "`" + `!version` + "`" + `) - Show the prop-voter build and the installed version of each managed binary
+
+**Slash commands:** ` + "`" + `/proposals` + "`" + `, ` + "`" + `/vote` + "`" + `, ` + "`" + `/status` + "`" + `, ` + "`" + `/tally` + "`" + ` (type ` + "`" + `/` + "`" + ` to see options)
 
 **Examples:**
 ` + "`" + `!pproposals cosmoshub-4` + "`" + `
 ` + "`" + `!pvote cosmoshub-4 123 yes mysecret` + "`" + `
 ` + "`" + `!pavote cosmoshub-4 123 yes mysecret` + "`" + ` (authz vote)
-` + "`" + `!pstatus cosmoshub-4 123` + "`" + ``
+` + "`" + `!psimulate cosmoshub-4 123 yes mysecret` + "`" + ` (dry run)
+` + "`" + `!pstatus cosmoshub-4 123` + "`" + `
+` + "`" + `!info cosmoshub-4 123` + "`" + ` (live from chain)
+` + "`" + `!balance cosmoshub-4` + "`" + ``
 
 	b.sendMessage(channelID, help)
 }
 
-// listProposals lists recent proposals
+// proposalsPageSize is the number of proposals !prop-proposals shows per page
+const proposalsPageSize = 10
+
+// listProposals lists recent proposals, newest first, a page at a time
 func (b *Bot) listProposals(channelID string, args []string) {
-	var proposals []models.Proposal
-	query := b.db.Order("created_at DESC").Limit(10)
+	chainFilter, page, err := parseProposalsArgs(args)
+	if err != nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ %v", err))
+		return
+	}
+
+	content, components, err := b.buildProposalsPage(chainFilter, page)
+	if err != nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ %v", err))
+		return
+	}
+
+	message := &discordgo.MessageSend{Content: content, Components: components}
+	if _, err := b.session.ChannelMessageSendComplex(channelID, message); err != nil {
+		b.logger.Error("Failed to send Discord message", zap.Error(err))
+	}
+}
 
+// parseProposalsArgs reads `[chain] [page]`; a trailing number is the page (chain IDs are
+// never plain numbers)
+func parseProposalsArgs(args []string) (chainFilter string, page int, err error) {
+	page = 1
 	if len(args) > 0 {
-		chainFilter := args[0]
+		if n, convErr := strconv.Atoi(args[len(args)-1]); convErr == nil {
+			if n < 1 {
+				return "", 0, fmt.Errorf("page must be 1 or more, got %d", n)
+			}
+			page = n
+			args = args[:len(args)-1]
+		}
+	}
+	if len(args) > 1 {
+		return "", 0, fmt.Errorf("usage: `!prop-proposals [chain] [page]`")
+	}
+	if len(args) == 1 {
+		chainFilter = args[0]
+	}
+	return chainFilter, page, nil
+}
+
+// buildProposalsPage renders one page of the proposal listing, with Previous/Next buttons
+// when there is more than one page
+func (b *Bot) buildProposalsPage(chainFilter string, page int) (string, []discordgo.MessageComponent, error) {
+	query := b.db.Model(&models.Proposal{}).Scopes(models.NotArchived)
+	if chainFilter != "" {
 		query = query.Where("chain_id = ?", chainFilter)
 	}
 
-	if err := query.Find(&proposals).Error; err != nil {
-		b.sendMessage(channelID, "❌ Failed to fetch proposals")
-		return
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		b.logger.Error("Failed to fetch proposals", zap.Error(err))
+		return "", nil, fmt.Errorf("failed to fetch proposals")
+	}
+	if total == 0 {
+		return "No proposals found", nil, nil
 	}
 
-	if len(proposals) == 0 {
-		b.sendMessage(channelID, "No proposals found")
-		return
+	pages := int((total + proposalsPageSize - 1) / proposalsPageSize)
+	if page > pages {
+		return "", nil, fmt.Errorf("page %d doesn't exist, there are %d", page, pages)
+	}
+
+	var proposals []models.Proposal
+	if err := query.Order("created_at DESC").Offset((page - 1) * proposalsPageSize).Limit(proposalsPageSize).Find(&proposals).Error; err != nil {
+		b.logger.Error("Failed to fetch proposals", zap.Error(err))
+		return "", nil, fmt.Errorf("failed to fetch proposals")
 	}
 
 	var message strings.Builder
-	message.WriteString("**Recent Proposals:**\n\n")
+	heading := "Recent Proposals"
+	if chainFilter != "" {
+		heading = fmt.Sprintf("Recent %s Proposals", chainFilter)
+	}
+	message.WriteString(fmt.Sprintf("**%s** (page %d of %d, %d total):\n\n", heading, page, pages, total))
 
 	for _, proposal := range proposals {
 		message.WriteString(fmt.Sprintf("**%s - Proposal #%s**\n", proposal.ChainID, proposal.ProposalID))
@@ -183,13 +535,59 @@ func (b *Bot) listProposals(channelID string, args []string) {
 		message.WriteString("\n")
 	}
 
-	b.sendMessage(channelID, message.String())
+	if pages == 1 {
+		return message.String(), nil, nil
+	}
+	return message.String(), proposalsPageButtons(chainFilter, page, pages), nil
+}
+
+// proposalsPageButtons returns the Previous/Next buttons of a proposal listing; their IDs
+// are proposals_page_{page}_{chainFilter}, with the page first since chain IDs may contain
+// underscores
+func proposalsPageButtons(chainFilter string, page, pages int) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Previous",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("proposals_page_%d_%s", page-1, chainFilter),
+					Disabled: page <= 1,
+					Emoji:    discordgo.ComponentEmoji{Name: "◀️"},
+				},
+				discordgo.Button{
+					Label:    "Next",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("proposals_page_%d_%s", page+1, chainFilter),
+					Disabled: page >= pages,
+					Emoji:    discordgo.ComponentEmoji{Name: "▶️"},
+				},
+			},
+		},
+	}
+}
+
+// parseProposalsPageCustomID splits a proposals_page_{page}_{chainFilter} component ID
+func parseProposalsPageCustomID(customID string) (chainFilter string, page int, ok bool) {
+	remainder, found := strings.CutPrefix(customID, "proposals_page_")
+	if !found {
+		return "", 0, false
+	}
+	pagePart, chainFilter, found := strings.Cut(remainder, "_")
+	if !found {
+		return "", 0, false
+	}
+	page, err := strconv.Atoi(pagePart)
+	if err != nil || page < 1 {
+		return "", 0, false
+	}
+	return chainFilter, page, true
 }
 
 // handleVoteCommand handles vote commands
-func (b *Bot) handleVoteCommand(channelID string, args []string) {
+func (b *Bot) handleVoteCommand(channelID, userID string, args []string) {
 	if len(args) < 4 {
-		b.sendMessage(channelID, "❌ Usage: `!prop-vote <chain> <proposal_id> <vote> <secret>` (or `!pvote`)")
+		b.sendMessage(channelID, "❌ Usage: `!prop-vote <chain> <proposal_id> <vote> <secret> [gas] [memo] [--reason \"...\"]` (or `!pvote`)")
 		return
 	}
 
@@ -199,9 +597,9 @@ func (b *Bot) handleVoteCommand(channelID string, args []string) {
 	secret := args[3]
 
 	// Verify secret
-	if secret != b.config.Security.VoteSecret {
-		b.sendMessage(channelID, "❌ Invalid secret")
+	if !b.checkVoteSecret(channelID, userID, secret) {
 		b.logger.Warn("Invalid vote secret provided",
+			zap.String("user_id", userID),
 			zap.String("chain", chainID),
 			zap.String("proposal", proposalID),
 		)
@@ -221,6 +619,17 @@ func (b *Bot) handleVoteCommand(channelID string, args []string) {
 		return
 	}
 
+	extra, rationale, err := splitRationale(args[4:])
+	if err != nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ %v", err))
+		return
+	}
+	opts, err := parseTxOptions(extra)
+	if err != nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ %v", err))
+		return
+	}
+
 	// Check if proposal exists
 	var proposal models.Proposal
 	if err := b.db.Where("chain_id = ? AND proposal_id = ?", chainID, proposalID).First(&proposal).Error; err != nil {
@@ -232,16 +641,80 @@ func (b *Bot) handleVoteCommand(channelID string, args []string) {
 		return
 	}
 
+	b.logger.Info("Vote requested",
+		zap.String("user_id", userID),
+		zap.String("chain", chainID),
+		zap.String("proposal", proposalID),
+		zap.String("option", voteOption),
+	)
+
+	if b.config.Discord.RequireConfirmation {
+		b.requestVoteConfirmation(channelID, userID, proposal, voteOption, opts, rationale)
+		return
+	}
+
+	b.submitVote(channelID, userID, chainID, proposalID, voteOption, opts, rationale)
+}
+
+// maxRationaleLength caps a vote's --reason so it fits in a Discord embed field
+const maxRationaleLength = 1000
+
+// splitRationale separates a trailing `--reason "..."` from the optional vote arguments. The
+// reason is everything after the flag, so it may contain spaces; it is stored with the vote
+// but never broadcast
+func splitRationale(extra []string) ([]string, string, error) {
+	for i, arg := range extra {
+		if !strings.EqualFold(arg, "--reason") {
+			continue
+		}
+		rationale := strings.TrimSpace(strings.Trim(strings.Join(extra[i+1:], " "), "\"“”"))
+		if rationale == "" {
+			return nil, "", fmt.Errorf("--reason needs a note, e.g. --reason \"aligns with our treasury policy\"")
+		}
+		if length := utf8.RuneCountInString(rationale); length > maxRationaleLength {
+			return nil, "", fmt.Errorf("reason is too long (%d characters, max %d)", length, maxRationaleLength)
+		}
+		return extra[:i], rationale, nil
+	}
+	return extra, "", nil
+}
+
+// parseTxOptions parses the optional arguments following the secret on vote commands:
+// an optional gas override (a gas limit or "fixed") followed by an optional memo
+func parseTxOptions(extra []string) (voting.TxOptions, error) {
+	var opts voting.TxOptions
+	if len(extra) == 0 {
+		return opts, nil
+	}
+
+	if gas := strings.ToLower(extra[0]); voting.ValidateGas(gas) == nil {
+		opts.Gas = gas
+		extra = extra[1:]
+	}
+
+	memo := strings.Join(extra, " ")
+	opts.Memo = strings.Trim(memo, "\"")
+
+	if err := opts.Validate(); err != nil {
+		return voting.TxOptions{}, err
+	}
+	return opts, nil
+}
+
+// submitVote broadcasts a vote and reports the result to the channel; rationale is stored
+// with the vote record only
+func (b *Bot) submitVote(channelID, userID, chainID, proposalID, voteOption string, opts voting.TxOptions, rationale string) {
 	b.sendMessage(channelID, fmt.Sprintf("🗳️ Submitting vote: **%s** on **%s** proposal **#%s**...", voteOption, chainID, proposalID))
+	b.notifyLedgerConfirmation(channelID, chainID)
 
 	// Submit vote with timeout handling
 	done := make(chan struct{})
-	var txHash string
+	var result *voting.VoteResult
 	var err error
 
 	go func() {
 		defer close(done)
-		txHash, err = b.voter.Vote(chainID, proposalID, voteOption)
+		result, err = b.voter.Vote(chainID, proposalID, voteOption, opts)
 	}()
 
 	// Send a warning if it's taking too long
@@ -249,7 +722,8 @@ func (b *Bot) handleVoteCommand(channelID string, args []string) {
 	case <-done:
 		// Vote completed (success or failure)
 	case <-time.After(30 * time.Second):
-		b.sendMessage(channelID, "⏳ Vote is taking longer than expected... still processing (max 60s timeout)")
+		b.sendMessage(channelID, fmt.Sprintf("⏳ Vote is taking longer than expected... still processing (max %ds timeout)",
+			int(b.voter.VoteTimeout(chainID).Seconds())))
 		<-done // Wait for completion
 	}
 	if err != nil {
@@ -264,6 +738,7 @@ func (b *Bot) handleVoteCommand(channelID string, args []string) {
 		b.sendMessage(channelID, errorMsg)
 		return
 	}
+	txHash := result.TxHash
 
 	// Store vote in database
 	vote := models.Vote{
@@ -272,111 +747,475 @@ func (b *Bot) handleVoteCommand(channelID string, args []string) {
 		Option:     voteOption,
 		TxHash:     txHash,
 		VotedAt:    time.Now(),
+		VotedBy:    userID,
+		Rationale:  rationale,
 	}
 
 	if err := b.db.Create(&vote).Error; err != nil {
 		b.logger.Error("Failed to store vote", zap.Error(err))
 	}
 
+	b.logger.Info("Vote recorded",
+		zap.String("user_id", userID),
+		zap.String("chain", chainID),
+		zap.String("proposal", proposalID),
+		zap.String("option", voteOption),
+		zap.String("tx_hash", txHash),
+	)
+
 	// Enhanced success message
 	if txHash == "UNKNOWN_HASH_CHECK_LOGS" {
 		// Vote succeeded but couldn't parse hash
 		successMsg := fmt.Sprintf("⚠️ **Vote Likely Submitted Successfully!**\n\n**Chain:** %s\n**Proposal:** #%s\n**Vote:** %s\n\n**Note:** Could not parse transaction hash from CLI output. Check server logs for raw output or verify your vote manually on the explorer.",
 			chainID, proposalID, voteOption)
-		b.sendMessage(channelID, successMsg)
+		b.sendVoteConfirmation(channelID, chainID, proposalID, successMsg,
+			fmt.Sprintf("🗳️ <@%s> voted **%s** (transaction hash unknown, check the logs)", userID, voteOption))
 	} else {
 		// Normal success with hash
-		successMsg := fmt.Sprintf("✅ **Vote Submitted Successfully!**\n\n**Chain:** %s\n**Proposal:** #%s\n**Vote:** %s\n**Transaction Hash:** `%s`\n\n🔗 [View on Explorer](https://www.mintscan.io/%s/txs/%s)",
-			chainID, proposalID, voteOption, txHash, b.getExplorerChainName(chainID), txHash)
-		b.sendMessage(channelID, successMsg)
+		successMsg := fmt.Sprintf("✅ **Vote Submitted Successfully!**\n\n**Chain:** %s\n**Proposal:** #%s\n**Vote:** %s\n**Transaction Hash:** `%s`%s\n\n🔗 [View on Explorer](https://www.mintscan.io/%s/txs/%s)",
+			chainID, proposalID, voteOption, txHash, formatBlockHeight(result), b.getExplorerChainName(chainID), txHash)
+		b.sendVoteConfirmation(channelID, chainID, proposalID, successMsg,
+			fmt.Sprintf("🗳️ <@%s> voted **%s** - tx `%s`", userID, voteOption, txHash))
 	}
 }
 
-// handleAuthzVoteCommand handles authz vote commands
-func (b *Bot) handleAuthzVoteCommand(channelID string, args []string) {
-	if len(args) < 4 {
-		b.sendMessage(channelID, "❌ Usage: `!prop-authz-vote <chain> <proposal_id> <vote> <secret>` (or `!pavote`)")
-		return
+// formatBlockHeight returns a message line with the block the vote was included in, when known
+func formatBlockHeight(result *voting.VoteResult) string {
+	if result.Height == 0 {
+		return ""
 	}
+	return fmt.Sprintf("\n**Included in Block:** %d", result.Height)
+}
 
-	chainID := args[0]
-	proposalID := args[1]
-	voteOption := strings.ToLower(args[2])
-	secret := args[3]
-
-	// Verify secret
-	if secret != b.config.Security.VoteSecret {
-		b.sendMessage(channelID, "❌ Invalid secret")
-		b.logger.Warn("Invalid authz vote secret provided",
-			zap.String("chain", chainID),
-			zap.String("proposal", proposalID),
-		)
-		return
+// notifyLedgerConfirmation reminds the operator to approve the tx on the device for Ledger chains
+func (b *Bot) notifyLedgerConfirmation(channelID, chainID string) {
+	if chainConfig := b.chains[chainID]; chainConfig != nil && chainConfig.UseLedger {
+		b.sendMessage(channelID, fmt.Sprintf("🔐 **%s** signs with a Ledger - confirm the transaction on the device (waiting up to %s)",
+			chainID, voting.LedgerVoteTimeout))
 	}
+}
 
-	// Validate vote option
-	validVotes := map[string]bool{
-		"yes":          true,
-		"no":           true,
-		"abstain":      true,
-		"no_with_veto": true,
+// requestVoteConfirmation posts a summary of the requested vote with confirm/cancel buttons
+func (b *Bot) requestVoteConfirmation(channelID, userID string, proposal models.Proposal, voteOption string, opts voting.TxOptions, rationale string) {
+	embed := &discordgo.MessageEmbed{
+		Title:       "🗳️ Confirm Vote",
+		Description: proposal.Title,
+		Color:       0xffcc00, // Yellow
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Chain", Value: proposal.ChainID, Inline: true},
+			{Name: "Proposal", Value: fmt.Sprintf("#%s", proposal.ProposalID), Inline: true},
+			{Name: "Vote", Value: strings.ToUpper(voteOption), Inline: true},
+			{Name: "Requested By", Value: fmt.Sprintf("<@%s>", userID), Inline: false},
+		},
 	}
+	embed.Fields = append(embed.Fields, txOptionFields(opts, rationale)...)
 
-	if !validVotes[voteOption] {
-		b.sendMessage(channelID, "❌ Invalid vote option. Use: yes, no, abstain, no_with_veto")
-		return
-	}
+	b.requestConfirmation(embed, pendingVote{
+		Action:     "Vote",
+		ChainID:    proposal.ChainID,
+		ProposalID: proposal.ProposalID,
+		Option:     voteOption,
+		UserID:     userID,
+		ChannelID:  channelID,
+		TxOptions:  opts,
+		Rationale:  rationale,
+		Submit: func() {
+			b.submitVote(channelID, userID, proposal.ChainID, proposal.ProposalID, voteOption, opts, rationale)
+		},
+	})
+}
 
-	// Find the chain configuration and check if authz is enabled
-	var chainConfig *config.ChainConfig
-	for _, chain := range b.config.Chains {
-		if chain.GetChainID() == chainID {
-			chainConfig = &chain
-			break
-		}
+// requestAuthzVoteConfirmation posts a summary of the requested authz vote with confirm/cancel
+// buttons; confirming casts it for each of granters
+func (b *Bot) requestAuthzVoteConfirmation(channelID, userID string, req *authzVoteRequest, granters []config.AuthzGranter) {
+	embed := &discordgo.MessageEmbed{
+		Title:       "🗳️ Confirm Authz Vote",
+		Description: req.title,
+		Color:       0xffcc00, // Yellow
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Chain", Value: req.chain.GetChainID(), Inline: true},
+			{Name: "Proposal", Value: fmt.Sprintf("#%s", req.proposalID), Inline: true},
+			{Name: "Vote", Value: strings.ToUpper(req.option), Inline: true},
+			{Name: "On Behalf Of", Value: granterNames(granters), Inline: false},
+			{Name: "Requested By", Value: fmt.Sprintf("<@%s>", userID), Inline: false},
+		},
 	}
+	embed.Fields = append(embed.Fields, txOptionFields(req.opts, req.rationale)...)
+
+	b.requestConfirmation(embed, pendingVote{
+		Action:     "Authz vote",
+		ChainID:    req.chain.GetChainID(),
+		ProposalID: req.proposalID,
+		Option:     req.option,
+		UserID:     userID,
+		ChannelID:  channelID,
+		TxOptions:  req.opts,
+		Rationale:  req.rationale,
+		Submit: func() {
+			b.castAuthzVotes(channelID, userID, req, granters)
+		},
+	})
+}
 
-	if chainConfig == nil {
-		b.sendMessage(channelID, "❌ Chain not found in configuration")
-		return
+// requestDepositConfirmation posts a summary of the requested deposit with confirm/cancel buttons
+func (b *Bot) requestDepositConfirmation(channelID, userID, chainID, proposalID, amount string) {
+	embed := &discordgo.MessageEmbed{
+		Title: "💰 Confirm Deposit",
+		Color: 0xffcc00, // Yellow
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Chain", Value: chainID, Inline: true},
+			{Name: "Proposal", Value: fmt.Sprintf("#%s", proposalID), Inline: true},
+			{Name: "Amount", Value: amount, Inline: true},
+			{Name: "Requested By", Value: fmt.Sprintf("<@%s>", userID), Inline: false},
+		},
 	}
 
-	if !chainConfig.IsAuthzEnabled() {
-		b.sendMessage(channelID, fmt.Sprintf("❌ Authz voting is not enabled for chain %s", chainConfig.GetName()))
-		return
-	}
+	b.requestConfirmation(embed, pendingVote{
+		Action:     "Deposit",
+		ChainID:    chainID,
+		ProposalID: proposalID,
+		UserID:     userID,
+		ChannelID:  channelID,
+		Submit: func() {
+			b.submitDeposit(channelID, userID, chainID, proposalID, amount)
+		},
+	})
+}
 
-	// Check if proposal exists
-	var proposal models.Proposal
-	if err := b.db.Where("chain_id = ? AND proposal_id = ?", chainID, proposalID).First(&proposal).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			b.sendMessage(channelID, "❌ Proposal not found")
-		} else {
-			b.sendMessage(channelID, "❌ Database error")
-		}
-		return
+// txOptionFields lists any per-vote tx overrides and the rationale so they're confirmed too
+func txOptionFields(opts voting.TxOptions, rationale string) []*discordgo.MessageEmbedField {
+	var fields []*discordgo.MessageEmbedField
+	if opts.Gas != "" {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "Gas", Value: opts.Gas, Inline: true})
+	}
+	if opts.Memo != "" {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "Memo", Value: opts.Memo, Inline: false})
 	}
+	if rationale != "" {
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "Reason (stored locally)", Value: rationale, Inline: false})
+	}
+	return fields
+}
 
-	granterName := chainConfig.GetGranterName()
-	b.sendMessage(channelID, fmt.Sprintf("🗳️ Submitting authz vote: **%s** on **%s** proposal **#%s** on behalf of **%s**...",
-		voteOption, chainID, proposalID, granterName))
+// requestConfirmation stores pending under a fresh token and posts embed with confirm/cancel
+// buttons for it; nothing is broadcast until the requester confirms
+func (b *Bot) requestConfirmation(embed *discordgo.MessageEmbed, pending pendingVote) {
+	token := strconv.FormatInt(time.Now().UnixNano(), 36)
+	pending.RequestedAt = time.Now()
 
-	// Submit authz vote with timeout handling
-	done := make(chan struct{})
-	var txHash string
-	var err error
+	b.pendingMu.Lock()
+	for key, existing := range b.pendingVotes {
+		if time.Since(existing.RequestedAt) > pendingVoteTTL {
+			delete(b.pendingVotes, key)
+		}
+	}
+	b.pendingVotes[token] = pending
+	b.pendingMu.Unlock()
 
-	go func() {
-		defer close(done)
-		txHash, err = b.voter.VoteAuthz(chainID, proposalID, voteOption)
-	}()
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: fmt.Sprintf("Confirm within %s - nothing is broadcast until you confirm", pendingVoteTTL),
+	}
 
-	// Send a warning if it's taking too long
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Confirm",
+					Style:    discordgo.SuccessButton,
+					CustomID: "vote_confirm_" + token,
+					Emoji:    discordgo.ComponentEmoji{Name: "✅"},
+				},
+				discordgo.Button{
+					Label:    "Cancel",
+					Style:    discordgo.DangerButton,
+					CustomID: "vote_cancel_" + token,
+					Emoji:    discordgo.ComponentEmoji{Name: "❌"},
+				},
+			},
+		},
+	}
+
+	b.sendEmbedWithButtons(pending.ChannelID, embed, components)
+}
+
+// takePendingVote removes and returns a pending vote if it is still valid and belongs to userID
+func (b *Bot) takePendingVote(token, userID string) (pendingVote, error) {
+	b.pendingMu.Lock()
+	defer b.pendingMu.Unlock()
+
+	pending, exists := b.pendingVotes[token]
+	if !exists || time.Since(pending.RequestedAt) > pendingVoteTTL {
+		delete(b.pendingVotes, token)
+		return pendingVote{}, fmt.Errorf("this request has expired or was already handled")
+	}
+
+	if pending.UserID != userID {
+		return pendingVote{}, fmt.Errorf("only the user who requested this can confirm it")
+	}
+
+	delete(b.pendingVotes, token)
+	return pending, nil
+}
+
+// handleVoteConfirmationButton handles confirm/cancel clicks on a vote, authz vote or deposit
+// confirmation prompt
+func (b *Bot) handleVoteConfirmationButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	customID := i.MessageComponentData().CustomID
+	confirmed := strings.HasPrefix(customID, "vote_confirm_")
+	token := strings.TrimPrefix(strings.TrimPrefix(customID, "vote_confirm_"), "vote_cancel_")
+
+	var userID string
+	if i.Member != nil && i.Member.User != nil {
+		userID = i.Member.User.ID
+	} else if i.User != nil {
+		userID = i.User.ID
+	}
+
+	pending, err := b.takePendingVote(token, userID)
+	if err != nil {
+		b.respondWithError(s, i, err.Error())
+		return
+	}
+
+	status := fmt.Sprintf("❌ %s cancelled by <@%s>", pending.Action, userID)
+	if confirmed {
+		status = fmt.Sprintf("✅ %s confirmed by <@%s>", pending.Action, userID)
+	}
+
+	// Replace the buttons so the prompt can't be clicked again
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    status,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		b.logger.Error("Failed to update vote confirmation message", zap.Error(err))
+	}
+
+	if !confirmed {
+		b.logger.Info("Confirmation cancelled",
+			zap.String("action", pending.Action),
+			zap.String("user_id", userID),
+			zap.String("chain", pending.ChainID),
+			zap.String("proposal", pending.ProposalID),
+		)
+		return
+	}
+
+	pending.Submit()
+}
+
+// authzVoteRequest is an authz vote command that passed validation
+type authzVoteRequest struct {
+	chain      *config.ChainConfig
+	proposalID string
+	option     string
+	opts       voting.TxOptions
+	rationale  string
+	title      string // Proposal title, shown on the confirmation prompt
+}
+
+// handleAuthzVoteCommand handles authz vote commands, which vote on behalf of the chain's only
+// granter; chains with several granters need !vote-authz to pick one
+func (b *Bot) handleAuthzVoteCommand(channelID, userID string, args []string) {
+	if len(args) < 4 {
+		b.sendMessage(channelID, "❌ Usage: `!prop-authz-vote <chain> <proposal_id> <vote> <secret> [gas] [memo] [--reason \"...\"]` (or `!pavote`)")
+		return
+	}
+
+	req := b.prepareAuthzVote(channelID, userID, args[0], args[1], args[2], args[3], args[4:])
+	if req == nil {
+		return
+	}
+
+	granters := req.chain.GetGranters()
+	if len(granters) > 1 {
+		b.sendMessage(channelID, fmt.Sprintf("❌ %s has %d authz granters (%s). Use `!vote-authz %s %s %s <granter|all> <secret>` to choose who to vote for",
+			req.chain.GetName(), len(granters), granterNames(granters), args[0], req.proposalID, req.option))
+		return
+	}
+
+	if b.config.Discord.RequireConfirmation {
+		b.requestAuthzVoteConfirmation(channelID, userID, req, granters)
+		return
+	}
+
+	b.castAuthzVotes(channelID, userID, req, granters)
+}
+
+// handleVoteAuthzCommand handles !vote-authz, which votes on behalf of one named granter or,
+// with "all", each of the chain's granters in turn
+func (b *Bot) handleVoteAuthzCommand(channelID, userID string, args []string) {
+	if len(args) < 5 {
+		b.sendMessage(channelID, "❌ Usage: `!vote-authz <chain> <proposal_id> <vote> <granter|all> <secret> [gas] [memo] [--reason \"...\"]`")
+		return
+	}
+
+	req := b.prepareAuthzVote(channelID, userID, args[0], args[1], args[2], args[4], args[5:])
+	if req == nil {
+		return
+	}
+
+	granters := req.chain.GetGranters()
+	if !strings.EqualFold(args[3], "all") {
+		granter := req.chain.FindGranter(args[3])
+		if granter == nil {
+			b.sendMessage(channelID, fmt.Sprintf("❌ Unknown granter %s for %s. Configured granters: %s",
+				args[3], req.chain.GetName(), granterNames(granters)))
+			return
+		}
+		granters = []config.AuthzGranter{*granter}
+	}
+
+	if b.config.Discord.RequireConfirmation {
+		b.requestAuthzVoteConfirmation(channelID, userID, req, granters)
+		return
+	}
+
+	b.castAuthzVotes(channelID, userID, req, granters)
+}
+
+// castAuthzVotes casts a validated authz vote for each of granters, summarizing the outcome
+// when there are several
+func (b *Bot) castAuthzVotes(channelID, userID string, req *authzVoteRequest, granters []config.AuthzGranter) {
+	var failed []string
+	for _, granter := range granters {
+		if !b.castAuthzVote(channelID, userID, req, granter) {
+			failed = append(failed, granter.DisplayName())
+		}
+	}
+
+	if len(granters) > 1 {
+		summary := fmt.Sprintf("🗳️ **Authz votes on %s proposal #%s:** %d of %d granters voted **%s**",
+			req.chain.GetName(), req.proposalID, len(granters)-len(failed), len(granters), req.option)
+		if len(failed) > 0 {
+			summary += fmt.Sprintf("\n❌ Failed for: %s", strings.Join(failed, ", "))
+		}
+		b.sendMessage(channelID, summary)
+	}
+}
+
+// granterNames lists granters by display name for Discord replies
+func granterNames(granters []config.AuthzGranter) string {
+	names := make([]string, len(granters))
+	for i, granter := range granters {
+		names[i] = granter.DisplayName()
+	}
+	return strings.Join(names, ", ")
+}
+
+// prepareAuthzVote checks the secret, vote option, tx options, chain and proposal of an authz
+// vote command, replying with the problem and returning nil if any is invalid
+func (b *Bot) prepareAuthzVote(channelID, userID, chainID, proposalID, option, secret string, extraArgs []string) *authzVoteRequest {
+	voteOption := strings.ToLower(option)
+
+	// Verify secret
+	if !b.checkVoteSecret(channelID, userID, secret) {
+		b.logger.Warn("Invalid authz vote secret provided",
+			zap.String("user_id", userID),
+			zap.String("chain", chainID),
+			zap.String("proposal", proposalID),
+		)
+		return nil
+	}
+
+	// Validate vote option
+	validVotes := map[string]bool{
+		"yes":          true,
+		"no":           true,
+		"abstain":      true,
+		"no_with_veto": true,
+	}
+
+	if !validVotes[voteOption] {
+		b.sendMessage(channelID, "❌ Invalid vote option. Use: yes, no, abstain, no_with_veto")
+		return nil
+	}
+
+	extra, rationale, err := splitRationale(extraArgs)
+	if err != nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ %v", err))
+		return nil
+	}
+	opts, err := parseTxOptions(extra)
+	if err != nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ %v", err))
+		return nil
+	}
+
+	// Find the chain configuration and check if authz is enabled
+	chainConfig := b.chains[chainID]
+
+	if chainConfig == nil {
+		b.sendMessage(channelID, "❌ Chain not found in configuration")
+		return nil
+	}
+
+	if !chainConfig.IsAuthzEnabled() {
+		b.sendMessage(channelID, fmt.Sprintf("❌ Authz voting is not enabled for chain %s", chainConfig.GetName()))
+		return nil
+	}
+
+	// Check if proposal exists
+	var proposal models.Proposal
+	if err := b.db.Where("chain_id = ? AND proposal_id = ?", chainID, proposalID).First(&proposal).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			b.sendMessage(channelID, "❌ Proposal not found")
+		} else {
+			b.sendMessage(channelID, "❌ Database error")
+		}
+		return nil
+	}
+
+	return &authzVoteRequest{
+		chain:      chainConfig,
+		proposalID: proposalID,
+		option:     voteOption,
+		opts:       opts,
+		rationale:  rationale,
+		title:      proposal.Title,
+	}
+}
+
+// castAuthzVote submits a validated authz vote on behalf of granter, records it and reports
+// the outcome, returning whether the vote went through
+func (b *Bot) castAuthzVote(channelID, userID string, req *authzVoteRequest, granter config.AuthzGranter) bool {
+	chainID := req.chain.GetChainID()
+	proposalID := req.proposalID
+	voteOption := req.option
+	granterName := granter.DisplayName()
+
+	b.logger.Info("Authz vote requested",
+		zap.String("user_id", userID),
+		zap.String("chain", chainID),
+		zap.String("proposal", proposalID),
+		zap.String("option", voteOption),
+		zap.String("granter", granter.Address),
+	)
+
+	b.sendMessage(channelID, fmt.Sprintf("🗳️ Submitting authz vote: **%s** on **%s** proposal **#%s** on behalf of **%s**...",
+		voteOption, chainID, proposalID, granterName))
+	b.notifyLedgerConfirmation(channelID, chainID)
+
+	// Submit authz vote with timeout handling
+	done := make(chan struct{})
+	var result *voting.VoteResult
+	var err error
+
+	go func() {
+		defer close(done)
+		result, err = b.voter.VoteAuthz(chainID, proposalID, voteOption, granter.Address, req.opts)
+	}()
+
+	// Send a warning if it's taking too long
 	select {
 	case <-done:
 		// Vote completed (success or failure)
 	case <-time.After(30 * time.Second):
-		b.sendMessage(channelID, "⏳ Authz vote is taking longer than expected... still processing (max 60s timeout)")
+		b.sendMessage(channelID, fmt.Sprintf("⏳ Authz vote is taking longer than expected... still processing (max %ds timeout)",
+			int(b.voter.VoteTimeout(chainID).Seconds())))
 		<-done // Wait for completion
 	}
 
@@ -390,8 +1229,9 @@ func (b *Bot) handleAuthzVoteCommand(channelID string, args []string) {
 		errorMsg := fmt.Sprintf("❌ **Authz Vote Failed**\n\n**Chain:** %s\n**Proposal:** #%s\n**Vote:** %s\n**Granter:** %s\n\n**Error Details:**\n```\n%s\n```",
 			chainID, proposalID, voteOption, granterName, errorDetails)
 		b.sendMessage(channelID, errorMsg)
-		return
+		return false
 	}
+	txHash := result.TxHash
 
 	// Store authz vote in database
 	vote := models.Vote{
@@ -401,87 +1241,858 @@ func (b *Bot) handleAuthzVoteCommand(channelID string, args []string) {
 		TxHash:      txHash,
 		VotedAt:     time.Now(),
 		IsAuthzVote: true,
-		GranterAddr: chainConfig.GetGranterAddr(),
+		GranterAddr: granter.Address,
 		GranterName: granterName,
+		VotedBy:     userID,
+		Rationale:   req.rationale,
+	}
+
+	if err := b.db.Create(&vote).Error; err != nil {
+		b.logger.Error("Failed to store authz vote", zap.Error(err))
+	}
+
+	b.logger.Info("Authz vote recorded",
+		zap.String("user_id", userID),
+		zap.String("chain", chainID),
+		zap.String("proposal", proposalID),
+		zap.String("option", voteOption),
+		zap.String("tx_hash", txHash),
+		zap.String("granter", granter.Address),
+	)
+
+	// Enhanced success message
+	if txHash == "UNKNOWN_HASH_CHECK_LOGS" {
+		// Vote succeeded but couldn't parse hash
+		successMsg := fmt.Sprintf("⚠️ **Authz Vote Likely Submitted Successfully!**\n\n**Chain:** %s\n**Proposal:** #%s\n**Vote:** %s\n**Granter:** %s\n\n**Note:** Could not parse transaction hash from CLI output. Check server logs for raw output or verify your vote manually on the explorer.",
+			chainID, proposalID, voteOption, granterName)
+		b.sendVoteConfirmation(channelID, chainID, proposalID, successMsg,
+			fmt.Sprintf("🗳️ <@%s> voted **%s** on behalf of **%s** (transaction hash unknown, check the logs)", userID, voteOption, granterName))
+	} else {
+		// Normal success with hash
+		successMsg := fmt.Sprintf("✅ **Authz Vote Submitted Successfully!**\n\n**Chain:** %s\n**Proposal:** #%s\n**Vote:** %s\n**Granter:** %s\n**Transaction Hash:** `%s`%s\n\n🔗 [View on Explorer](https://www.mintscan.io/%s/txs/%s)",
+			chainID, proposalID, voteOption, granterName, txHash, formatBlockHeight(result), b.getExplorerChainName(chainID), txHash)
+		b.sendVoteConfirmation(channelID, chainID, proposalID, successMsg,
+			fmt.Sprintf("🗳️ <@%s> voted **%s** on behalf of **%s** - tx `%s`", userID, voteOption, granterName, txHash))
+	}
+	return true
+}
+
+// handleSimulateCommand handles vote simulation (dry-run) commands
+func (b *Bot) handleSimulateCommand(channelID, userID string, args []string) {
+	if len(args) < 4 {
+		b.sendMessage(channelID, "❌ Usage: `!prop-simulate <chain> <proposal_id> <vote> <secret> [gas] [memo]` (or `!psimulate` / `!simulate`)")
+		return
+	}
+
+	chainID := args[0]
+	proposalID := args[1]
+	voteOption := strings.ToLower(args[2])
+	secret := args[3]
+
+	// Verify secret
+	if !b.checkVoteSecret(channelID, userID, secret) {
+		b.logger.Warn("Invalid simulate secret provided",
+			zap.String("user_id", userID),
+			zap.String("chain", chainID),
+			zap.String("proposal", proposalID),
+		)
+		return
+	}
+
+	// Validate vote option
+	validVotes := map[string]bool{
+		"yes":          true,
+		"no":           true,
+		"abstain":      true,
+		"no_with_veto": true,
+	}
+
+	if !validVotes[voteOption] {
+		b.sendMessage(channelID, "❌ Invalid vote option. Use: yes, no, abstain, no_with_veto")
+		return
+	}
+
+	opts, err := parseTxOptions(args[4:])
+	if err != nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ %v", err))
+		return
+	}
+
+	// Check if proposal exists
+	var proposal models.Proposal
+	if err := b.db.Where("chain_id = ? AND proposal_id = ?", chainID, proposalID).First(&proposal).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			b.sendMessage(channelID, "❌ Proposal not found")
+		} else {
+			b.sendMessage(channelID, "❌ Database error")
+		}
+		return
+	}
+
+	b.logger.Info("Vote simulation requested",
+		zap.String("user_id", userID),
+		zap.String("chain", chainID),
+		zap.String("proposal", proposalID),
+		zap.String("option", voteOption),
+	)
+
+	b.sendMessage(channelID, fmt.Sprintf("🧪 Simulating vote: **%s** on **%s** proposal **#%s**...", voteOption, chainID, proposalID))
+	b.notifyLedgerConfirmation(channelID, chainID)
+
+	result, err := b.voter.SimulateVote(chainID, proposalID, voteOption, opts)
+	if err != nil {
+		errorDetails := err.Error()
+		if len(errorDetails) > 1500 {
+			errorDetails = errorDetails[:1500] + "...\n[Error truncated - check server logs for full details]"
+		}
+
+		errorMsg := fmt.Sprintf("❌ **Simulation Failed**\n\n**Chain:** %s\n**Proposal:** #%s\n**Vote:** %s\n\n**Error Details:**\n```\n%s\n```",
+			chainID, proposalID, voteOption, errorDetails)
+		b.sendMessage(channelID, errorMsg)
+		return
+	}
+
+	successMsg := fmt.Sprintf("✅ **Simulation Succeeded** (nothing was broadcast)\n\n**Chain:** %s\n**Proposal:** #%s\n**Vote:** %s\n**Gas Used:** %d\n**Estimated Gas (x1.3):** %d\n**Fees:** %s",
+		chainID, proposalID, voteOption, result.GasUsed, result.EstimatedGas, result.Fees)
+	b.sendMessage(channelID, successMsg)
+}
+
+// handleDepositCommand deposits to a proposal from the bot's wallet, warning when the amount
+// is above the chain's max_deposit
+func (b *Bot) handleDepositCommand(channelID, userID string, args []string) {
+	if len(args) < 4 {
+		b.sendMessage(channelID, "❌ Usage: `!deposit <chain> <proposal_id> <amount> <secret>` (amount in base units, e.g. 1000000uatom)")
+		return
+	}
+
+	chainID := args[0]
+	proposalID := args[1]
+	amount := args[2]
+	secret := args[3]
+
+	// Verify secret
+	if !b.checkVoteSecret(channelID, userID, secret) {
+		b.logger.Warn("Invalid deposit secret provided",
+			zap.String("user_id", userID),
+			zap.String("chain", chainID),
+			zap.String("proposal", proposalID),
+		)
+		return
+	}
+
+	exceedsCap, err := b.voter.ValidateDeposit(chainID, amount)
+	if err != nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ %v", err))
+		return
+	}
+	if exceedsCap {
+		b.sendMessage(channelID, fmt.Sprintf("⚠️ **%s** is above %s's max_deposit of %s%s",
+			amount, chainID, b.chains[chainID].MaxDeposit, b.chains[chainID].GetDenom()))
+	}
+
+	b.logger.Info("Deposit requested",
+		zap.String("user_id", userID),
+		zap.String("chain", chainID),
+		zap.String("proposal", proposalID),
+		zap.String("amount", amount),
+	)
+
+	if b.config.Discord.RequireConfirmation {
+		b.requestDepositConfirmation(channelID, userID, chainID, proposalID, amount)
+		return
+	}
+
+	b.submitDeposit(channelID, userID, chainID, proposalID, amount)
+}
+
+// submitDeposit broadcasts a validated deposit and reports the result to the channel
+func (b *Bot) submitDeposit(channelID, userID, chainID, proposalID, amount string) {
+	b.sendMessage(channelID, fmt.Sprintf("💰 Submitting deposit: **%s** on **%s** proposal **#%s**...", amount, chainID, proposalID))
+	b.notifyLedgerConfirmation(channelID, chainID)
+
+	result, err := b.voter.Deposit(chainID, proposalID, amount)
+	if err != nil {
+		errorDetails := err.Error()
+		if len(errorDetails) > 1500 {
+			errorDetails = errorDetails[:1500] + "...\n[Error truncated - check server logs for full details]"
+		}
+
+		errorMsg := fmt.Sprintf("❌ **Deposit Failed**\n\n**Chain:** %s\n**Proposal:** #%s\n**Amount:** %s\n\n**Error Details:**\n```\n%s\n```",
+			chainID, proposalID, amount, errorDetails)
+		b.sendMessage(channelID, errorMsg)
+		return
+	}
+
+	b.logger.Info("Deposit recorded",
+		zap.String("user_id", userID),
+		zap.String("chain", chainID),
+		zap.String("proposal", proposalID),
+		zap.String("amount", amount),
+		zap.String("tx_hash", result.TxHash),
+	)
+
+	successMsg := fmt.Sprintf("✅ **Deposit Submitted Successfully!**\n\n**Chain:** %s\n**Proposal:** #%s\n**Amount:** %s\n**Transaction Hash:** `%s`%s\n\n🔗 [View on Explorer](https://www.mintscan.io/%s/txs/%s)",
+		chainID, proposalID, amount, result.TxHash, formatBlockHeight(result), b.getExplorerChainName(chainID), result.TxHash)
+	b.sendMessage(channelID, successMsg)
+}
+
+// handleCancelCommand cancels a proposal submitted by the chain's wallet
+func (b *Bot) handleCancelCommand(channelID, userID string, args []string) {
+	if len(args) < 3 {
+		b.sendMessage(channelID, "❌ Usage: `!cancel <chain> <proposal_id> <secret>`")
+		return
+	}
+
+	chainID := args[0]
+	proposalID := args[1]
+	secret := args[2]
+
+	// Verify secret
+	if !b.checkVoteSecret(channelID, userID, secret) {
+		b.logger.Warn("Invalid cancel secret provided",
+			zap.String("user_id", userID),
+			zap.String("chain", chainID),
+			zap.String("proposal", proposalID),
+		)
+		return
+	}
+
+	if b.chains[chainID] == nil {
+		b.sendMessage(channelID, "❌ Chain not found in configuration")
+		return
+	}
+
+	b.logger.Info("Proposal cancellation requested",
+		zap.String("user_id", userID),
+		zap.String("chain", chainID),
+		zap.String("proposal", proposalID),
+	)
+
+	b.sendMessage(channelID, fmt.Sprintf("🛑 Cancelling **%s** proposal **#%s**...", chainID, proposalID))
+	b.notifyLedgerConfirmation(channelID, chainID)
+
+	result, err := b.voter.CancelProposal(chainID, proposalID)
+	if err != nil {
+		errorDetails := err.Error()
+		if len(errorDetails) > 1500 {
+			errorDetails = errorDetails[:1500] + "...\n[Error truncated - check server logs for full details]"
+		}
+
+		errorMsg := fmt.Sprintf("❌ **Cancel Failed**\n\n**Chain:** %s\n**Proposal:** #%s\n\n**Error Details:**\n```\n%s\n```",
+			chainID, proposalID, errorDetails)
+		b.sendMessage(channelID, errorMsg)
+		return
+	}
+
+	b.logger.Info("Proposal cancelled",
+		zap.String("user_id", userID),
+		zap.String("chain", chainID),
+		zap.String("proposal", proposalID),
+		zap.String("tx_hash", result.TxHash),
+	)
+
+	successMsg := fmt.Sprintf("✅ **Proposal Cancelled!**\n\n**Chain:** %s\n**Proposal:** #%s\n**Transaction Hash:** `%s`%s\n\n🔗 [View on Explorer](https://www.mintscan.io/%s/txs/%s)",
+		chainID, proposalID, result.TxHash, formatBlockHeight(result), b.getExplorerChainName(chainID), result.TxHash)
+	b.sendMessage(channelID, successMsg)
+}
+
+// getExplorerChainName maps chain IDs to their explorer names for Mintscan URLs
+func (b *Bot) getExplorerChainName(chainID string) string {
+	explorerNames := map[string]string{
+		"cosmoshub-4":     "cosmos",
+		"osmosis-1":       "osmosis",
+		"juno-1":          "juno",
+		"akashnet-2":      "akash",
+		"ssc-1":           "saga",
+		"althea_258432-1": "althea",
+		"omniflixhub-1":   "omniflixhub",
+		"quicksilver-2":   "quicksilver",
+	}
+
+	if explorerName, exists := explorerNames[chainID]; exists {
+		return explorerName
+	}
+
+	// Fallback to chain ID if no mapping found
+	return chainID
+}
+
+// showVersion reports the running build and the version of every managed chain binary
+func (b *Bot) showVersion(channelID string) {
+	var binaries []binmgr.BinaryInfo
+	if b.binaries != nil && b.config.BinaryManager.Enabled {
+		var err error
+		if binaries, err = b.binaries.GetManagedBinaries(); err != nil {
+			b.logger.Error("Failed to list managed binaries", zap.Error(err))
+			b.sendMessage(channelID, "❌ Failed to read binary versions")
+			return
+		}
+	}
+
+	b.sendEmbed(channelID, b.buildVersionEmbed(binaries))
+}
+
+// buildVersionEmbed renders the build details followed by one field per managed binary
+func (b *Bot) buildVersionEmbed(binaries []binmgr.BinaryInfo) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: "🏷️ Prop-Voter Version",
+		Color: 0x0099ff, // Blue
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Version", Value: fmt.Sprintf("`%s`", version.Version), Inline: true},
+			{Name: "Commit", Value: fmt.Sprintf("`%s`", version.Commit), Inline: true},
+			{Name: "Built", Value: fmt.Sprintf("`%s`", version.BuildDate), Inline: true},
+			{Name: "Go", Value: fmt.Sprintf("`%s`", runtime.Version()), Inline: true},
+			{Name: "Platform", Value: fmt.Sprintf("`%s/%s`", runtime.GOOS, runtime.GOARCH), Inline: true},
+		},
+	}
+
+	if len(binaries) == 0 {
+		embed.Description = "No managed chain binaries (binary_manager is disabled or no chain has a binary source)."
+		return embed
+	}
+
+	for idx, binary := range binaries {
+		// Discord embeds are limited to 25 fields
+		if len(embed.Fields) == 25 {
+			embed.Footer = &discordgo.MessageEmbedFooter{
+				Text: fmt.Sprintf("... and %d more binaries", len(binaries)-idx),
+			}
+			break
+		}
+
+		name := binary.ChainID
+		if chain := b.chains[binary.ChainID]; chain != nil {
+			name = chain.GetName()
+		}
+		value := fmt.Sprintf("`%s`", binary.Version)
+		if !binary.LastUpdated.IsZero() {
+			value += fmt.Sprintf(" • updated <t:%d:R>", binary.LastUpdated.Unix())
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%s (%s)", name, binary.Name),
+			Value:  value,
+			Inline: false,
+		})
+	}
+
+	return embed
+}
+
+// listChains lists configured chains with their binary, authz and scan status
+func (b *Bot) listChains(channelID string) {
+	if len(b.config.Chains) == 0 {
+		b.sendMessage(channelID, "No chains configured")
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("⛓️ Configured Chains (%d)", len(b.config.Chains)),
+		Color: 0x0099ff, // Blue
+	}
+
+	for idx, chain := range b.config.Chains {
+		// Discord embeds are limited to 25 fields
+		if idx == 25 {
+			embed.Footer = &discordgo.MessageEmbedFooter{
+				Text: fmt.Sprintf("... and %d more chains", len(b.config.Chains)-25),
+			}
+			break
+		}
+
+		binaryStatus := "❌ missing"
+		binaryPath := filepath.Join(b.config.BinaryManager.GetBinDir(), chain.GetCLIName())
+		if _, err := os.Stat(binaryPath); err == nil {
+			binaryStatus = "✅ present"
+		}
+
+		authzStatus := "➖ disabled"
+		if chain.IsAuthzEnabled() {
+			authzStatus = "✅ enabled"
+		}
+
+		lastScan := "never"
+		if b.scanner != nil {
+			if t, ok := b.scanner.LastScanTime(chain.GetChainID()); ok {
+				lastScan = fmt.Sprintf("<t:%d:R>", t.Unix())
+			}
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%s (`%s`)", chain.GetName(), chain.GetChainID()),
+			Value:  fmt.Sprintf("**Binary:** %s • **Authz:** %s • **Last Scan:** %s", binaryStatus, authzStatus, lastScan),
+			Inline: false,
+		})
+	}
+
+	b.sendEmbed(channelID, embed)
+}
+
+// historyLimit is the number of votes shown by the history command (embeds allow 25 fields)
+const historyLimit = 20
+
+// showHistory lists the most recent votes cast by the bot
+func (b *Bot) showHistory(channelID string, args []string) {
+	var votes []models.Vote
+	query := b.db.Order("voted_at DESC").Limit(historyLimit)
+
+	if len(args) > 0 {
+		query = query.Where("chain_id = ?", args[0])
+	}
+
+	if err := query.Find(&votes).Error; err != nil {
+		b.sendMessage(channelID, "❌ Failed to fetch vote history")
+		return
+	}
+
+	if len(votes) == 0 {
+		b.sendMessage(channelID, "No votes found")
+		return
+	}
+
+	title := "🗂️ Vote History"
+	if len(args) > 0 {
+		title = fmt.Sprintf("🗂️ Vote History - %s", args[0])
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: title,
+		Color: 0x0099ff, // Blue
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Showing the %d most recent votes", len(votes)),
+		},
+	}
+
+	for _, vote := range votes {
+		var value strings.Builder
+		value.WriteString(fmt.Sprintf("**Vote:** %s • <t:%d:d>\n", strings.ToUpper(vote.Option), vote.VotedAt.Unix()))
+		value.WriteString(fmt.Sprintf("**Tx:** `%s`", vote.TxHash))
+
+		if vote.IsAuthzVote {
+			granter := vote.GranterAddr
+			if vote.GranterName != "" {
+				granter = fmt.Sprintf("%s (`%s`)", vote.GranterName, vote.GranterAddr)
+			}
+			value.WriteString(fmt.Sprintf("\n**Authz for:** %s", granter))
+		}
+		if vote.Rationale != "" {
+			value.WriteString(fmt.Sprintf("\n**Reason:** %s", truncateRationale(vote.Rationale)))
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%s - Proposal #%s", vote.ChainID, vote.ProposalID),
+			Value:  value.String(),
+			Inline: false,
+		})
+	}
+
+	b.sendEmbed(channelID, embed)
+}
+
+// historyRationaleLength is how much of each vote's reason !prop-history shows; !prop-status
+// shows it in full
+const historyRationaleLength = 200
+
+// truncateRationale shortens a vote's reason for the history listing
+func truncateRationale(rationale string) string {
+	runes := []rune(rationale)
+	if len(runes) <= historyRationaleLength {
+		return rationale
+	}
+	return string(runes[:historyRationaleLength-3]) + "..."
+}
+
+// pendingLimit caps how many unnotified proposals !prop-pending lists
+const pendingLimit = 20
+
+// showPending lists proposals with notification_sent = false (what the notifier will pick
+// up next), oldest first, with the reason each is still pending
+func (b *Bot) showPending(channelID string, args []string) {
+	query := b.db.Model(&models.Proposal{}).Where("notification_sent = ?", false)
+	if len(args) > 0 {
+		query = query.Where("chain_id = ?", args[0])
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		b.sendMessage(channelID, "❌ Failed to fetch pending notifications")
+		return
+	}
+
+	var proposals []models.Proposal
+	if err := query.Order("created_at ASC").Limit(pendingLimit).Find(&proposals).Error; err != nil {
+		b.sendMessage(channelID, "❌ Failed to fetch pending notifications")
+		return
+	}
+
+	if len(proposals) == 0 {
+		b.sendMessage(channelID, "✅ No pending notifications")
+		return
+	}
+
+	b.sendEmbed(channelID, b.buildPendingEmbed(proposals, total, time.Now()))
+}
+
+// buildPendingEmbed renders unnotified proposals and why each hasn't been sent
+func (b *Bot) buildPendingEmbed(proposals []models.Proposal, total int64, now time.Time) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: "📬 Pending Notifications",
+		Color: 0xff9900, // Orange
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("%d pending • The notifier checks every %s", total, b.notificationInterval()),
+		},
+	}
+	if total > int64(len(proposals)) {
+		embed.Footer.Text = fmt.Sprintf("Showing the oldest %d of %d pending • The notifier checks every %s", len(proposals), total, b.notificationInterval())
+	}
+
+	for _, proposal := range proposals {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%s - Proposal #%s", proposal.ChainID, proposal.ProposalID),
+			Value:  fmt.Sprintf("%s\n**Status:** %s • stored <t:%d:R>\n**Why:** %s", proposal.Title, proposal.Status, proposal.CreatedAt.Unix(), b.pendingReason(proposal, now)),
+			Inline: false,
+		})
+	}
+
+	return embed
+}
+
+// pendingReason explains why a proposal hasn't been notified about yet
+func (b *Bot) pendingReason(proposal models.Proposal, now time.Time) string {
+	interval := b.notificationInterval()
+
+	switch {
+	case proposal.Archived:
+		return "Archived before it was announced; archived proposals are never notified"
+	case b.chains[proposal.ChainID] == nil:
+		return "Chain is no longer configured; it will be sent without chain details"
+	case now.Sub(proposal.CreatedAt) > 3*interval:
+		return fmt.Sprintf("Queued for %s, longer than expected - check the logs for Discord or database errors", formatDuration(now.Sub(proposal.CreatedAt)))
+	default:
+		return "Queued for the next notifier check"
+	}
+}
+
+// handleNotifyCommand re-queues a stored proposal's notification, e.g. one missed while the
+// bot was down or wrongly marked as sent
+func (b *Bot) handleNotifyCommand(channelID, userID string, args []string) {
+	if len(args) < 2 {
+		b.sendMessage(channelID, "❌ Usage: `!notify <chain> <proposal_id>`")
+		return
+	}
+
+	chainID := args[0]
+	proposalID := args[1]
+
+	alreadyQueued, err := b.resetNotification(chainID, proposalID)
+	if err != nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ %v", err))
+		return
+	}
+	if alreadyQueued {
+		b.sendMessage(channelID, fmt.Sprintf("ℹ️ %s - Proposal #%s is already queued for the next notifier check", chainID, proposalID))
+		return
+	}
+
+	b.logger.Info("Proposal notification re-queued",
+		zap.String("chain", chainID),
+		zap.String("proposal_id", proposalID),
+		zap.String("user_id", userID),
+	)
+	b.sendMessage(channelID, fmt.Sprintf("🔁 %s - Proposal #%s will be re-posted within %s", chainID, proposalID, b.notificationInterval()))
+}
+
+// resetNotification clears notification_sent on a stored proposal so checkForNewProposals
+// picks it up again, reporting whether it was already queued. Archived proposals are refused
+// because the notifier skips them
+func (b *Bot) resetNotification(chainID, proposalID string) (bool, error) {
+	var proposal models.Proposal
+	if err := b.db.Where("chain_id = ? AND proposal_id = ?", chainID, proposalID).First(&proposal).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, fmt.Errorf("proposal not found")
+		}
+		return false, fmt.Errorf("database error: %w", err)
+	}
+
+	if proposal.Archived {
+		return false, fmt.Errorf("proposal is archived; archived proposals are never notified")
+	}
+	if !proposal.NotificationSent {
+		return true, nil
+	}
+
+	if err := b.db.Model(&proposal).Update("notification_sent", false).Error; err != nil {
+		return false, fmt.Errorf("failed to re-queue notification: %w", err)
+	}
+	return false, nil
+}
+
+// notificationInterval returns how often checkForNewProposals polls for unnotified proposals
+func (b *Bot) notificationInterval() time.Duration {
+	if b.config.Discord.NotificationInterval <= 0 {
+		return defaultNotificationInterval
+	}
+	return b.config.Discord.NotificationInterval
+}
+
+// showStatus shows voting status for a proposal
+func (b *Bot) showStatus(channelID string, args []string) {
+	if len(args) < 2 {
+		b.sendMessage(channelID, "❌ Usage: `!status <chain> <proposal_id>`")
+		return
+	}
+
+	chainID := args[0]
+	proposalID := args[1]
+
+	var proposal models.Proposal
+	if err := b.db.Preload("Vote").Where("chain_id = ? AND proposal_id = ?", chainID, proposalID).First(&proposal).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			b.sendMessage(channelID, "❌ Proposal not found")
+		} else {
+			b.sendMessage(channelID, "❌ Database error")
+		}
+		return
+	}
+
+	b.sendMessage(channelID, b.buildStatusMessage(proposal))
+}
+
+// buildStatusMessage renders a stored proposal's status, final tally (once voting has ended)
+// and the bot's vote
+func (b *Bot) buildStatusMessage(proposal models.Proposal) string {
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("**%s - Proposal #%s Status**\n\n", proposal.ChainID, proposal.ProposalID))
+	message.WriteString(fmt.Sprintf("Title: %s\n", proposal.Title))
+	message.WriteString(fmt.Sprintf("Status: %s\n", proposal.Status))
+
+	if proposal.VotingEnd != nil {
+		message.WriteString(fmt.Sprintf("Voting Ends: %s\n", proposal.VotingEnd.Format(time.RFC3339)))
+	}
+
+	if tally := b.storedFinalTally(proposal); tally != nil {
+		message.WriteString(fmt.Sprintf("\n**Final Tally:** ✅ Yes %s | ❌ No %s | 🤷 Abstain %s | 🚫 Veto %s\n",
+			tally.Yes, tally.No, tally.Abstain, tally.NoWithVeto))
+	}
+
+	if proposal.Vote != nil {
+		message.WriteString(fmt.Sprintf("\n**Your Vote:** %s\n", proposal.Vote.Option))
+		message.WriteString(fmt.Sprintf("Voted At: %s\n", proposal.Vote.VotedAt.Format(time.RFC3339)))
+		message.WriteString(fmt.Sprintf("Tx Hash: %s\n", proposal.Vote.TxHash))
+		if proposal.Vote.Rationale != "" {
+			message.WriteString(fmt.Sprintf("Reason: %s\n", proposal.Vote.Rationale))
+		}
+	} else {
+		message.WriteString("\n**Your Vote:** Not voted yet")
+	}
+
+	return message.String()
+}
+
+// showInfo queries the chain directly for a proposal's current state, bypassing the database
+func (b *Bot) showInfo(channelID string, args []string) {
+	if len(args) < 2 {
+		b.sendMessage(channelID, "❌ Usage: `!info <chain> <proposal_id>`")
+		return
+	}
+
+	chainID := args[0]
+	proposalID := args[1]
+
+	chainConfig := b.chains[chainID]
+	if chainConfig == nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ Chain configuration not found for %s", chainID))
+		return
+	}
+
+	details, err := b.queryProposalDetails(chainConfig, proposalID)
+	if err != nil {
+		b.logger.Error("Failed to query proposal details",
+			zap.String("chain", chainID),
+			zap.String("proposal_id", proposalID),
+			zap.Error(err),
+		)
+		b.sendMessage(channelID, fmt.Sprintf("❌ Failed to query proposal: %v", err))
+		return
+	}
+
+	b.sendEmbed(channelID, b.buildProposalInfoEmbed(chainConfig, details))
+}
+
+// showBalance reports the voting wallet's balance of the chain's fee denom
+func (b *Bot) showBalance(channelID string, args []string) {
+	if len(args) < 1 {
+		b.sendMessage(channelID, "❌ Usage: `!balance <chain>`")
+		return
+	}
+
+	chainID := args[0]
+
+	chainConfig := b.chains[chainID]
+	if chainConfig == nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ Chain configuration not found for %s", chainID))
+		return
+	}
+
+	balance, err := b.voter.QueryBalance(chainID)
+	if err != nil {
+		b.logger.Error("Failed to query wallet balance",
+			zap.String("chain", chainID),
+			zap.Error(err),
+		)
+		b.sendMessage(channelID, fmt.Sprintf("❌ Failed to query balance: %v", err))
+		return
+	}
+
+	b.sendEmbed(channelID, b.buildBalanceEmbed(chainConfig, balance))
+}
+
+// showAuthzStatus reports whether the bot's wallet holds a gov vote grant from each of the
+// chain's configured granters (or just the one named), and when it expires
+func (b *Bot) showAuthzStatus(channelID string, args []string) {
+	if len(args) < 1 {
+		b.sendMessage(channelID, "❌ Usage: `!authz-status <chain> [granter]`")
+		return
 	}
 
-	if err := b.db.Create(&vote).Error; err != nil {
-		b.logger.Error("Failed to store authz vote", zap.Error(err))
+	chainID := args[0]
+
+	chainConfig := b.chains[chainID]
+	if chainConfig == nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ Chain configuration not found for %s", chainID))
+		return
 	}
 
-	// Enhanced success message
-	if txHash == "UNKNOWN_HASH_CHECK_LOGS" {
-		// Vote succeeded but couldn't parse hash
-		successMsg := fmt.Sprintf("⚠️ **Authz Vote Likely Submitted Successfully!**\n\n**Chain:** %s\n**Proposal:** #%s\n**Vote:** %s\n**Granter:** %s\n\n**Note:** Could not parse transaction hash from CLI output. Check server logs for raw output or verify your vote manually on the explorer.",
-			chainID, proposalID, voteOption, granterName)
-		b.sendMessage(channelID, successMsg)
-	} else {
-		// Normal success with hash
-		successMsg := fmt.Sprintf("✅ **Authz Vote Submitted Successfully!**\n\n**Chain:** %s\n**Proposal:** #%s\n**Vote:** %s\n**Granter:** %s\n**Transaction Hash:** `%s`\n\n🔗 [View on Explorer](https://www.mintscan.io/%s/txs/%s)",
-			chainID, proposalID, voteOption, granterName, txHash, b.getExplorerChainName(chainID), txHash)
-		b.sendMessage(channelID, successMsg)
+	if !chainConfig.IsAuthzEnabled() {
+		b.sendMessage(channelID, fmt.Sprintf("❌ Authz is not enabled for %s (set authz.enabled and authz.granter_addr)", chainConfig.GetName()))
+		return
 	}
-}
 
-// getExplorerChainName maps chain IDs to their explorer names for Mintscan URLs
-func (b *Bot) getExplorerChainName(chainID string) string {
-	explorerNames := map[string]string{
-		"cosmoshub-4":     "cosmos",
-		"osmosis-1":       "osmosis",
-		"juno-1":          "juno",
-		"akashnet-2":      "akash",
-		"ssc-1":           "saga",
-		"althea_258432-1": "althea",
-		"omniflixhub-1":   "omniflixhub",
-		"quicksilver-2":   "quicksilver",
+	granters := chainConfig.GetGranters()
+	if len(args) > 1 {
+		granter := chainConfig.FindGranter(args[1])
+		if granter == nil {
+			b.sendMessage(channelID, fmt.Sprintf("❌ Unknown granter %s for %s. Configured granters: %s",
+				args[1], chainConfig.GetName(), granterNames(granters)))
+			return
+		}
+		granters = []config.AuthzGranter{*granter}
 	}
 
-	if explorerName, exists := explorerNames[chainID]; exists {
-		return explorerName
+	for _, granter := range granters {
+		status, err := b.voter.QueryAuthzGrants(chainID, granter.Address)
+		if err != nil {
+			b.logger.Error("Failed to query authz grants",
+				zap.String("chain", chainID),
+				zap.String("granter", granter.Address),
+				zap.Error(err),
+			)
+			b.sendMessage(channelID, fmt.Sprintf("❌ Failed to query authz grants from %s: %v", granter.DisplayName(), err))
+			continue
+		}
+
+		b.sendEmbed(channelID, b.buildAuthzStatusEmbed(chainConfig, status, time.Now()))
 	}
+}
 
-	// Fallback to chain ID if no mapping found
-	return chainID
+// selfTestCheck is one step of a chain self-test
+type selfTestCheck struct {
+	Name    string
+	Err     error
+	Skipped string // Reason the check was not run
 }
 
-// showStatus shows voting status for a proposal
-func (b *Bot) showStatus(channelID string, args []string) {
-	if len(args) < 2 {
-		b.sendMessage(channelID, "❌ Usage: `!status <chain> <proposal_id>`")
+// runSelfTest validates a single chain end to end: CLI, wallet key, endpoints and a gov query
+func (b *Bot) runSelfTest(channelID string, args []string) {
+	if len(args) < 1 {
+		b.sendMessage(channelID, "❌ Usage: `!selftest <chain>`")
 		return
 	}
 
 	chainID := args[0]
-	proposalID := args[1]
 
+	chainConfig := b.chains[chainID]
+	if chainConfig == nil {
+		b.sendMessage(channelID, fmt.Sprintf("❌ Chain configuration not found for %s", chainID))
+		return
+	}
+
+	b.sendMessage(channelID, fmt.Sprintf("🩺 Running self-test for **%s**...", chainConfig.GetName()))
+
+	checks := []selfTestCheck{
+		{Name: "CLI binary", Err: b.voter.ValidateChainCLI(*chainConfig)},
+		{Name: "Wallet key", Err: b.voter.ValidateWalletKey(*chainConfig)},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	endpoints := b.voter.CheckEndpoints(ctx, *chainConfig)
+	checks = append(checks,
+		selfTestCheck{Name: "REST endpoint", Err: endpoints.RESTErr},
+		selfTestCheck{Name: "RPC endpoint", Err: endpoints.RPCErr},
+	)
+
+	// Query the tally of the most recent proposal we know about
+	tallyCheck := selfTestCheck{Name: "Gov tally query"}
 	var proposal models.Proposal
-	if err := b.db.Preload("Vote").Where("chain_id = ? AND proposal_id = ?", chainID, proposalID).First(&proposal).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			b.sendMessage(channelID, "❌ Proposal not found")
-		} else {
-			b.sendMessage(channelID, "❌ Database error")
+	if err := b.db.Scopes(models.NotArchived).Where("chain_id = ?", chainID).Order("created_at DESC").First(&proposal).Error; err != nil {
+		tallyCheck.Skipped = "no proposals recorded for this chain yet"
+	} else if _, err := b.queryVoteTally(chainConfig, proposal.ProposalID); err != nil {
+		tallyCheck.Err = fmt.Errorf("proposal #%s: %w", proposal.ProposalID, err)
+	}
+	checks = append(checks, tallyCheck)
+
+	failed := 0
+	for _, check := range checks {
+		if check.Err != nil {
+			failed++
+			b.logger.Warn("Self-test check failed",
+				zap.String("chain", chainID),
+				zap.String("check", check.Name),
+				zap.Error(check.Err),
+			)
 		}
-		return
 	}
+	b.logger.Info("Self-test completed", zap.String("chain", chainID), zap.Int("failed", failed))
 
-	var message strings.Builder
-	message.WriteString(fmt.Sprintf("**%s - Proposal #%s Status**\n\n", chainID, proposalID))
-	message.WriteString(fmt.Sprintf("Title: %s\n", proposal.Title))
-	message.WriteString(fmt.Sprintf("Status: %s\n", proposal.Status))
+	b.sendEmbed(channelID, b.buildSelfTestEmbed(chainConfig, checks))
+}
 
-	if proposal.VotingEnd != nil {
-		message.WriteString(fmt.Sprintf("Voting Ends: %s\n", proposal.VotingEnd.Format(time.RFC3339)))
+// buildSelfTestEmbed renders self-test results as a checklist
+func (b *Bot) buildSelfTestEmbed(chainConfig *config.ChainConfig, checks []selfTestCheck) *discordgo.MessageEmbed {
+	var lines []string
+	failed := 0
+	for _, check := range checks {
+		switch {
+		case check.Err != nil:
+			failed++
+			detail := check.Err.Error()
+			if len(detail) > 200 {
+				detail = detail[:200] + "..."
+			}
+			lines = append(lines, fmt.Sprintf("❌ **%s** - %s", check.Name, detail))
+		case check.Skipped != "":
+			lines = append(lines, fmt.Sprintf("➖ **%s** - skipped: %s", check.Name, check.Skipped))
+		default:
+			lines = append(lines, fmt.Sprintf("✅ **%s**", check.Name))
+		}
 	}
 
-	if proposal.Vote != nil {
-		message.WriteString(fmt.Sprintf("\n**Your Vote:** %s\n", proposal.Vote.Option))
-		message.WriteString(fmt.Sprintf("Voted At: %s\n", proposal.Vote.VotedAt.Format(time.RFC3339)))
-		message.WriteString(fmt.Sprintf("Tx Hash: %s\n", proposal.Vote.TxHash))
-	} else {
-		message.WriteString("\n**Your Vote:** Not voted yet")
+	title := fmt.Sprintf("🩺 Self-Test Passed - %s", chainConfig.GetName())
+	color := 0x00ff00 // Green
+	if failed > 0 {
+		title = fmt.Sprintf("🩺 Self-Test Failed (%d/%d checks) - %s", failed, len(checks), chainConfig.GetName())
+		color = 0xff0000 // Red
 	}
 
-	b.sendMessage(channelID, message.String())
+	return &discordgo.MessageEmbed{
+		Title:       title,
+		Description: strings.Join(lines, "\n"),
+		Color:       color,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Chain: %s • Run at: %s", chainConfig.GetChainID(), time.Now().Format("15:04:05")),
+		},
+	}
 }
 
 // sendMessage sends a message to a Discord channel
@@ -493,7 +2104,7 @@ func (b *Bot) sendMessage(channelID, content string) {
 
 // checkForNewProposals periodically checks for new proposals to notify about
 func (b *Bot) checkForNewProposals(ctx context.Context) {
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := time.NewTicker(b.notificationInterval())
 	defer ticker.Stop()
 
 	for {
@@ -502,7 +2113,7 @@ func (b *Bot) checkForNewProposals(ctx context.Context) {
 			return
 		case <-ticker.C:
 			var proposals []models.Proposal
-			if err := b.db.Where("notification_sent = ?", false).Find(&proposals).Error; err != nil {
+			if err := b.db.Scopes(models.NotArchived).Where("notification_sent = ?", false).Find(&proposals).Error; err != nil {
 				b.logger.Error("Failed to fetch unnotified proposals", zap.Error(err))
 				continue
 			}
@@ -514,8 +2125,255 @@ func (b *Bot) checkForNewProposals(ctx context.Context) {
 					return
 				}
 			}
+
+			b.sendStatusChanges()
+		}
+	}
+}
+
+// sendStatusChanges announces every status change the scanner queued for an announced proposal
+func (b *Bot) sendStatusChanges() int {
+	if !b.config.StatusChanges.Enabled {
+		return 0
+	}
+
+	var proposals []models.Proposal
+	if err := b.db.Preload("Vote").Scopes(models.NotArchived).Where("status_change_pending = ?", true).Find(&proposals).Error; err != nil {
+		b.logger.Error("Failed to fetch proposal status changes", zap.Error(err))
+		return 0
+	}
+
+	for _, proposal := range proposals {
+		b.sendStatusChangeNotification(proposal)
+	}
+	return len(proposals)
+}
+
+// storedFinalTally returns the proposal's stored final tally in display units, or nil before
+// voting has ended
+func (b *Bot) storedFinalTally(proposal models.Proposal) *VoteTally {
+	if !proposal.HasFinalTally() {
+		return nil
+	}
+	chainConfig := b.chains[proposal.ChainID]
+	if chainConfig == nil {
+		chainConfig = &config.ChainConfig{}
+	}
+	return &VoteTally{
+		Yes:        b.formatTokenAmount(proposal.FinalTallyYes, chainConfig),
+		No:         b.formatTokenAmount(proposal.FinalTallyNo, chainConfig),
+		Abstain:    b.formatTokenAmount(proposal.FinalTallyAbstain, chainConfig),
+		NoWithVeto: b.formatTokenAmount(proposal.FinalTallyNoWithVeto, chainConfig),
+	}
+}
+
+// buildStatusChangeEmbed renders a status change; tally may be nil when the chain couldn't be
+// queried, and final labels it as the final rather than the running tally
+func (b *Bot) buildStatusChangeEmbed(proposal models.Proposal, tally *VoteTally, final bool) *discordgo.MessageEmbed {
+	chainName := proposal.ChainID
+	proposalURL := ""
+	if chainConfig := b.chains[proposal.ChainID]; chainConfig != nil {
+		chainName = chainConfig.GetName()
+		proposalURL = chainConfig.GetProposalURL(proposal.ProposalID)
+	}
+
+	yourVote := "Not voted"
+	if proposal.Vote != nil {
+		yourVote = strings.ToUpper(proposal.Vote.Option)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("🔔 Status Changed - %s Proposal #%s", chainName, proposal.ProposalID),
+		URL:         proposalURL,
+		Description: proposal.Title,
+		Color:       b.getStatusColor(proposal.Status),
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "📋 Status", Value: fmt.Sprintf("%s → %s", b.formatStatus(proposal.PreviousStatus), b.formatStatus(proposal.Status)), Inline: false},
+			{Name: "🗳️ Your Vote", Value: yourVote, Inline: true},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Chain: %s", proposal.ChainID),
+		},
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	if tally != nil {
+		tallyName := "Final Tally"
+		if !final {
+			tallyName = "Current Tally"
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: "📊 " + tallyName,
+			Value: fmt.Sprintf("✅ Yes: %s\n❌ No: %s\n🤷 Abstain: %s\n🚫 No with Veto: %s",
+				tally.Yes, tally.No, tally.Abstain, tally.NoWithVeto),
+			Inline: false,
+		})
+	}
+
+	return embed
+}
+
+// sendStatusChangeNotification posts a proposal's old and new status with its tally (final
+// once voting has ended) and the bot's vote, then clears the pending flag
+func (b *Bot) sendStatusChangeNotification(proposal models.Proposal) {
+	// Ended proposals carry their stored final tally; otherwise show the live one
+	tally, final := b.storedFinalTally(proposal), true
+	if chainConfig := b.chains[proposal.ChainID]; tally == nil && chainConfig != nil {
+		if details, err := b.queryProposalDetails(chainConfig, proposal.ProposalID); err == nil {
+			tally, final = details.Tally, !strings.Contains(details.Status, "VOTING")
+		} else {
+			b.logger.Warn("Failed to query tally for status change notification",
+				zap.String("chain_id", proposal.ChainID),
+				zap.String("proposal_id", proposal.ProposalID),
+				zap.Error(err),
+			)
+		}
+	}
+	embed := b.buildStatusChangeEmbed(proposal, tally, final)
+
+	if _, err := b.sendProposalUpdate(&proposal, &discordgo.MessageSend{Embed: embed}); err != nil {
+		b.logger.Error("Failed to send status change notification",
+			zap.String("chain_id", proposal.ChainID),
+			zap.String("proposal_id", proposal.ProposalID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if err := b.db.Model(&proposal).Update("status_change_pending", false).Error; err != nil {
+		b.logger.Error("Failed to mark status change as sent", zap.Error(err))
+	}
+
+	b.logger.Info("Status change notification sent",
+		zap.String("chain_id", proposal.ChainID),
+		zap.String("proposal_id", proposal.ProposalID),
+		zap.String("from", proposal.PreviousStatus),
+		zap.String("to", proposal.Status),
+	)
+}
+
+// checkForEndingProposals periodically reminds about proposals ending soon without a vote
+func (b *Bot) checkForEndingProposals(ctx context.Context) {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			proposals, err := b.findProposalsNeedingReminder(time.Now())
+			if err != nil {
+				b.logger.Error("Failed to fetch proposals needing reminders", zap.Error(err))
+				continue
+			}
+
+			for _, proposal := range proposals {
+				b.sendVotingReminder(proposal)
+			}
+		}
+	}
+}
+
+// findProposalsNeedingReminder returns voting proposals ending within the reminder window
+// that have no vote recorded and haven't been reminded about yet
+func (b *Bot) findProposalsNeedingReminder(now time.Time) ([]models.Proposal, error) {
+	var candidates []models.Proposal
+	err := b.db.Preload("Vote").Scopes(models.InVotingPeriod).
+		Where("reminder_sent = ?", false).
+		Where("voting_end IS NOT NULL AND voting_end > ? AND voting_end <= ?", now, now.Add(b.config.Reminders.Window)).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var proposals []models.Proposal
+	for _, proposal := range candidates {
+		if proposal.Vote == nil {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals, nil
+}
+
+// sendVotingReminder sends a reminder embed for a proposal that hasn't been voted on
+func (b *Bot) sendVotingReminder(proposal models.Proposal) {
+	title := fmt.Sprintf("⏰ Voting Ends Soon - %s Proposal #%s", proposal.ChainID, proposal.ProposalID)
+	color := 0xff9900 // Orange
+	if proposal.Expedited {
+		title = fmt.Sprintf("⚡ EXPEDITED Voting Ends Soon - %s Proposal #%s", proposal.ChainID, proposal.ProposalID)
+		color = expeditedColor
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: proposal.Title,
+		Color:       color,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "⏰ Voting Ends",
+				Value:  fmt.Sprintf("<t:%d:R>", proposal.VotingEnd.Unix()),
+				Inline: true,
+			},
+			{
+				Name:   "🗳️ Your Vote",
+				Value:  "Not voted yet",
+				Inline: true,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Vote: !pvote %s %s <yes/no/abstain/no_with_veto> <secret>", proposal.ChainID, proposal.ProposalID),
+		},
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	data := &discordgo.MessageSend{Embed: embed}
+	if b.config.Reminders.Ping {
+		var mentions []string
+		for _, userID := range b.config.Discord.GetAllowedUsers() {
+			mentions = append(mentions, fmt.Sprintf("<@%s>", userID))
 		}
+		if b.config.Discord.UsesRoleAuthorization() {
+			mentions = append(mentions, fmt.Sprintf("<@&%s>", b.config.Discord.AllowedRole))
+		}
+		data.Content = strings.Join(mentions, " ")
+	}
+
+	if _, err := b.sendProposalUpdate(&proposal, data); err != nil {
+		b.logger.Error("Failed to send voting reminder",
+			zap.String("chain_id", proposal.ChainID),
+			zap.String("proposal_id", proposal.ProposalID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if err := b.db.Model(&proposal).Update("reminder_sent", true).Error; err != nil {
+		b.logger.Error("Failed to mark reminder as sent", zap.Error(err))
+	}
+
+	b.logger.Info("Voting reminder sent",
+		zap.String("chain_id", proposal.ChainID),
+		zap.String("proposal_id", proposal.ProposalID),
+	)
+}
+
+// FlushNotifications sends every pending proposal notification and status change and waits
+// for webhook deliveries to finish; used by single-scan mode, where the notification loops
+// never run
+func (b *Bot) FlushNotifications() (int, error) {
+	var proposals []models.Proposal
+	if err := b.db.Scopes(models.NotArchived).Where("notification_sent = ?", false).Find(&proposals).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch unnotified proposals: %w", err)
+	}
+
+	for _, proposal := range proposals {
+		b.sendProposalNotification(proposal)
 	}
+	sent := len(proposals) + b.sendStatusChanges()
+
+	b.webhooks.Wait()
+	return sent, nil
 }
 
 // handleNotifications handles proposal notifications
@@ -538,21 +2396,15 @@ func (b *Bot) sendProposalNotification(proposal models.Proposal) {
 		zap.String("title", proposal.Title),
 	)
 
-	// Find the chain config to get the logo and metadata
-	var chainConfig *config.ChainConfig
-	for i, chain := range b.config.Chains {
-		// Use GetChainID() to support both legacy and Chain Registry formats
-		if chain.GetChainID() == proposal.ChainID {
-			chainConfig = &b.config.Chains[i]
-			b.logger.Debug("Found matching chain config",
-				zap.String("chain_name", chain.GetName()),
-				zap.String("chain_id", chain.GetChainID()),
-			)
-			break
-		}
-	}
-
-	if chainConfig == nil {
+	// Find the chain config to get the logo and metadata (keyed by GetChainID(), so both
+	// legacy and Chain Registry formats match)
+	chainConfig := b.chains[proposal.ChainID]
+	if chainConfig != nil {
+		b.logger.Debug("Found matching chain config",
+			zap.String("chain_name", chainConfig.GetName()),
+			zap.String("chain_id", chainConfig.GetChainID()),
+		)
+	} else {
 		b.logger.Warn("No chain config found for proposal",
 			zap.String("proposal_chain_id", proposal.ChainID),
 			zap.String("proposal_id", proposal.ProposalID),
@@ -562,21 +2414,37 @@ func (b *Bot) sendProposalNotification(proposal models.Proposal) {
 	// Determine chain name and logo (works for both legacy and Chain Registry)
 	chainName := proposal.ChainID // Fallback to chain ID
 	chainLogoURL := ""
+	proposalURL := ""
 
 	if chainConfig != nil {
-		// Get chain name and logo using helper methods
+		// Get chain name, logo and explorer link using helper methods
 		chainName = chainConfig.GetName()
 		chainLogoURL = chainConfig.GetLogoURL()
+		proposalURL = chainConfig.GetProposalURL(proposal.ProposalID)
+	}
+
+	// Link the proposal title to the explorer when one is known
+	proposalTitle := proposal.Title
+	if proposalURL != "" {
+		proposalTitle = fmt.Sprintf("[%s](%s)", proposal.Title, proposalURL)
+	}
+
+	title := fmt.Sprintf("🗳️ New Proposal #%s", proposal.ProposalID)
+	color := b.getStatusColor(proposal.Status)
+	if proposal.Expedited {
+		title = fmt.Sprintf("⚡ EXPEDITED Proposal #%s", proposal.ProposalID)
+		color = expeditedColor
 	}
 
 	// Create rich embed
 	embed := &discordgo.MessageEmbed{
-		Title: fmt.Sprintf("🗳️ New Proposal #%s", proposal.ProposalID),
-		Color: b.getStatusColor(proposal.Status),
+		Title: title,
+		URL:   proposalURL,
+		Color: color,
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Name:   "📋 Title",
-				Value:  proposal.Title,
+				Value:  proposalTitle,
 				Inline: false,
 			},
 			{
@@ -607,6 +2475,48 @@ func (b *Bot) sendProposalNotification(proposal models.Proposal) {
 		}
 	}
 
+	// Warn that expedited proposals have a much shorter voting window
+	if proposal.Expedited {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "⚡ Expedited",
+			Value:  "This proposal has a shortened voting period - vote soon!",
+			Inline: false,
+		})
+	}
+
+	// Label the proposal type, with the plan for software upgrades (operationally critical)
+	if label := formatProposalType(proposal.ProposalType); label != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "🏷️ Type",
+			Value:  label,
+			Inline: true,
+		})
+	}
+	if proposal.UpgradeName != "" || proposal.UpgradeHeight > 0 {
+		name := proposal.UpgradeName
+		if name == "" {
+			name = "(unnamed)"
+		}
+		upgrade := fmt.Sprintf("**%s**", name)
+		if proposal.UpgradeHeight > 0 {
+			upgrade += fmt.Sprintf(" at height **%d**", proposal.UpgradeHeight)
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "⬆️ Upgrade",
+			Value:  upgrade,
+			Inline: false,
+		})
+	}
+
+	// Add proposer if the chain exposed it
+	if proposal.Proposer != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "👤 Proposer",
+			Value:  fmt.Sprintf("`%s`", proposal.Proposer),
+			Inline: false,
+		})
+	}
+
 	// Add voting deadline if available
 	if proposal.VotingEnd != nil {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
@@ -629,16 +2539,66 @@ func (b *Bot) sendProposalNotification(proposal models.Proposal) {
 		})
 	}
 
-	// Send embed with interactive vote tally button
-	b.sendEmbedWithButtons(b.config.Discord.ChannelID, embed, proposal)
+	// Send embed with interactive vote tally button; later updates reply to this message
+	if message := b.sendEmbedWithButtons(b.notificationChannel(proposal.ChainID), embed, b.tallyButtons(proposal)); message != nil {
+		proposal.NotificationChannelID, proposal.NotificationMessageID = message.ChannelID, message.ID
+	}
+
+	// Deliver to the webhook alongside Discord (retries happen in the background)
+	if b.webhook != nil {
+		b.webhooks.Add(1)
+		go func() {
+			defer b.webhooks.Done()
+			b.sendWebhookNotification(proposal, chainConfig)
+		}()
+	}
 
-	// Mark notification as sent
-	proposal.NotificationSent = true
-	if err := b.db.Save(&proposal).Error; err != nil {
+	// Mark notification as sent, touching only these columns so a scanner update made while
+	// the message was being sent (status, status change, final tally) isn't overwritten
+	err := b.db.Model(&proposal).Updates(map[string]interface{}{
+		"notification_sent":       true,
+		"announced":               true,
+		"notification_channel_id": proposal.NotificationChannelID,
+		"notification_message_id": proposal.NotificationMessageID,
+	}).Error
+	if err != nil {
 		b.logger.Error("Failed to mark notification as sent", zap.Error(err))
 	}
 }
 
+// sendWebhookNotification POSTs a proposal notification to the configured webhook
+func (b *Bot) sendWebhookNotification(proposal models.Proposal, chainConfig *config.ChainConfig) {
+	payload := webhook.Payload{
+		Chain:      proposal.ChainID,
+		ProposalID: proposal.ProposalID,
+		Title:      proposal.Title,
+		Status:     proposal.Status,
+		VotingEnd:  proposal.VotingEnd,
+	}
+
+	// Link to the proposal on the explorer, falling back to the chain's REST API
+	if chainConfig != nil {
+		payload.URL = chainConfig.GetProposalURL(proposal.ProposalID)
+		if payload.URL == "" && chainConfig.REST != "" {
+			payload.URL = fmt.Sprintf("%s/cosmos/gov/v1/proposals/%s", strings.TrimSuffix(chainConfig.REST, "/"), proposal.ProposalID)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	if err := b.webhook.Notify(ctx, payload); err != nil {
+		b.logger.Error("Failed to send webhook notification",
+			zap.String("chain_id", proposal.ChainID),
+			zap.String("proposal_id", proposal.ProposalID),
+			zap.Error(err),
+		)
+	}
+}
+
+// expeditedColor highlights expedited proposals, which need faster action
+const expeditedColor = 0xff00aa // Magenta
+
 // getStatusColor returns a color code based on proposal status
 func (b *Bot) getStatusColor(status string) int {
 	switch {
@@ -673,6 +2633,40 @@ func (b *Bot) formatStatus(status string) string {
 	}
 }
 
+// proposalTypeLabels maps proposal message/content names (the last segment of the type URL)
+// to display labels
+var proposalTypeLabels = map[string]string{
+	"TextProposal":                  "📝 Text",
+	"SoftwareUpgradeProposal":       "⬆️ Software Upgrade",
+	"MsgSoftwareUpgrade":            "⬆️ Software Upgrade",
+	"CancelSoftwareUpgradeProposal": "🛑 Cancel Software Upgrade",
+	"MsgCancelUpgrade":              "🛑 Cancel Software Upgrade",
+	"ParameterChangeProposal":       "⚙️ Parameter Change",
+	"MsgUpdateParams":               "⚙️ Parameter Change",
+	"CommunityPoolSpendProposal":    "💸 Community Pool Spend",
+	"MsgCommunityPoolSpend":         "💸 Community Pool Spend",
+	"ClientUpdateProposal":          "🌉 IBC Client Update",
+	"MsgRecoverClient":              "🌉 IBC Client Recovery",
+	"MsgIBCSoftwareUpgrade":         "🌉 IBC Software Upgrade",
+	"StoreCodeProposal":             "📦 CosmWasm Store Code",
+	"MsgStoreCode":                  "📦 CosmWasm Store Code",
+	"MsgExecuteContract":            "📦 CosmWasm Execute",
+}
+
+// formatProposalType returns a human-friendly label for a proposal type URL, falling back to
+// the message name for types without a label
+func formatProposalType(typeURL string) string {
+	if typeURL == "" {
+		return ""
+	}
+
+	name := typeURL[strings.LastIndex(typeURL, ".")+1:]
+	if label, ok := proposalTypeLabels[name]; ok {
+		return label
+	}
+	return fmt.Sprintf("📄 %s", name)
+}
+
 // sendEmbed sends a Discord embed message
 func (b *Bot) sendEmbed(channelID string, embed *discordgo.MessageEmbed) {
 	_, err := b.session.ChannelMessageSendEmbed(channelID, embed)
@@ -684,10 +2678,9 @@ func (b *Bot) sendEmbed(channelID string, embed *discordgo.MessageEmbed) {
 	}
 }
 
-// sendEmbedWithButtons sends a Discord embed with interactive buttons
-func (b *Bot) sendEmbedWithButtons(channelID string, embed *discordgo.MessageEmbed, proposal models.Proposal) {
-	// Create vote tally button
-	components := []discordgo.MessageComponent{
+// tallyButtons creates the vote tally button attached to proposal notifications
+func (b *Bot) tallyButtons(proposal models.Proposal) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
 		discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
 				discordgo.Button{
@@ -701,68 +2694,171 @@ func (b *Bot) sendEmbedWithButtons(channelID string, embed *discordgo.MessageEmb
 			},
 		},
 	}
+}
 
+// sendEmbedWithButtons sends a Discord embed with interactive buttons, returning the sent
+// message (nil if sending failed)
+func (b *Bot) sendEmbedWithButtons(channelID string, embed *discordgo.MessageEmbed, components []discordgo.MessageComponent) *discordgo.Message {
 	data := &discordgo.MessageSend{
 		Embed:      embed,
 		Components: components,
 	}
 
-	_, err := b.session.ChannelMessageSendComplex(channelID, data)
+	message, err := b.session.ChannelMessageSendComplex(channelID, data)
 	if err != nil {
 		b.logger.Error("Failed to send embed with buttons",
 			zap.String("channel", channelID),
 			zap.Error(err),
 		)
+		return nil
 	}
+	return message
 }
 
-// interactionHandler handles Discord button interactions
-func (b *Bot) interactionHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	// Only handle button interactions
-	if i.Type != discordgo.InteractionMessageComponent {
-		return
+// notificationReference returns the reply reference to a proposal's original notification,
+// or nil when none is recorded (announced before threading, or the message was deleted)
+func notificationReference(proposal models.Proposal) *discordgo.MessageReference {
+	if proposal.NotificationChannelID == "" || proposal.NotificationMessageID == "" {
+		return nil
+	}
+	return &discordgo.MessageReference{
+		ChannelID: proposal.NotificationChannelID,
+		MessageID: proposal.NotificationMessageID,
+	}
+}
+
+// sendProposalUpdate posts an update about a proposal as a reply to its original
+// notification, so each proposal keeps one conversation. Without a recorded notification it
+// goes to the chain's notification channel; if the original has been deleted the reference
+// is cleared and the update is posted on its own
+func (b *Bot) sendProposalUpdate(proposal *models.Proposal, data *discordgo.MessageSend) (*discordgo.Message, error) {
+	reference := notificationReference(*proposal)
+	if reference == nil {
+		return b.session.ChannelMessageSendComplex(b.notificationChannel(proposal.ChainID), data)
+	}
+
+	data.Reference = reference
+	message, err := b.session.ChannelMessageSendComplex(reference.ChannelID, data)
+	if err == nil || !b.notificationDeleted(*reference) {
+		return message, err
 	}
 
-	// Check if this is a vote tally button
-	if strings.HasPrefix(i.MessageComponentData().CustomID, "vote_tally_") {
-		b.handleVoteTallyButton(s, i)
+	b.logger.Info("Original proposal notification was deleted, posting updates on their own",
+		zap.String("chain_id", proposal.ChainID),
+		zap.String("proposal_id", proposal.ProposalID),
+		zap.String("message_id", reference.MessageID),
+	)
+	proposal.NotificationChannelID, proposal.NotificationMessageID = "", ""
+	err = b.db.Model(proposal).Updates(map[string]interface{}{
+		"notification_channel_id": "",
+		"notification_message_id": "",
+	}).Error
+	if err != nil {
+		b.logger.Error("Failed to clear deleted notification message", zap.Error(err))
 	}
+
+	data.Reference = nil
+	return b.session.ChannelMessageSendComplex(b.notificationChannel(proposal.ChainID), data)
 }
 
-// handleVoteTallyButton handles vote tally button clicks
-func (b *Bot) handleVoteTallyButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	// Extract chain ID and proposal ID from custom ID
-	// Format: vote_tally_{chainID}_{proposalID}
-	// But chainID might contain underscores, so we need to be careful
-	customID := i.MessageComponentData().CustomID
+// notificationDeleted reports whether Discord no longer knows the referenced message
+func (b *Bot) notificationDeleted(reference discordgo.MessageReference) bool {
+	_, err := b.session.ChannelMessage(reference.ChannelID, reference.MessageID)
+	var restErr *discordgo.RESTError
+	return errors.As(err, &restErr) && restErr.Message != nil && restErr.Message.Code == discordgo.ErrCodeUnknownMessage
+}
 
-	// Remove the "vote_tally_" prefix
-	if !strings.HasPrefix(customID, "vote_tally_") {
-		b.respondWithError(s, i, "Invalid button format")
+// sendVoteConfirmation reports a successful vote in the channel it was cast from and on the
+// proposal's notification: as one reply when the vote was cast in that channel, otherwise
+// with a short summary replied to the notification
+func (b *Bot) sendVoteConfirmation(channelID, chainID, proposalID, content, summary string) {
+	var proposal models.Proposal
+	if err := b.db.Where("chain_id = ? AND proposal_id = ?", chainID, proposalID).First(&proposal).Error; err != nil || notificationReference(proposal) == nil {
+		b.sendMessage(channelID, content)
 		return
 	}
 
-	remainder := strings.TrimPrefix(customID, "vote_tally_")
+	reply := content
+	inChannel := proposal.NotificationChannelID == channelID
+	if !inChannel {
+		b.sendMessage(channelID, content)
+		reply = summary
+	}
+	if _, err := b.sendProposalUpdate(&proposal, &discordgo.MessageSend{Content: reply}); err != nil {
+		b.logger.Error("Failed to post vote confirmation to the proposal notification",
+			zap.String("chain_id", chainID),
+			zap.String("proposal_id", proposalID),
+			zap.Error(err),
+		)
+		if inChannel {
+			b.sendMessage(channelID, content)
+		}
+	}
+}
+
+// interactionHandler handles Discord button and slash command interactions
+func (b *Bot) interactionHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		b.handleSlashCommand(s, i)
+	case discordgo.InteractionMessageComponent:
+		customID := i.MessageComponentData().CustomID
+		switch {
+		case strings.HasPrefix(customID, "vote_tally_"):
+			b.handleVoteTallyButton(s, i)
+		case strings.HasPrefix(customID, "tally_refresh_"):
+			b.handleTallyRefreshButton(s, i)
+		case strings.HasPrefix(customID, "vote_confirm_"), strings.HasPrefix(customID, "vote_cancel_"):
+			b.handleVoteConfirmationButton(s, i)
+		case strings.HasPrefix(customID, "proposals_page_"):
+			b.handleProposalsPageButton(s, i)
+		}
+	}
+}
 
-	// Find the last underscore to separate proposal ID
-	lastUnderscoreIndex := strings.LastIndex(remainder, "_")
-	if lastUnderscoreIndex == -1 {
+// handleProposalsPageButton edits a proposal listing in place to show another page
+func (b *Bot) handleProposalsPageButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	chainFilter, page, ok := parseProposalsPageCustomID(i.MessageComponentData().CustomID)
+	if !ok {
 		b.respondWithError(s, i, "Invalid button data format")
 		return
 	}
 
-	chainID := remainder[:lastUnderscoreIndex]
-	proposalID := remainder[lastUnderscoreIndex+1:]
+	content, components, err := b.buildProposalsPage(chainFilter, page)
+	if err != nil {
+		// Proposals may have been archived since the listing was posted
+		b.respondWithError(s, i, fmt.Sprintf("%v", err))
+		return
+	}
+	if components == nil {
+		// Down to a single page; clear the old buttons
+		components = []discordgo.MessageComponent{}
+	}
 
-	// Find the chain config
-	var chainConfig *config.ChainConfig
-	for idx, chain := range b.config.Chains {
-		if chain.GetChainID() == chainID {
-			chainConfig = &b.config.Chains[idx]
-			break
-		}
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: components,
+		},
+	})
+	if err != nil {
+		b.logger.Error("Failed to update proposal listing", zap.Error(err))
+	}
+}
+
+// handleVoteTallyButton handles vote tally button clicks
+func (b *Bot) handleVoteTallyButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Format: vote_tally_{chainID}_{proposalID}
+	chainID, proposalID, ok := parseProposalCustomID(i.MessageComponentData().CustomID, "vote_tally_")
+	if !ok {
+		b.respondWithError(s, i, "Invalid button data format")
+		return
 	}
 
+	// Find the chain config
+	chainConfig := b.chains[chainID]
+
 	if chainConfig == nil {
 		b.respondWithError(s, i, fmt.Sprintf("Chain configuration not found for %s", chainID))
 		return
@@ -784,7 +2880,101 @@ func (b *Bot) handleVoteTallyButton(s *discordgo.Session, i *discordgo.Interacti
 		return
 	}
 
-	// Create response embed
+	// Send the follow-up response; its Refresh button updates it in place from then on
+	components := tallyRefreshButtons(chainID, proposalID)
+	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds:     []*discordgo.MessageEmbed{b.buildTallyEmbed(chainConfig, proposalID, tally)},
+		Components: components,
+	})
+	if err != nil {
+		b.logger.Error("Failed to send vote tally response", zap.Error(err))
+	}
+}
+
+// handleTallyRefreshButton re-queries the tally and edits the tally message the button is on
+func (b *Bot) handleTallyRefreshButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Format: tally_refresh_{chainID}_{proposalID}
+	chainID, proposalID, ok := parseProposalCustomID(i.MessageComponentData().CustomID, "tally_refresh_")
+	if !ok {
+		b.respondWithError(s, i, "Invalid button data format")
+		return
+	}
+
+	chainConfig := b.chains[chainID]
+
+	if chainConfig == nil {
+		b.respondWithError(s, i, fmt.Sprintf("Chain configuration not found for %s", chainID))
+		return
+	}
+
+	// Acknowledge without a new message; each click carries a fresh interaction token, so
+	// old tally messages stay refreshable after the original token has expired
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	if err != nil {
+		b.logger.Error("Failed to defer tally refresh", zap.Error(err))
+		return
+	}
+
+	tally, err := b.queryVoteTally(chainConfig, proposalID)
+	if err != nil {
+		b.followupWithError(s, i, fmt.Sprintf("Failed to query vote tally: %v", err))
+		return
+	}
+
+	embed := b.buildTallyEmbed(chainConfig, proposalID, tally)
+	components := tallyRefreshButtons(chainID, proposalID)
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	})
+	if err != nil {
+		// The message may have been deleted or the token expired mid-query; post a fresh tally
+		b.logger.Warn("Failed to update tally message, sending a new one",
+			zap.String("chain", chainID),
+			zap.String("proposal", proposalID),
+			zap.Error(err),
+		)
+		b.sendEmbedWithButtons(i.ChannelID, embed, components)
+	}
+}
+
+// parseProposalCustomID splits a {prefix}{chainID}_{proposalID} component ID; chain IDs
+// may contain underscores, so the proposal ID is everything after the last one
+func parseProposalCustomID(customID, prefix string) (chainID, proposalID string, ok bool) {
+	if !strings.HasPrefix(customID, prefix) {
+		return "", "", false
+	}
+	remainder := strings.TrimPrefix(customID, prefix)
+
+	lastUnderscoreIndex := strings.LastIndex(remainder, "_")
+	if lastUnderscoreIndex <= 0 || lastUnderscoreIndex == len(remainder)-1 {
+		return "", "", false
+	}
+	return remainder[:lastUnderscoreIndex], remainder[lastUnderscoreIndex+1:], true
+}
+
+// tallyRefreshButtons returns the Refresh button attached to tally messages
+func tallyRefreshButtons(chainID, proposalID string) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Refresh",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("tally_refresh_%s_%s", chainID, proposalID),
+					Emoji: discordgo.ComponentEmoji{
+						Name: "🔄",
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildTallyEmbed creates the embed used to display a proposal's vote tally
+func (b *Bot) buildTallyEmbed(chainConfig *config.ChainConfig, proposalID string, tally *VoteTally) *discordgo.MessageEmbed {
 	embed := &discordgo.MessageEmbed{
 		Title: fmt.Sprintf("📊 Vote Tally - Proposal #%s", proposalID),
 		Color: 0x3498db, // Blue color
@@ -815,13 +3005,10 @@ func (b *Bot) handleVoteTallyButton(s *discordgo.Session, i *discordgo.Interacti
 		},
 	}
 
-	// Send the follow-up response
-	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
-		Embeds: []*discordgo.MessageEmbed{embed},
-	})
-	if err != nil {
-		b.logger.Error("Failed to send vote tally response", zap.Error(err))
+	if tally.Context != nil {
+		embed.Fields = append(embed.Fields, tallyContextFields(tally.Context)...)
 	}
+	return embed
 }
 
 // VoteTally represents vote tally results
@@ -830,6 +3017,8 @@ type VoteTally struct {
 	No         string `json:"no"`
 	Abstain    string `json:"abstain"`
 	NoWithVeto string `json:"no_with_veto"`
+
+	Context *TallyContext `json:"-"` // Nil when the gov params or bonded total couldn't be fetched
 }
 
 // queryVoteTally queries the chain for vote tally results
@@ -840,21 +3029,13 @@ func (b *Bot) queryVoteTally(chainConfig *config.ChainConfig, proposalID string)
 	apiVersions := []string{"v1", "v1beta1"}
 
 	for _, version := range apiVersions {
-		url := fmt.Sprintf("%s/cosmos/gov/%s/proposals/%s/tally", baseURL, version, proposalID)
-		if b.config.AuthEndpoints.Enabled && b.config.AuthEndpoints.APIKey != "" {
-			// Ensure api_key is appended as the very last query parameter
-			if strings.Contains(url, "?") {
-				url = url + "&api_key=" + b.config.AuthEndpoints.APIKey
-			} else {
-				url = url + "?api_key=" + b.config.AuthEndpoints.APIKey
-			}
-		}
+		url := b.config.AuthEndpoints.AppendQuery(fmt.Sprintf("%s/cosmos/gov/%s/proposals/%s/tally", baseURL, version, proposalID))
 
 		b.logger.Info("Querying vote tally",
 			zap.String("chain", chainConfig.GetName()),
 			zap.String("proposal", proposalID),
 			zap.String("api_version", version),
-			zap.String("url", url),
+			zap.String("url", redact.String(url, b.config.SecretValues()...)),
 		)
 
 		tally, err := b.tryQueryTally(url, version)
@@ -871,18 +3052,36 @@ func (b *Bot) queryVoteTally(chainConfig *config.ChainConfig, proposalID string)
 			zap.String("api_version", version),
 		)
 
+		// Quorum and threshold context is optional; the tally is still shown without it
+		tallyContext, err := b.queryTallyContext(chainConfig, tally, b.proposalExpedited(chainConfig.GetChainID(), proposalID))
+		if err != nil {
+			b.logger.Debug("Tally shown without quorum and threshold context",
+				zap.String("chain", chainConfig.GetName()),
+				zap.Error(err),
+			)
+		}
+
 		// Convert raw amounts to human-readable format
 		return &VoteTally{
 			Yes:        b.formatTokenAmount(tally.Yes, chainConfig),
 			No:         b.formatTokenAmount(tally.No, chainConfig),
 			Abstain:    b.formatTokenAmount(tally.Abstain, chainConfig),
 			NoWithVeto: b.formatTokenAmount(tally.NoWithVeto, chainConfig),
+			Context:    tallyContext,
 		}, nil
 	}
 
 	return nil, fmt.Errorf("failed to query vote tally using any API version")
 }
 
+// proposalExpedited reports whether a stored proposal is expedited; unknown proposals are
+// treated as regular ones
+func (b *Bot) proposalExpedited(chainID, proposalID string) bool {
+	var proposal models.Proposal
+	err := b.db.Select("expedited").Where("chain_id = ? AND proposal_id = ?", chainID, proposalID).Limit(1).Find(&proposal).Error
+	return err == nil && proposal.Expedited
+}
+
 // TallyResponse represents the normalized tally data
 type TallyResponse struct {
 	Yes        string
@@ -907,17 +3106,29 @@ type TallyResponseV1Beta1 struct {
 	NoWithVeto string `json:"no_with_veto"`
 }
 
-// tryQueryTally attempts to query the tally using a specific API version
-func (b *Bot) tryQueryTally(url, version string) (*TallyResponse, error) {
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+// restGet GETs a chain REST URL, sending the API key header when header-style auth is
+// configured (query-style keys are already part of url)
+func (b *Bot) restGet(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
+	b.config.AuthEndpoints.SetHeader(req.Header)
 
-	resp, err := client.Get(url)
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
+	return resp, nil
+}
+
+// tryQueryTally attempts to query the tally using a specific API version
+func (b *Bot) tryQueryTally(url, version string) (*TallyResponse, error) {
+	resp, err := b.restGet(url)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
@@ -975,6 +3186,264 @@ func (b *Bot) tryQueryTally(url, version string) (*TallyResponse, error) {
 	}
 }
 
+// ProposalDetails is a live snapshot of a proposal read directly from the chain
+type ProposalDetails struct {
+	ProposalID string
+	Title      string
+	Status     string
+	VotingEnd  *time.Time
+	Tally      *VoteTally
+}
+
+// queryProposalDetails fetches a proposal's current status, tally and voting end from the
+// chain, trying gov v1 first and falling back to v1beta1
+func (b *Bot) queryProposalDetails(chainConfig *config.ChainConfig, proposalID string) (*ProposalDetails, error) {
+	baseURL := strings.TrimSuffix(chainConfig.REST, "/")
+
+	var lastErr error
+	for _, version := range []string{"v1", "v1beta1"} {
+		url := b.config.AuthEndpoints.AppendQuery(fmt.Sprintf("%s/cosmos/gov/%s/proposals/%s", baseURL, version, proposalID))
+
+		details, err := b.tryQueryProposal(url, version, chainConfig)
+		if err != nil {
+			b.logger.Debug("API version failed, trying next",
+				zap.String("version", version),
+				zap.Error(err),
+			)
+			lastErr = err
+			continue
+		}
+
+		// The final tally stays empty until voting ends, so fetch the running tally instead
+		if strings.Contains(details.Status, "VOTING") {
+			if tally, err := b.queryVoteTally(chainConfig, proposalID); err == nil {
+				details.Tally = tally
+			} else {
+				b.logger.Warn("Failed to query live tally, showing final tally result",
+					zap.String("chain", chainConfig.GetName()),
+					zap.String("proposal_id", proposalID),
+					zap.Error(err),
+				)
+			}
+		}
+
+		return details, nil
+	}
+
+	return nil, fmt.Errorf("failed to query proposal using any API version: %w", lastErr)
+}
+
+// tryQueryProposal queries a single proposal using a specific API version
+func (b *Bot) tryQueryProposal(url, version string, chainConfig *config.ChainConfig) (*ProposalDetails, error) {
+	resp, err := b.restGet(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var (
+		details       ProposalDetails
+		votingEndTime string
+		tally         TallyResponse
+	)
+
+	if version == "v1" {
+		var response struct {
+			Proposal *struct {
+				ID               string          `json:"id"`
+				Title            string          `json:"title"`
+				Status           string          `json:"status"`
+				FinalTallyResult TallyResponseV1 `json:"final_tally_result"`
+				VotingEndTime    string          `json:"voting_end_time"`
+			} `json:"proposal"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to decode v1 response: %w", err)
+		}
+		if response.Proposal == nil {
+			return nil, fmt.Errorf("proposal field is null in v1 response")
+		}
+
+		p := response.Proposal
+		details = ProposalDetails{ProposalID: p.ID, Title: p.Title, Status: p.Status}
+		votingEndTime = p.VotingEndTime
+		tally = TallyResponse(p.FinalTallyResult)
+	} else {
+		var response struct {
+			Proposal *struct {
+				ProposalID string `json:"proposal_id"`
+				Content    struct {
+					Title string `json:"title"`
+				} `json:"content"`
+				Status           string               `json:"status"`
+				FinalTallyResult TallyResponseV1Beta1 `json:"final_tally_result"`
+				VotingEndTime    string               `json:"voting_end_time"`
+			} `json:"proposal"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("failed to decode v1beta1 response: %w", err)
+		}
+		if response.Proposal == nil {
+			return nil, fmt.Errorf("proposal field is null in v1beta1 response")
+		}
+
+		p := response.Proposal
+		details = ProposalDetails{ProposalID: p.ProposalID, Title: p.Content.Title, Status: p.Status}
+		votingEndTime = p.VotingEndTime
+		tally = TallyResponse(p.FinalTallyResult)
+	}
+
+	if t, err := time.Parse(time.RFC3339, votingEndTime); err == nil && !t.IsZero() {
+		details.VotingEnd = &t
+	}
+
+	details.Tally = &VoteTally{
+		Yes:        b.formatTokenAmount(tally.Yes, chainConfig),
+		No:         b.formatTokenAmount(tally.No, chainConfig),
+		Abstain:    b.formatTokenAmount(tally.Abstain, chainConfig),
+		NoWithVeto: b.formatTokenAmount(tally.NoWithVeto, chainConfig),
+	}
+
+	return &details, nil
+}
+
+// buildProposalInfoEmbed creates the embed used to display live proposal details
+func (b *Bot) buildProposalInfoEmbed(chainConfig *config.ChainConfig, details *ProposalDetails) *discordgo.MessageEmbed {
+	title := details.Title
+	if title == "" {
+		title = "(untitled)"
+	}
+
+	votingEnd := "Unknown"
+	if details.VotingEnd != nil {
+		votingEnd = fmt.Sprintf("<t:%d:F> (<t:%d:R>)", details.VotingEnd.Unix(), details.VotingEnd.Unix())
+	}
+
+	return &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("🔎 Proposal #%s - %s", details.ProposalID, title),
+		URL:   chainConfig.GetProposalURL(details.ProposalID),
+		Color: b.getStatusColor(details.Status),
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "📋 Status", Value: b.formatStatus(details.Status), Inline: true},
+			{Name: "⏰ Voting Ends", Value: votingEnd, Inline: true},
+			{Name: "✅ Yes", Value: details.Tally.Yes, Inline: true},
+			{Name: "❌ No", Value: details.Tally.No, Inline: true},
+			{Name: "🤷 Abstain", Value: details.Tally.Abstain, Inline: true},
+			{Name: "🚫 No with Veto", Value: details.Tally.NoWithVeto, Inline: true},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Live from chain • Chain: %s • Updated: %s", chainConfig.GetName(), time.Now().Format("15:04:05")),
+		},
+	}
+}
+
+// buildBalanceEmbed renders the voting wallet's balance, flagging it when low on fees
+func (b *Bot) buildBalanceEmbed(chainConfig *config.ChainConfig, balance *voting.WalletBalance) *discordgo.MessageEmbed {
+	symbol := displayDenom(balance.Denom)
+
+	embed := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("💰 Wallet Balance - %s", chainConfig.GetName()),
+		Color: 0x00ff00, // Green
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "👛 Address", Value: fmt.Sprintf("`%s`", balance.Address), Inline: false},
+			{Name: "💵 Balance", Value: fmt.Sprintf("%s %s", b.formatTokenAmount(balance.Amount, chainConfig), symbol), Inline: true},
+			{Name: "⛽ Fee Threshold", Value: fmt.Sprintf("%s %s", b.formatTokenAmount(balance.Threshold, chainConfig), symbol), Inline: true},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Live from chain • Chain: %s • Updated: %s", chainConfig.GetName(), time.Now().Format("15:04:05")),
+		},
+	}
+
+	if balance.Low() {
+		embed.Color = 0xff9900 // Orange
+		embed.Description = "⚠️ **Balance is below the fee threshold** - top up the wallet or votes may fail with insufficient fees"
+	}
+
+	return embed
+}
+
+// buildAuthzStatusEmbed renders the gov vote grants held by the bot's wallet on a chain
+func (b *Bot) buildAuthzStatusEmbed(chainConfig *config.ChainConfig, status *voting.AuthzGrantStatus, now time.Time) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("🔐 Authz Grant - %s", chainConfig.GetName()),
+		Color: 0x00ff00, // Green
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "🏛️ Granter", Value: fmt.Sprintf("%s\n`%s`", chainConfig.GranterDisplayName(status.Granter), status.Granter), Inline: false},
+			{Name: "🤖 Grantee (bot wallet)", Value: fmt.Sprintf("`%s`", status.Grantee), Inline: false},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Live from chain • Chain: %s • Updated: %s", chainConfig.GetName(), now.Format("15:04:05")),
+		},
+	}
+
+	for _, grant := range status.Grants {
+		expiry := "Never expires"
+		if grant.Expiration != nil {
+			expiry = grant.Expiration.UTC().Format("2006-01-02 15:04 UTC")
+			if grant.Expired(now) {
+				expiry += " (expired)"
+			} else {
+				expiry += fmt.Sprintf(" (in %s)", formatDuration(grant.Expiration.Sub(now)))
+			}
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("📜 `%s`", grant.MsgTypeURL),
+			Value:  expiry,
+			Inline: false,
+		})
+	}
+
+	if status.ActiveGrant(now) == nil {
+		msgType := status.MsgTypeURL
+		if msgType == "" {
+			msgType = voting.MsgVoteV1TypeURL
+		}
+		embed.Color = 0xff0000 // Red
+		embed.Description = fmt.Sprintf("❌ **No valid gov vote grant** - authz votes will fail. The granter must run:\n```\ntx authz grant %s generic --msg-type %s --from <granter-key>\n```",
+			status.Grantee, msgType)
+		return embed
+	}
+
+	embed.Description = "✅ The bot can vote on behalf of the granter"
+	if status.MsgTypeURL != "" {
+		embed.Description += fmt.Sprintf(" - authz votes are sent as `%s`", status.MsgTypeURL)
+	}
+	return embed
+}
+
+// formatDuration renders a duration as days and hours, or minutes when under an hour
+func formatDuration(d time.Duration) string {
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	if days == 0 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dd %dh", days, hours)
+}
+
+// displayDenom turns a base denom such as uatom into its display symbol (ATOM)
+func displayDenom(denom string) string {
+	if strings.Contains(denom, "/") {
+		return denom
+	}
+	if len(denom) > 3 && (denom[0] == 'u' || denom[0] == 'a') {
+		denom = denom[1:]
+	}
+	return strings.ToUpper(denom)
+}
+
 // formatTokenAmount converts raw token amounts to human-readable format
 func (b *Bot) formatTokenAmount(amount string, chainConfig *config.ChainConfig) string {
 	if amount == "" || amount == "0" {
@@ -984,10 +3453,7 @@ func (b *Bot) formatTokenAmount(amount string, chainConfig *config.ChainConfig)
 	// Convert string to float for formatting
 	if value, err := strconv.ParseFloat(amount, 64); err == nil {
 		// Get the correct decimal precision for this chain
-		decimals := 6 // Default to 6 decimals
-		if chainConfig.UsesChainRegistry() && chainConfig.RegistryInfo != nil {
-			decimals = chainConfig.RegistryInfo.Decimals
-		}
+		decimals := chainConfig.GetDecimals()
 
 		// Convert from base units to main units using the chain's decimal precision
 		divisor := 1.0
@@ -1,12 +1,23 @@
 package discord
 
 import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"prop-voter/config"
+	"prop-voter/internal/binmgr"
 	"prop-voter/internal/models"
+	"prop-voter/internal/redact"
+	"prop-voter/internal/voting"
 
+	"github.com/bwmarrin/discordgo"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 	"gorm.io/driver/sqlite"
@@ -102,9 +113,9 @@ func (b *testBot) handleAuthzVoteCommand(channelID string, args []string) {
 
 	// Find the chain configuration and check if authz is enabled
 	var chainConfig *config.ChainConfig
-	for _, chain := range b.config.Chains {
-		if chain.GetChainID() == chainID {
-			chainConfig = &chain
+	for i := range b.config.Chains {
+		if b.config.Chains[i].GetChainID() == chainID {
+			chainConfig = &b.config.Chains[i]
 			break
 		}
 	}
@@ -516,3 +527,1557 @@ func BenchmarkHandleAuthzVoteCommand(b *testing.B) {
 		bot.handleAuthzVoteCommand("test-channel", args)
 	}
 }
+
+func TestSlashCommands(t *testing.T) {
+	bot := &Bot{config: &config.Config{}}
+
+	commands := make(map[string]bool)
+	for _, cmd := range bot.slashCommands() {
+		commands[cmd.Name] = true
+
+		if cmd.Name == "vote" {
+			var hasSecret bool
+			for _, opt := range cmd.Options {
+				if !opt.Required {
+					t.Errorf("Expected /vote option '%s' to be required", opt.Name)
+				}
+				if opt.Name == "secret" {
+					hasSecret = true
+				}
+			}
+			if !hasSecret {
+				t.Error("Expected /vote to take a secret option")
+			}
+		}
+	}
+
+	for _, name := range []string{"proposals", "vote", "status", "tally"} {
+		if !commands[name] {
+			t.Errorf("Expected slash command '%s' to be registered", name)
+		}
+	}
+}
+
+func TestTakePendingVote(t *testing.T) {
+	bot := &Bot{pendingVotes: map[string]pendingVote{
+		"valid":   {ChainID: "test-1", ProposalID: "1", Option: "yes", UserID: "user1", RequestedAt: time.Now()},
+		"expired": {ChainID: "test-1", ProposalID: "2", Option: "no", UserID: "user1", RequestedAt: time.Now().Add(-2 * pendingVoteTTL)},
+	}}
+
+	if _, err := bot.takePendingVote("valid", "user2"); err == nil {
+		t.Error("Expected error when another user confirms the vote")
+	}
+
+	pending, err := bot.takePendingVote("valid", "user1")
+	if err != nil {
+		t.Fatalf("Expected pending vote to be returned, got error: %v", err)
+	}
+	if pending.ProposalID != "1" || pending.Option != "yes" {
+		t.Errorf("Unexpected pending vote: %+v", pending)
+	}
+
+	if _, err := bot.takePendingVote("valid", "user1"); err == nil {
+		t.Error("Expected error when confirming the same vote twice")
+	}
+
+	if _, err := bot.takePendingVote("expired", "user1"); err == nil {
+		t.Error("Expected error for an expired vote request")
+	}
+}
+
+func TestFindProposalsNeedingReminder(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+
+	now := time.Now()
+	soon := now.Add(2 * time.Hour)
+	later := now.Add(72 * time.Hour)
+	past := now.Add(-time.Hour)
+
+	proposals := []models.Proposal{
+		{ChainID: "test-1", ProposalID: "1", Status: "PROPOSAL_STATUS_VOTING_PERIOD", VotingEnd: &soon},                     // needs reminder
+		{ChainID: "test-1", ProposalID: "2", Status: "PROPOSAL_STATUS_VOTING_PERIOD", VotingEnd: &soon},                     // already voted
+		{ChainID: "test-1", ProposalID: "3", Status: "PROPOSAL_STATUS_VOTING_PERIOD", VotingEnd: &later},                    // outside window
+		{ChainID: "test-1", ProposalID: "4", Status: "PROPOSAL_STATUS_VOTING_PERIOD", VotingEnd: &soon, ReminderSent: true}, // already reminded
+		{ChainID: "test-1", ProposalID: "5", Status: "PROPOSAL_STATUS_PASSED", VotingEnd: &past},                            // not voting
+	}
+	for i := range proposals {
+		if err := db.Create(&proposals[i]).Error; err != nil {
+			t.Fatalf("Failed to create proposal: %v", err)
+		}
+	}
+	if err := db.Create(&models.Vote{ChainID: "test-1", ProposalID: "2", Option: "yes", VotedAt: now}).Error; err != nil {
+		t.Fatalf("Failed to create vote: %v", err)
+	}
+
+	bot := &Bot{
+		db:     db,
+		config: &config.Config{Reminders: config.ReminderConfig{Enabled: true, Window: 24 * time.Hour}},
+	}
+
+	found, err := bot.findProposalsNeedingReminder(now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(found) != 1 || found[0].ProposalID != "1" {
+		ids := make([]string, 0, len(found))
+		for _, p := range found {
+			ids = append(ids, p.ProposalID)
+		}
+		t.Errorf("Expected only proposal 1 to need a reminder, got %v", ids)
+	}
+}
+
+func TestParseProposalsArgs(t *testing.T) {
+	tests := []struct {
+		args      []string
+		wantChain string
+		wantPage  int
+	}{
+		{nil, "", 1},
+		{[]string{"cosmoshub-4"}, "cosmoshub-4", 1},
+		{[]string{"3"}, "", 3},
+		{[]string{"cosmoshub-4", "2"}, "cosmoshub-4", 2},
+	}
+	for _, tt := range tests {
+		chain, page, err := parseProposalsArgs(tt.args)
+		if err != nil || chain != tt.wantChain || page != tt.wantPage {
+			t.Errorf("%v: expected %q page %d, got %q page %d (err %v)", tt.args, tt.wantChain, tt.wantPage, chain, page, err)
+		}
+	}
+
+	for _, args := range [][]string{{"0"}, {"cosmoshub-4", "-1"}, {"cosmoshub-4", "osmosis-1"}} {
+		if _, _, err := parseProposalsArgs(args); err == nil {
+			t.Errorf("Expected %v to be rejected", args)
+		}
+	}
+}
+
+func TestBuildProposalsPage(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+
+	// 23 proposals on test_chain-1, created a minute apart so #23 is the newest
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for n := 1; n <= 23; n++ {
+		proposal := models.Proposal{ChainID: "test_chain-1", ProposalID: fmt.Sprint(n), Title: fmt.Sprintf("Proposal %d", n)}
+		proposal.CreatedAt = created.Add(time.Duration(n) * time.Minute)
+		if err := db.Create(&proposal).Error; err != nil {
+			t.Fatalf("Failed to create proposal: %v", err)
+		}
+	}
+	db.Create(&models.Proposal{ChainID: "other-1", ProposalID: "1", Title: "Other"})
+
+	bot := &Bot{db: db, config: &config.Config{}, logger: zaptest.NewLogger(t)}
+
+	content, components, err := bot.buildProposalsPage("test_chain-1", 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(content, "page 3 of 3, 23 total") || !strings.Contains(content, "Proposal #3**") ||
+		!strings.Contains(content, "Proposal #1**") || strings.Contains(content, "Proposal #4**") {
+		t.Errorf("Expected the oldest three proposals on the last page, got:\n%s", content)
+	}
+	buttons := components[0].(discordgo.ActionsRow).Components
+	previous, next := buttons[0].(discordgo.Button), buttons[1].(discordgo.Button)
+	if previous.Disabled || !next.Disabled {
+		t.Errorf("Expected only Previous to be enabled on the last page")
+	}
+	chain, page, ok := parseProposalsPageCustomID(previous.CustomID)
+	if !ok || chain != "test_chain-1" || page != 2 {
+		t.Errorf("Expected Previous to open page 2 of test_chain-1, got %q page %d", chain, page)
+	}
+
+	if _, _, err := bot.buildProposalsPage("test_chain-1", 4); err == nil {
+		t.Error("Expected a page past the end to be rejected")
+	}
+
+	// A single page needs no buttons
+	content, components, err = bot.buildProposalsPage("other-1", 1)
+	if err != nil || components != nil || !strings.Contains(content, "page 1 of 1, 1 total") {
+		t.Errorf("Expected one page without buttons, got %v (err %v):\n%s", components, err, content)
+	}
+
+	// Unfiltered listings page through every chain
+	if chain, page, ok := parseProposalsPageCustomID("proposals_page_2_"); !ok || chain != "" || page != 2 {
+		t.Errorf("Expected page 2 of all chains, got %q page %d", chain, page)
+	}
+	if content, _, _ := bot.buildProposalsPage("", 1); !strings.Contains(content, "24 total") {
+		t.Errorf("Expected all 24 proposals counted, got:\n%s", content)
+	}
+}
+
+func TestDigest(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+
+	now := time.Now()
+	soon := now.Add(2 * time.Hour)
+	later := now.Add(72 * time.Hour)
+	proposals := []models.Proposal{
+		{ChainID: "osmosis-1", ProposalID: "9", Title: "Later", Status: "PROPOSAL_STATUS_VOTING_PERIOD", VotingEnd: &later},
+		{ChainID: "test-1", ProposalID: "1", Title: "Unknown end", Status: "PROPOSAL_STATUS_VOTING_PERIOD"},
+		{ChainID: "test-1", ProposalID: "2", Title: "Soon", Status: "PROPOSAL_STATUS_VOTING_PERIOD", VotingEnd: &soon, Expedited: true},
+		{ChainID: "test-1", ProposalID: "3", Title: "Passed", Status: "PROPOSAL_STATUS_PASSED", VotingEnd: &soon},
+		{ChainID: "test-1", ProposalID: "4", Title: "Archived", Status: "PROPOSAL_STATUS_VOTING_PERIOD", VotingEnd: &soon, Archived: true},
+	}
+	for i := range proposals {
+		if err := db.Create(&proposals[i]).Error; err != nil {
+			t.Fatalf("Failed to create proposal: %v", err)
+		}
+	}
+	if err := db.Create(&models.Vote{ChainID: "osmosis-1", ProposalID: "9", Option: "yes", VotedAt: now}).Error; err != nil {
+		t.Fatalf("Failed to create vote: %v", err)
+	}
+
+	bot := &Bot{db: db, config: &config.Config{}}
+	found, err := bot.findVotingProposals()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var ids []string
+	for _, proposal := range found {
+		ids = append(ids, proposal.ProposalID)
+	}
+	if strings.Join(ids, ",") != "2,9,1" {
+		t.Fatalf("Expected voting proposals by soonest deadline (2,9,1), got %v", ids)
+	}
+
+	embed := bot.buildDigestEmbed(found, now)
+	if len(embed.Fields) != 3 || !strings.Contains(embed.Description, "**2** without a vote") {
+		t.Fatalf("Unexpected digest: %s with %d fields", embed.Description, len(embed.Fields))
+	}
+	if !strings.HasPrefix(embed.Fields[0].Name, "⚡ test-1 #2") || !strings.Contains(embed.Fields[0].Value, "Not voted yet") {
+		t.Errorf("Expected the expedited unvoted proposal first, got %+v", embed.Fields[0])
+	}
+	if !strings.Contains(embed.Fields[1].Value, "Voted **YES**") {
+		t.Errorf("Expected the bot's vote in the digest, got %q", embed.Fields[1].Value)
+	}
+	if !strings.Contains(embed.Fields[2].Value, "Voting end unknown") {
+		t.Errorf("Expected an unknown deadline to be noted, got %q", embed.Fields[2].Value)
+	}
+
+	if empty := bot.buildDigestEmbed(nil, now); len(empty.Fields) != 0 || !strings.Contains(empty.Description, "No proposals") {
+		t.Errorf("Expected an empty digest to say so, got %q", empty.Description)
+	}
+}
+
+func TestBuildStatusChangeEmbed(t *testing.T) {
+	bot := &Bot{
+		config: &config.Config{},
+		logger: zaptest.NewLogger(t),
+		chains: map[string]*config.ChainConfig{"cosmoshub-4": {Name: "Cosmos Hub", ChainID: "cosmoshub-4"}},
+	}
+
+	proposal := models.Proposal{
+		ChainID:              "cosmoshub-4",
+		ProposalID:           "42",
+		Title:                "Enable feature X",
+		Status:               "PROPOSAL_STATUS_PASSED",
+		PreviousStatus:       "PROPOSAL_STATUS_VOTING_PERIOD",
+		FinalTallyYes:        "1500000000",
+		FinalTallyNo:         "10000000",
+		FinalTallyAbstain:    "0",
+		FinalTallyNoWithVeto: "0",
+		Vote:                 &models.Vote{Option: "yes"},
+	}
+
+	embed := bot.buildStatusChangeEmbed(proposal, bot.storedFinalTally(proposal), true)
+	if !strings.Contains(embed.Title, "Cosmos Hub Proposal #42") {
+		t.Errorf("Unexpected title: %s", embed.Title)
+	}
+	if len(embed.Fields) != 3 {
+		t.Fatalf("Expected status, vote and tally fields, got %d", len(embed.Fields))
+	}
+	if got := embed.Fields[0].Value; got != "🔴 **VOTING PERIOD** → ✅ **PASSED**" {
+		t.Errorf("Unexpected status transition: %s", got)
+	}
+	if embed.Fields[1].Value != "YES" {
+		t.Errorf("Expected the bot's vote, got %s", embed.Fields[1].Value)
+	}
+	if !strings.Contains(embed.Fields[2].Name, "Final Tally") || !strings.Contains(embed.Fields[2].Value, "Yes: 1.50K") || !strings.Contains(embed.Fields[2].Value, "No: 10.00") {
+		t.Errorf("Unexpected tally field: %s %s", embed.Fields[2].Name, embed.Fields[2].Value)
+	}
+
+	// A transition into voting shows the running tally
+	proposal.Status, proposal.PreviousStatus = "PROPOSAL_STATUS_VOTING_PERIOD", "PROPOSAL_STATUS_DEPOSIT_PERIOD"
+	embed = bot.buildStatusChangeEmbed(proposal, &VoteTally{Yes: "1", No: "0", Abstain: "0", NoWithVeto: "0"}, false)
+	if !strings.Contains(embed.Fields[2].Name, "Current Tally") {
+		t.Errorf("Expected a current tally, got %s", embed.Fields[2].Name)
+	}
+
+	// Without a reachable chain the change is still announced, just without a tally
+	proposal.Vote = nil
+	embed = bot.buildStatusChangeEmbed(proposal, nil, false)
+	if len(embed.Fields) != 2 || embed.Fields[1].Value != "Not voted" {
+		t.Errorf("Expected status and vote fields only, got %+v", embed.Fields)
+	}
+}
+
+func TestBuildStatusMessageFinalTally(t *testing.T) {
+	bot := &Bot{config: &config.Config{}, chains: map[string]*config.ChainConfig{}}
+
+	proposal := models.Proposal{ChainID: "test-1", ProposalID: "7", Title: "Spend", Status: "PROPOSAL_STATUS_VOTING_PERIOD"}
+	if message := bot.buildStatusMessage(proposal); strings.Contains(message, "Final Tally") {
+		t.Errorf("Expected no final tally while voting, got:\n%s", message)
+	}
+
+	proposal.Status = "PROPOSAL_STATUS_REJECTED"
+	proposal.FinalTallyYes, proposal.FinalTallyNo = "2000000", "5000000000"
+	proposal.FinalTallyAbstain, proposal.FinalTallyNoWithVeto = "0", "1000000"
+	message := bot.buildStatusMessage(proposal)
+	if !strings.Contains(message, "**Final Tally:** ✅ Yes 2.00 | ❌ No 5.00K | 🤷 Abstain 0 | 🚫 Veto 1.00") {
+		t.Errorf("Expected the final tally for an unconfigured chain with default decimals, got:\n%s", message)
+	}
+
+	proposal.Vote = &models.Vote{Option: "no", TxHash: "ABC", Rationale: "Spend is not itemised"}
+	if message := bot.buildStatusMessage(proposal); !strings.Contains(message, "Reason: Spend is not itemised") {
+		t.Errorf("Expected the vote's reason in the status, got:\n%s", message)
+	}
+}
+
+func TestResetNotification(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+
+	proposals := []models.Proposal{
+		{ChainID: "test-1", ProposalID: "1", NotificationSent: true},
+		{ChainID: "test-1", ProposalID: "2", NotificationSent: false},
+		{ChainID: "test-1", ProposalID: "3", NotificationSent: true, Archived: true},
+	}
+	for i := range proposals {
+		if err := db.Create(&proposals[i]).Error; err != nil {
+			t.Fatalf("Failed to create proposal: %v", err)
+		}
+	}
+
+	bot := &Bot{db: db, config: &config.Config{}}
+
+	if queued, err := bot.resetNotification("test-1", "1"); err != nil || queued {
+		t.Fatalf("Expected proposal 1 to be re-queued, got queued=%v err=%v", queued, err)
+	}
+	var stored models.Proposal
+	db.Where("chain_id = ? AND proposal_id = ?", "test-1", "1").First(&stored)
+	if stored.NotificationSent {
+		t.Error("Expected notification_sent to be reset")
+	}
+
+	if queued, err := bot.resetNotification("test-1", "2"); err != nil || !queued {
+		t.Errorf("Expected proposal 2 to be reported as already queued, got queued=%v err=%v", queued, err)
+	}
+	if _, err := bot.resetNotification("test-1", "3"); err == nil || !strings.Contains(err.Error(), "archived") {
+		t.Errorf("Expected archived proposals to be refused, got %v", err)
+	}
+	if _, err := bot.resetNotification("test-1", "99"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a missing proposal to be reported, got %v", err)
+	}
+}
+
+func TestParseTxOptions(t *testing.T) {
+	opts, err := parseTxOptions(nil)
+	if err != nil || opts.Gas != "" {
+		t.Errorf("Expected empty options without extra args, got %+v (err %v)", opts, err)
+	}
+
+	opts, err = parseTxOptions([]string{"400000"})
+	if err != nil || opts.Gas != "400000" {
+		t.Errorf("Expected gas override 400000, got %+v (err %v)", opts, err)
+	}
+
+	opts, err = parseTxOptions([]string{"FIXED"})
+	if err != nil || opts.Gas != voting.GasFixed {
+		t.Errorf("Expected fixed gas, got %+v (err %v)", opts, err)
+	}
+
+	// Anything that isn't a gas override is treated as the memo
+	opts, err = parseTxOptions([]string{"plenty"})
+	if err != nil || opts.Gas != "" || opts.Memo != "plenty" {
+		t.Errorf("Expected non-gas argument to become the memo, got %+v (err %v)", opts, err)
+	}
+}
+
+func TestParseTxOptionsMemo(t *testing.T) {
+	opts, err := parseTxOptions([]string{"300000", "\"Supporting", "the", "upgrade\""})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.Gas != "300000" || opts.Memo != "Supporting the upgrade" {
+		t.Errorf("Expected gas 300000 and memo, got %+v", opts)
+	}
+
+	// A memo without a gas override
+	opts, err = parseTxOptions([]string{"Voted", "after", "review"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.Gas != "" || opts.Memo != "Voted after review" {
+		t.Errorf("Expected memo only, got %+v", opts)
+	}
+
+	if _, err := parseTxOptions([]string{strings.Repeat("x", voting.MaxMemoLength+1)}); err == nil {
+		t.Error("Expected error for memo exceeding max length")
+	}
+}
+
+func TestSplitRationale(t *testing.T) {
+	extra, rationale, err := splitRationale([]string{"300000", "memo", "--reason", "\"Treasury", "policy", "allows", "it\""})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rationale != "Treasury policy allows it" || len(extra) != 2 || extra[1] != "memo" {
+		t.Errorf("Expected the reason split from gas and memo, got %q and %v", rationale, extra)
+	}
+
+	// Without the flag everything is left for parseTxOptions
+	if extra, rationale, err := splitRationale([]string{"fixed"}); err != nil || rationale != "" || len(extra) != 1 {
+		t.Errorf("Expected no reason, got %q and %v (err %v)", rationale, extra, err)
+	}
+
+	if _, _, err := splitRationale([]string{"--reason"}); err == nil {
+		t.Error("Expected an empty reason to be rejected")
+	}
+	if _, _, err := splitRationale([]string{"--REASON", strings.Repeat("x", maxRationaleLength+1)}); err == nil {
+		t.Error("Expected an overly long reason to be rejected")
+	}
+	// The limit counts characters, not bytes
+	if _, rationale, err := splitRationale([]string{"--reason", strings.Repeat("é", maxRationaleLength)}); err != nil || rationale == "" {
+		t.Errorf("Expected %d multi-byte characters to be accepted, got err %v", maxRationaleLength, err)
+	}
+}
+
+func TestTruncateRationale(t *testing.T) {
+	if short := "Treasury policy"; truncateRationale(short) != short {
+		t.Errorf("Expected a short reason unchanged, got %q", truncateRationale(short))
+	}
+
+	truncated := truncateRationale(strings.Repeat("é", historyRationaleLength+1))
+	if !utf8.ValidString(truncated) || utf8.RuneCountInString(truncated) != historyRationaleLength || !strings.HasSuffix(truncated, "...") {
+		t.Errorf("Expected a valid %d-character truncation, got %q", historyRationaleLength, truncated)
+	}
+}
+
+func TestConnectionProblem(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	if problem := connectionProblem(true, now.Add(-time.Minute), now); problem != "" {
+		t.Errorf("Expected a recent heartbeat to be healthy, got %q", problem)
+	}
+	// Before the first heartbeat only readiness counts
+	if problem := connectionProblem(true, time.Time{}, now); problem != "" {
+		t.Errorf("Expected no heartbeat yet to be healthy, got %q", problem)
+	}
+	if problem := connectionProblem(false, now, now); problem == "" {
+		t.Error("Expected a session that isn't ready to be unhealthy")
+	}
+	if problem := connectionProblem(true, now.Add(-staleHeartbeat-time.Second), now); !strings.Contains(problem, "heartbeat") {
+		t.Errorf("Expected a stale heartbeat to be reported, got %q", problem)
+	}
+}
+
+func TestQueryProposalDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/cosmos/gov/v1/proposals/1":
+			w.Write([]byte(`{"proposal":{"id":"1","title":"Live Proposal","status":"PROPOSAL_STATUS_VOTING_PERIOD",` +
+				`"final_tally_result":{"yes_count":"0","no_count":"0","abstain_count":"0","no_with_veto_count":"0"},` +
+				`"voting_end_time":"2030-01-02T15:04:05Z"}}`))
+		case "/cosmos/gov/v1/proposals/1/tally":
+			w.Write([]byte(`{"tally":{"yes_count":"5000000","no_count":"0","abstain_count":"0","no_with_veto_count":"0"}}`))
+		case "/cosmos/gov/v1beta1/proposals/2":
+			w.Write([]byte(`{"proposal":{"proposal_id":"2","content":{"title":"Old Proposal"},"status":"PROPOSAL_STATUS_PASSED",` +
+				`"final_tally_result":{"yes":"2000000","no":"1000000","abstain":"0","no_with_veto":"0"},` +
+				`"voting_end_time":"2020-01-02T15:04:05Z"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+
+	bot := &Bot{db: db, config: &config.Config{}, logger: zaptest.NewLogger(t)}
+	chain := &config.ChainConfig{Name: "Test Chain", ChainID: "test-1", REST: server.URL}
+
+	live, err := bot.queryProposalDetails(chain, "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if live.Title != "Live Proposal" || live.Status != "PROPOSAL_STATUS_VOTING_PERIOD" {
+		t.Errorf("Unexpected v1 details: %+v", live)
+	}
+	if live.VotingEnd == nil || live.VotingEnd.Year() != 2030 {
+		t.Errorf("Expected voting end in 2030, got %v", live.VotingEnd)
+	}
+	if live.Tally.Yes != "5.00" {
+		t.Errorf("Expected live tally yes of 5.00, got %s", live.Tally.Yes)
+	}
+
+	// v1 is unavailable for this proposal, so v1beta1 should be used
+	old, err := bot.queryProposalDetails(chain, "2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if old.Title != "Old Proposal" || old.Tally.Yes != "2.00" || old.Tally.No != "1.00" {
+		t.Errorf("Unexpected v1beta1 details: %+v (tally %+v)", old, old.Tally)
+	}
+
+	if _, err := bot.queryProposalDetails(chain, "404"); err == nil {
+		t.Error("Expected error for unknown proposal")
+	}
+}
+
+func TestSecretGuardLockout(t *testing.T) {
+	var guard secretGuard
+	start := time.Now()
+	window := 10 * time.Minute
+	lockout := 30 * time.Minute
+
+	// Failures spread beyond the window never lock the user out
+	for i := 0; i < 5; i++ {
+		if _, locked := guard.recordFailure("user1", start.Add(time.Duration(i)*window), 5, window, lockout); locked {
+			t.Fatalf("Expected no lockout for failures outside the window (attempt %d)", i+1)
+		}
+	}
+
+	// A valid secret clears earlier failures
+	guard.reset("user1")
+	for i := 0; i < 4; i++ {
+		if _, locked := guard.recordFailure("user1", start.Add(time.Duration(i)*time.Minute), 5, window, lockout); locked {
+			t.Fatalf("Expected no lockout before the limit (attempt %d)", i+1)
+		}
+	}
+	attempts, locked := guard.recordFailure("user1", start.Add(4*time.Minute), 5, window, lockout)
+	if !locked || attempts != 5 {
+		t.Fatalf("Expected lockout on the fifth attempt, got locked=%v attempts=%d", locked, attempts)
+	}
+
+	until, locked := guard.lockout("user1", start.Add(5*time.Minute))
+	if !locked || !until.Equal(start.Add(4*time.Minute+lockout)) {
+		t.Errorf("Expected user to be locked until %v, got %v (locked=%v)", start.Add(4*time.Minute+lockout), until, locked)
+	}
+	if _, locked := guard.lockout("user2", start.Add(5*time.Minute)); locked {
+		t.Error("Expected other users to be unaffected")
+	}
+	if _, locked := guard.lockout("user1", start.Add(4*time.Minute+lockout)); locked {
+		t.Error("Expected lockout to expire")
+	}
+}
+
+func TestNewBotKeepsVoteSecretRedacted(t *testing.T) {
+	cfg := &config.Config{
+		Discord:  config.DiscordConfig{Token: "test-token"},
+		Security: config.SecurityConfig{VoteSecret: "test-secret"},
+	}
+	bot, err := NewBot(nil, cfg, zaptest.NewLogger(t), nil)
+	if err != nil {
+		t.Fatalf("Failed to create bot: %v", err)
+	}
+
+	if !bot.voteSecret.matches("test-secret") {
+		t.Error("Expected the bot to check commands against the configured secret")
+	}
+	if got := redact.String("vote test-secret", cfg.SecretValues()...); strings.Contains(got, "test-secret") {
+		t.Errorf("Expected the vote secret to stay redactable after NewBot, got %q", got)
+	}
+}
+
+func TestSecretHashMatches(t *testing.T) {
+	hash, err := newSecretHash("test-secret")
+	if err != nil {
+		t.Fatalf("Failed to hash secret: %v", err)
+	}
+
+	if !hash.matches("test-secret") {
+		t.Error("Expected the configured secret to match")
+	}
+	for _, candidate := range []string{"", "test-secre", "test-secret2", "TEST-SECRET"} {
+		if hash.matches(candidate) {
+			t.Errorf("Expected %q not to match", candidate)
+		}
+	}
+
+	other, err := newSecretHash("test-secret")
+	if err != nil {
+		t.Fatalf("Failed to hash secret: %v", err)
+	}
+	if string(other.sum) == string(hash.sum) {
+		t.Error("Expected hashes of the same secret to use different salts")
+	}
+
+	var unset secretHash
+	if unset.matches("") {
+		t.Error("Expected an unset hash to match nothing")
+	}
+}
+
+func TestBuildBalanceEmbed(t *testing.T) {
+	bot := &Bot{}
+	chain := &config.ChainConfig{Name: "Cosmos Hub", ChainID: "cosmoshub-4"}
+
+	embed := bot.buildBalanceEmbed(chain, &voting.WalletBalance{Address: "cosmos1abc", Denom: "uatom", Amount: "2500000", Threshold: "50000"})
+	if embed.Description != "" {
+		t.Errorf("Expected no warning for a funded wallet, got %q", embed.Description)
+	}
+	if got := embed.Fields[1].Value; got != "2.50 ATOM" {
+		t.Errorf("Expected balance '2.50 ATOM', got %q", got)
+	}
+
+	embed = bot.buildBalanceEmbed(chain, &voting.WalletBalance{Address: "cosmos1abc", Denom: "uatom", Amount: "1000", Threshold: "50000"})
+	if !strings.Contains(embed.Description, "below the fee threshold") {
+		t.Errorf("Expected a low balance warning, got %q", embed.Description)
+	}
+}
+
+func TestBuildAuthzStatusEmbed(t *testing.T) {
+	bot := &Bot{}
+	chain := &config.ChainConfig{Name: "Cosmos Hub", ChainID: "cosmoshub-4", Authz: config.AuthzConfig{Enabled: true, GranterAddr: "cosmos1granter"}}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	expires := now.Add(50 * time.Hour)
+
+	embed := bot.buildAuthzStatusEmbed(chain, &voting.AuthzGrantStatus{
+		Granter: "cosmos1granter",
+		Grantee: "cosmos1bot",
+		Grants:  []voting.AuthzGrant{{MsgTypeURL: voting.MsgVoteV1TypeURL, Expiration: &expires}},
+	}, now)
+	if !strings.Contains(embed.Description, "can vote") {
+		t.Errorf("Expected a valid grant, got %q", embed.Description)
+	}
+	if got := embed.Fields[2].Value; got != "2024-05-03 14:00 UTC (in 2d 2h)" {
+		t.Errorf("Unexpected expiry %q", got)
+	}
+
+	expired := now.Add(-time.Hour)
+	embed = bot.buildAuthzStatusEmbed(chain, &voting.AuthzGrantStatus{
+		Granter: "cosmos1granter",
+		Grantee: "cosmos1bot",
+		Grants:  []voting.AuthzGrant{{MsgTypeURL: voting.MsgVoteV1TypeURL, Expiration: &expired}},
+	}, now)
+	if !strings.Contains(embed.Description, "No valid gov vote grant") || !strings.Contains(embed.Fields[2].Value, "(expired)") {
+		t.Errorf("Expected an expired grant warning, got %q / %q", embed.Description, embed.Fields[2].Value)
+	}
+
+	embed = bot.buildAuthzStatusEmbed(chain, &voting.AuthzGrantStatus{Granter: "cosmos1granter", Grantee: "cosmos1bot"}, now)
+	if !strings.Contains(embed.Description, "tx authz grant cosmos1bot generic") {
+		t.Errorf("Expected grant instructions when no grant exists, got %q", embed.Description)
+	}
+}
+
+func TestFormatTokenAmount(t *testing.T) {
+	bot := &Bot{}
+
+	tests := []struct {
+		name   string
+		amount string
+		chain  *config.ChainConfig
+		want   string
+	}{
+		{"legacy default 6 decimals", "2500000", &config.ChainConfig{Name: "Cosmos Hub"}, "2.50"},
+		{"legacy 18 decimals", "1500000000000000000000", &config.ChainConfig{Name: "Evmos", Decimals: 18}, "1.50K"},
+		{"registry 18 decimals", "2000000000000000000", &config.ChainConfig{
+			ChainRegistryName: "evmos",
+			RegistryInfo:      &config.ChainRegistryInfo{Decimals: 18},
+		}, "2.00"},
+		{"registry 6 decimals", "3000000000000", &config.ChainConfig{
+			ChainRegistryName: "osmosis",
+			RegistryInfo:      &config.ChainRegistryInfo{Decimals: 6},
+		}, "3.00M"},
+		{"zero", "0", &config.ChainConfig{Name: "Evmos", Decimals: 18}, "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bot.formatTokenAmount(tt.amount, tt.chain); got != tt.want {
+				t.Errorf("formatTokenAmount(%s) = %q, want %q", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSelfTestEmbed(t *testing.T) {
+	bot := &Bot{}
+	chain := &config.ChainConfig{Name: "Cosmos Hub", ChainID: "cosmoshub-4"}
+
+	embed := bot.buildSelfTestEmbed(chain, []selfTestCheck{
+		{Name: "CLI binary"},
+		{Name: "Gov tally query", Skipped: "no proposals recorded for this chain yet"},
+	})
+	if !strings.Contains(embed.Title, "Passed") {
+		t.Errorf("Expected passing title, got %q", embed.Title)
+	}
+	if !strings.Contains(embed.Description, "➖ **Gov tally query** - skipped") {
+		t.Errorf("Expected skipped check in checklist, got %q", embed.Description)
+	}
+
+	embed = bot.buildSelfTestEmbed(chain, []selfTestCheck{
+		{Name: "CLI binary"},
+		{Name: "RPC endpoint", Err: fmt.Errorf("connection refused")},
+	})
+	if !strings.Contains(embed.Title, "Failed (1/2 checks)") {
+		t.Errorf("Expected failure count in title, got %q", embed.Title)
+	}
+	if !strings.Contains(embed.Description, "❌ **RPC endpoint** - connection refused") {
+		t.Errorf("Expected failed check with its error, got %q", embed.Description)
+	}
+}
+
+func TestFormatProposalType(t *testing.T) {
+	tests := map[string]string{
+		"": "",
+		"/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade":              "⬆️ Software Upgrade",
+		"/cosmos.distribution.v1beta1.CommunityPoolSpendProposal": "💸 Community Pool Spend",
+		"/cosmos.gov.v1beta1.TextProposal":                        "📝 Text",
+		"/osmosis.poolmanager.v1beta1.MsgSetDenomPairTakerFee":    "📄 MsgSetDenomPairTakerFee",
+	}
+
+	for typeURL, want := range tests {
+		if got := formatProposalType(typeURL); got != want {
+			t.Errorf("formatProposalType(%q) = %q, want %q", typeURL, got, want)
+		}
+	}
+}
+
+func TestPendingAuthzWarning(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+
+	bot := &Bot{db: db, config: &config.Config{AuthzExpiry: config.AuthzExpiryConfig{Enabled: true, Window: 7 * 24 * time.Hour}}}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	far, soon := now.Add(30*24*time.Hour), now.Add(3*24*time.Hour)
+
+	grantExpiring := func(expiration time.Time) *voting.AuthzGrantStatus {
+		return &voting.AuthzGrantStatus{Granter: "cosmos1granter", Grantee: "cosmos1bot",
+			Grants: []voting.AuthzGrant{{MsgTypeURL: voting.MsgVoteV1TypeURL, Expiration: &expiration}}}
+	}
+
+	if warning, err := bot.pendingAuthzWarning("cosmoshub-4", grantExpiring(far), now); err != nil || warning != nil {
+		t.Fatalf("Expected no warning for a grant outside the window, got %+v, %v", warning, err)
+	}
+	never := &voting.AuthzGrantStatus{Granter: "cosmos1granter", Grants: []voting.AuthzGrant{{MsgTypeURL: voting.MsgVoteV1TypeURL}}}
+	if warning, err := bot.pendingAuthzWarning("cosmoshub-4", never, now); err != nil || warning != nil {
+		t.Fatalf("Expected no warning for a grant without expiration, got %+v, %v", warning, err)
+	}
+
+	warning, err := bot.pendingAuthzWarning("cosmoshub-4", grantExpiring(soon), now)
+	if err != nil || warning == nil || warning.Expired || !warning.Expiration.Equal(soon) {
+		t.Fatalf("Expected an expiring warning, got %+v, %v", warning, err)
+	}
+	if err := bot.recordAuthzWarning("cosmoshub-4", "cosmos1granter", *warning, now); err != nil {
+		t.Fatalf("Failed to record warning: %v", err)
+	}
+
+	// The same grant is only warned about once
+	if warning, err := bot.pendingAuthzWarning("cosmoshub-4", grantExpiring(soon), now.Add(time.Hour)); err != nil || warning != nil {
+		t.Errorf("Expected the repeated warning to be suppressed, got %+v, %v", warning, err)
+	}
+
+	// ...but again once it lapses
+	warning, err = bot.pendingAuthzWarning("cosmoshub-4", grantExpiring(soon), soon.Add(time.Minute))
+	if err != nil || warning == nil || !warning.Expired {
+		t.Fatalf("Expected an expired warning, got %+v, %v", warning, err)
+	}
+	if err := bot.recordAuthzWarning("cosmoshub-4", "cosmos1granter", *warning, soon.Add(time.Minute)); err != nil {
+		t.Fatalf("Failed to record warning: %v", err)
+	}
+
+	// A renewed grant nearing expiry is a new warning
+	renewed := soon.Add(5 * 24 * time.Hour)
+	if warning, err := bot.pendingAuthzWarning("cosmoshub-4", grantExpiring(renewed), soon.Add(time.Hour)); err != nil || warning == nil || !warning.Expiration.Equal(renewed) {
+		t.Errorf("Expected a warning for the renewed grant, got %+v, %v", warning, err)
+	}
+
+	var count int64
+	db.Model(&models.AuthzGrantWarning{}).Count(&count)
+	if count != 1 {
+		t.Errorf("Expected one warning record per chain, got %d", count)
+	}
+
+	// Each granter's grant is tracked on its own
+	other := grantExpiring(soon)
+	other.Granter = "cosmos1other"
+	warning, err = bot.pendingAuthzWarning("cosmoshub-4", other, now)
+	if err != nil || warning == nil {
+		t.Fatalf("Expected a warning for another granter's grant, got %+v, %v", warning, err)
+	}
+	if err := bot.recordAuthzWarning("cosmoshub-4", "cosmos1other", *warning, now); err != nil {
+		t.Fatalf("Failed to record warning: %v", err)
+	}
+	db.Model(&models.AuthzGrantWarning{}).Count(&count)
+	if count != 2 {
+		t.Errorf("Expected one warning record per granter, got %d", count)
+	}
+}
+
+func TestParseProposalCustomID(t *testing.T) {
+	tests := []struct {
+		customID, prefix    string
+		chainID, proposalID string
+		ok                  bool
+	}{
+		{"vote_tally_cosmoshub-4_123", "vote_tally_", "cosmoshub-4", "123", true},
+		{"tally_refresh_my_test_chain_7", "tally_refresh_", "my_test_chain", "7", true},
+		{"vote_tally_cosmoshub-4_123", "tally_refresh_", "", "", false},
+		{"tally_refresh_nounderscore", "tally_refresh_", "", "", false},
+		{"tally_refresh_chain_", "tally_refresh_", "", "", false},
+		{"tally_refresh__5", "tally_refresh_", "", "", false},
+	}
+
+	for _, tt := range tests {
+		chainID, proposalID, ok := parseProposalCustomID(tt.customID, tt.prefix)
+		if chainID != tt.chainID || proposalID != tt.proposalID || ok != tt.ok {
+			t.Errorf("parseProposalCustomID(%q, %q) = %q, %q, %v; want %q, %q, %v",
+				tt.customID, tt.prefix, chainID, proposalID, ok, tt.chainID, tt.proposalID, tt.ok)
+		}
+	}
+}
+
+func TestNotificationChannel(t *testing.T) {
+	osmosis := &config.ChainConfig{Name: "Osmosis", ChainID: "osmosis-1", ChannelID: "osmosis-gov"}
+	juno := &config.ChainConfig{Name: "Juno", ChainID: "juno-1"}
+	bot := &Bot{
+		config: &config.Config{Discord: config.DiscordConfig{ChannelID: "governance"}},
+		chains: map[string]*config.ChainConfig{"osmosis-1": osmosis, "juno-1": juno},
+		logger: zaptest.NewLogger(t),
+	}
+
+	for chainID, want := range map[string]string{"osmosis-1": "osmosis-gov", "juno-1": "governance", "unknown-1": "governance"} {
+		if got := bot.notificationChannel(chainID); got != want {
+			t.Errorf("Expected %s notifications in %s, got %s", chainID, want, got)
+		}
+	}
+
+	for channelID, want := range map[string]bool{"governance": true, "osmosis-gov": true, "random": false, "": false} {
+		if got := bot.isCommandChannel(channelID); got != want {
+			t.Errorf("isCommandChannel(%q) = %v, want %v", channelID, got, want)
+		}
+	}
+}
+
+func TestSendProposalUpdate(t *testing.T) {
+	// A fake Discord API: message "deleted" in channel "gov" is gone, anything else exists
+	var posts []discordgo.MessageSend
+	var postedTo []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/messages/deleted"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":10008,"message":"Unknown Message"}`))
+		case r.Method == http.MethodPost:
+			var data discordgo.MessageSend
+			json.NewDecoder(r.Body).Decode(&data)
+			if data.Reference != nil && data.Reference.MessageID == "deleted" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":50035,"message":"Invalid Form Body"}`))
+				return
+			}
+			posts = append(posts, data)
+			postedTo = append(postedTo, strings.Split(r.URL.Path, "/")[2])
+			w.Write([]byte(`{"id":"reply","channel_id":"gov"}`))
+		default:
+			w.Write([]byte(`{"id":"original","channel_id":"gov"}`))
+		}
+	}))
+	defer server.Close()
+	defer func(endpoint string) { discordgo.EndpointChannels = endpoint }(discordgo.EndpointChannels)
+	discordgo.EndpointChannels = server.URL + "/channels/"
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+	session, _ := discordgo.New("Bot test")
+	bot := &Bot{
+		db:      db,
+		session: session,
+		config:  &config.Config{Discord: config.DiscordConfig{ChannelID: "governance"}},
+		chains:  map[string]*config.ChainConfig{},
+		logger:  zaptest.NewLogger(t),
+	}
+
+	threaded := models.Proposal{ChainID: "test-1", ProposalID: "1", NotificationChannelID: "gov", NotificationMessageID: "original"}
+	deleted := models.Proposal{ChainID: "test-1", ProposalID: "2", NotificationChannelID: "gov", NotificationMessageID: "deleted"}
+	unthreaded := models.Proposal{ChainID: "test-1", ProposalID: "3"}
+	for _, proposal := range []*models.Proposal{&threaded, &deleted, &unthreaded} {
+		if err := db.Create(proposal).Error; err != nil {
+			t.Fatalf("Failed to create proposal: %v", err)
+		}
+		if _, err := bot.sendProposalUpdate(proposal, &discordgo.MessageSend{Content: "update"}); err != nil {
+			t.Fatalf("Unexpected error for proposal %s: %v", proposal.ProposalID, err)
+		}
+	}
+
+	if len(posts) != 3 {
+		t.Fatalf("Expected 3 updates posted, got %d", len(posts))
+	}
+	if posts[0].Reference == nil || posts[0].Reference.MessageID != "original" || postedTo[0] != "gov" {
+		t.Errorf("Expected a reply to the original notification, got %+v in %s", posts[0].Reference, postedTo[0])
+	}
+	for i := 1; i < 3; i++ {
+		if posts[i].Reference != nil || postedTo[i] != "governance" {
+			t.Errorf("Expected update %d posted on its own in the notification channel, got %+v in %s", i, posts[i].Reference, postedTo[i])
+		}
+	}
+
+	var stored models.Proposal
+	db.Where("chain_id = ? AND proposal_id = ?", "test-1", "2").First(&stored)
+	if stored.NotificationMessageID != "" || stored.NotificationChannelID != "" || deleted.NotificationMessageID != "" {
+		t.Errorf("Expected the deleted notification to be forgotten, got %q/%q", stored.NotificationChannelID, stored.NotificationMessageID)
+	}
+}
+
+func TestTallyRefreshButtons(t *testing.T) {
+	components := tallyRefreshButtons("my_test_chain", "7")
+	button := components[0].(discordgo.ActionsRow).Components[0].(discordgo.Button)
+
+	chainID, proposalID, ok := parseProposalCustomID(button.CustomID, "tally_refresh_")
+	if !ok || chainID != "my_test_chain" || proposalID != "7" {
+		t.Errorf("Expected the refresh button to round-trip, got %q (%q, %q, %v)", button.CustomID, chainID, proposalID, ok)
+	}
+}
+
+func TestComputeTallyContext(t *testing.T) {
+	params := &tallyParams{Quorum: big.NewRat(4, 10), Threshold: big.NewRat(1, 2), VetoThreshold: big.NewRat(334, 1000)}
+	bonded := big.NewRat(1000, 1)
+
+	tests := []struct {
+		name    string
+		tally   TallyResponse
+		summary string
+	}{
+		{"passing", TallyResponse{Yes: "300", No: "100", Abstain: "100", NoWithVeto: "0"}, "✅ PASSING"},
+		{"no quorum", TallyResponse{Yes: "300", No: "0", Abstain: "0", NoWithVeto: "0"}, "❌ FAILING (quorum not reached)"},
+		{"vetoed", TallyResponse{Yes: "250", No: "0", Abstain: "0", NoWithVeto: "150"}, "❌ FAILING (vetoed)"},
+		{"below threshold", TallyResponse{Yes: "200", No: "200", Abstain: "100", NoWithVeto: "0"}, "❌ FAILING (below threshold)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := computeTallyContext(&tt.tally, bonded, params)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := ctx.Summary(); got != tt.summary {
+				t.Errorf("Expected %q, got %q (%+v)", tt.summary, got, ctx)
+			}
+		})
+	}
+
+	// Abstain counts towards quorum but not the yes ratio
+	ctx, _ := computeTallyContext(&TallyResponse{Yes: "300", No: "100", Abstain: "100", NoWithVeto: "0"}, bonded, params)
+	if ctx.Turnout != 0.5 || ctx.YesRatio != 0.75 {
+		t.Errorf("Expected turnout 0.5 and yes ratio 0.75, got %+v", ctx)
+	}
+
+	if _, err := computeTallyContext(&TallyResponse{Yes: "lots"}, bonded, params); err == nil {
+		t.Error("Expected an error for an invalid tally amount")
+	}
+}
+
+func TestQueryVoteTallyContext(t *testing.T) {
+	paramsRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/gov/v1/proposals/1/tally":
+			w.Write([]byte(`{"tally":{"yes_count":"340000","no_count":"0","abstain_count":"0","no_with_veto_count":"0"}}`))
+		case "/cosmos/gov/v1/params/tallying":
+			// This chain has no gov v1 API, and its v1beta1 gateway serves base64 decimals
+			http.NotFound(w, r)
+		case "/cosmos/gov/v1beta1/params/tallying":
+			paramsRequests++
+			w.Write([]byte(`{"tally_params":{"quorum":"MC40MDAwMDAwMDAwMDAwMDAwMDA=","threshold":"0.500000000000000000","veto_threshold":"0.334000000000000000"}}`))
+		case "/cosmos/staking/v1beta1/pool":
+			w.Write([]byte(`{"pool":{"not_bonded_tokens":"5","bonded_tokens":"1000000"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+
+	bot := &Bot{db: db, config: &config.Config{}, logger: zaptest.NewLogger(t)}
+	chain := &config.ChainConfig{Name: "Test Chain", ChainID: "test-1", REST: server.URL}
+
+	for i := 0; i < 2; i++ {
+		tally, err := bot.queryVoteTally(chain, "1")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if tally.Context == nil {
+			t.Fatal("Expected quorum and threshold context")
+		}
+
+		embed := bot.buildTallyEmbed(chain, "1", tally)
+		fields := map[string]string{}
+		for _, field := range embed.Fields {
+			fields[field.Name] = field.Value
+		}
+		if fields["🗳️ Quorum"] != "34.0% / 40.0% required" || fields["Currently"] != "❌ FAILING (quorum not reached)" {
+			t.Errorf("Unexpected context fields: %v", fields)
+		}
+	}
+
+	if paramsRequests != 1 {
+		t.Errorf("Expected tallying params to be fetched once and cached, got %d requests", paramsRequests)
+	}
+}
+
+func TestQueryVoteTallyContextExpedited(t *testing.T) {
+	// The primary endpoint serves the tally but fails the params and pool queries
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/cosmos/gov/v1/proposals/1/tally" {
+			w.Write([]byte(`{"tally":{"yes_count":"300000","no_count":"200000","abstain_count":"0","no_with_veto_count":"0"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/gov/v1/params/tallying":
+			w.Write([]byte(`{"params":{"quorum":"0.4","threshold":"0.5","veto_threshold":"0.334","expedited_threshold":"0.667"}}`))
+		case "/cosmos/staking/v1beta1/pool":
+			w.Write([]byte(`{"pool":{"bonded_tokens":"1000000"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer fallback.Close()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+
+	bot := &Bot{db: db, config: &config.Config{}, logger: zaptest.NewLogger(t)}
+	chain := &config.ChainConfig{Name: "Test Chain", ChainID: "test-1", REST: primary.URL, RESTFallbacks: []string{fallback.URL}}
+
+	// 60% yes passes the regular threshold
+	tally, err := bot.queryVoteTally(chain, "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tally.Context == nil {
+		t.Fatal("Expected quorum and threshold context from the fallback endpoint")
+	}
+	if tally.Context.Threshold != 0.5 || !tally.Context.Passing() {
+		t.Errorf("Expected a regular proposal to pass at the 50%% threshold, got %+v", tally.Context)
+	}
+
+	// ...but not an expedited one
+	db.Create(&models.Proposal{ChainID: "test-1", ProposalID: "1", Expedited: true})
+	tally, err = bot.queryVoteTally(chain, "1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tally.Context == nil || tally.Context.Threshold != 0.667 || tally.Context.Quorum != 0.4 || tally.Context.Passing() {
+		t.Errorf("Expected an expedited proposal to fail the 66.7%% threshold, got %+v", tally.Context)
+	}
+}
+
+func TestBuildPendingEmbed(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	bot := &Bot{
+		config: &config.Config{Discord: config.DiscordConfig{NotificationInterval: time.Minute}},
+		chains: map[string]*config.ChainConfig{"cosmoshub-4": {Name: "Cosmos Hub", ChainID: "cosmoshub-4"}},
+	}
+
+	proposals := []models.Proposal{
+		{ChainID: "cosmoshub-4", ProposalID: "1", Title: "Queued", Status: "PROPOSAL_STATUS_VOTING_PERIOD", CreatedAt: now.Add(-30 * time.Second)},
+		{ChainID: "cosmoshub-4", ProposalID: "2", Title: "Stuck", Status: "PROPOSAL_STATUS_VOTING_PERIOD", CreatedAt: now.Add(-2 * time.Hour)},
+		{ChainID: "cosmoshub-4", ProposalID: "3", Title: "Archived", Archived: true, CreatedAt: now},
+		{ChainID: "osmosis-1", ProposalID: "4", Title: "Removed chain", CreatedAt: now},
+	}
+
+	wantReasons := []string{"next notifier check", "longer than expected", "never notified", "no longer configured"}
+	for i, want := range wantReasons {
+		if reason := bot.pendingReason(proposals[i], now); !strings.Contains(reason, want) {
+			t.Errorf("Proposal %s: expected reason containing %q, got %q", proposals[i].ProposalID, want, reason)
+		}
+	}
+
+	embed := bot.buildPendingEmbed(proposals, 6, now)
+	if len(embed.Fields) != len(proposals) {
+		t.Fatalf("Expected %d fields, got %d", len(proposals), len(embed.Fields))
+	}
+	if !strings.Contains(embed.Footer.Text, "4 of 6") {
+		t.Errorf("Expected the footer to note the truncation, got %q", embed.Footer.Text)
+	}
+}
+
+func TestBuildVersionEmbed(t *testing.T) {
+	bot := &Bot{chains: map[string]*config.ChainConfig{"cosmoshub-4": {Name: "Cosmos Hub", ChainID: "cosmoshub-4"}}}
+
+	embed := bot.buildVersionEmbed(nil)
+	if len(embed.Fields) != 5 || embed.Description == "" {
+		t.Errorf("Expected only the build fields and a note without managed binaries, got %+v", embed)
+	}
+
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	embed = bot.buildVersionEmbed([]binmgr.BinaryInfo{
+		{ChainID: "cosmoshub-4", Name: "gaiad", Version: "v16.0.0", LastUpdated: updated},
+		{ChainID: "osmosis-1", Name: "osmosisd", Version: "not installed"},
+	})
+	if len(embed.Fields) != 7 {
+		t.Fatalf("Expected build fields plus two binaries, got %d", len(embed.Fields))
+	}
+	if embed.Fields[5].Name != "Cosmos Hub (gaiad)" || !strings.Contains(embed.Fields[5].Value, "v16.0.0") ||
+		!strings.Contains(embed.Fields[5].Value, fmt.Sprintf("<t:%d:R>", updated.Unix())) {
+		t.Errorf("Unexpected gaiad field: %+v", embed.Fields[5])
+	}
+	if embed.Fields[6].Name != "osmosis-1 (osmosisd)" || !strings.Contains(embed.Fields[6].Value, "not installed") {
+		t.Errorf("Unexpected osmosisd field: %+v", embed.Fields[6])
+	}
+}
+
+func TestAutoVote(t *testing.T) {
+	// A fake Discord API: posts get sequential IDs, reactions are read from vetoes
+	var posts []discordgo.MessageSend
+	vetoes := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/reactions/"):
+			messageID := strings.Split(r.URL.Path, "/")[4]
+			users := `[{"id":"bot","bot":true}]`
+			if user := vetoes[messageID]; user != "" {
+				users = fmt.Sprintf(`[{"id":"bot","bot":true},{"id":%q}]`, user)
+			}
+			w.Write([]byte(users))
+		case r.Method == http.MethodPost:
+			var data discordgo.MessageSend
+			json.NewDecoder(r.Body).Decode(&data)
+			posts = append(posts, data)
+			fmt.Fprintf(w, `{"id":"notice-%d","channel_id":"gov"}`, len(posts))
+		default:
+			w.Write([]byte(`{"id":"original","channel_id":"gov"}`))
+		}
+	}))
+	defer server.Close()
+	defer func(endpoint string) { discordgo.EndpointChannels = endpoint }(discordgo.EndpointChannels)
+	discordgo.EndpointChannels = server.URL + "/channels/"
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+
+	const paramsType = "/cosmos.params.v1beta1.ParameterChangeProposal"
+	now := time.Now()
+	later, soon := now.Add(72*time.Hour), now.Add(5*time.Minute)
+	proposals := []models.Proposal{
+		{ProposalID: "1", ProposalType: paramsType, VotingEnd: &later, NotificationSent: true, NotificationChannelID: "gov", NotificationMessageID: "original"},
+		{ProposalID: "2", ProposalType: "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade", VotingEnd: &later, NotificationSent: true},
+		{ProposalID: "3", ProposalType: paramsType, VotingEnd: &later, NotificationSent: true},
+		{ProposalID: "4", ProposalType: paramsType, VotingEnd: &soon, NotificationSent: true, NotificationChannelID: "gov", NotificationMessageID: "fourth"},
+		{ProposalID: "5", ProposalType: paramsType, VotingEnd: &later},
+		{ProposalID: "6", ProposalType: paramsType, VotingEnd: &later, NotificationSent: true, NotificationChannelID: "gov", NotificationMessageID: "sixth"},
+		// Hidden by the notification filter: marked sent but never posted
+		{ProposalID: "7", ProposalType: paramsType, VotingEnd: &later, NotificationSent: true},
+	}
+	for i := range proposals {
+		proposals[i].ChainID, proposals[i].Status = "test-1", "PROPOSAL_STATUS_VOTING_PERIOD"
+		if err := db.Create(&proposals[i]).Error; err != nil {
+			t.Fatalf("Failed to create proposal: %v", err)
+		}
+	}
+	db.Create(&models.Vote{ChainID: "test-1", ProposalID: "3", Option: "no", VotedAt: now})
+
+	session, _ := discordgo.New("Bot test")
+	chain := &config.ChainConfig{ChainID: "test-1", AutoVote: []config.AutoVoteRule{{Type: "ParameterChange", Option: "yes"}}}
+	bot := &Bot{
+		db:      db,
+		session: session,
+		config: &config.Config{
+			Discord:  config.DiscordConfig{ChannelID: "governance", AllowedUser: "operator"},
+			AutoVote: config.AutoVoteConfig{Enabled: true, Delay: time.Hour},
+		},
+		chains: map[string]*config.ChainConfig{"test-1": chain},
+		logger: zaptest.NewLogger(t),
+	}
+
+	bot.scheduleAutoVotes(now)
+	bot.scheduleAutoVotes(now) // Each proposal is considered once
+
+	var autoVotes []models.AutoVote
+	db.Order("proposal_id").Find(&autoVotes)
+	if len(autoVotes) != 3 || len(posts) != 2 {
+		t.Fatalf("Expected auto-votes for proposals 1, 4 and 6 with two announcements, got %+v and %d posts", autoVotes, len(posts))
+	}
+	if autoVotes[1].ProposalID != "4" || autoVotes[1].Status != models.AutoVoteSkipped {
+		t.Errorf("Expected proposal 4 ending too soon to be skipped, got %+v", autoVotes[1])
+	}
+	first, sixth := autoVotes[0], autoVotes[2]
+	if first.Status != models.AutoVotePending || first.Option != "yes" || !first.DueAt.Equal(now.Add(time.Hour)) || first.MessageID == "" {
+		t.Errorf("Unexpected auto-vote for proposal 1: %+v", first)
+	}
+	if len(posts[0].Embeds) != 1 || posts[0].Embeds[0].Description != "Auto-voting **YES** in 1h unless vetoed" ||
+		posts[0].Reference == nil || posts[0].Reference.MessageID != "original" {
+		t.Errorf("Expected the announcement embed to reply to the notification, got %+v", posts[0])
+	}
+
+	// The loop wakes for the first pending auto-vote due before the next notification check
+	if wait := bot.nextAutoVoteCheck(now.Add(time.Hour - 10*time.Second)); wait != 10*time.Second {
+		t.Errorf("Expected to wait until the auto-vote is due, got %s", wait)
+	}
+	if wait := bot.nextAutoVoteCheck(now); wait != defaultNotificationInterval {
+		t.Errorf("Expected to wait the notification interval, got %s", wait)
+	}
+
+	// Reactions from users who may not vote are ignored
+	bot.messageReactionAddHandler(session, &discordgo.MessageReactionAdd{MessageReaction: &discordgo.MessageReaction{
+		UserID: "stranger", MessageID: first.MessageID, Emoji: discordgo.Emoji{Name: vetoEmoji},
+	}})
+	db.First(&first, first.ID)
+	if first.Status != models.AutoVotePending {
+		t.Fatalf("Expected an unauthorized veto to be ignored, got %+v", first)
+	}
+
+	// At the due time: proposal 1 was vetoed by reaction, proposal 6 was voted on by hand
+	vetoes[first.MessageID] = "operator"
+	db.Create(&models.Vote{ChainID: "test-1", ProposalID: "6", Option: "abstain", VotedAt: now})
+	bot.castDueAutoVotes(now.Add(2 * time.Hour))
+
+	db.First(&first, first.ID)
+	db.First(&sixth, sixth.ID)
+	if first.Status != models.AutoVoteVetoed || !strings.Contains(first.Detail, "<@operator>") {
+		t.Errorf("Expected proposal 1 to be vetoed by the operator, got %+v", first)
+	}
+	if sixth.Status != models.AutoVoteSkipped || !strings.Contains(sixth.Detail, "already voted") {
+		t.Errorf("Expected proposal 6 to be skipped, got %+v", sixth)
+	}
+	if last := posts[len(posts)-1]; !strings.Contains(last.Content, "vetoed") {
+		t.Errorf("Expected the veto to be announced, got %q", last.Content)
+	}
+}
+
+func TestAuthorizeUserRoleLookups(t *testing.T) {
+	lookups := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"user":{"id":"fetched"},"roles":["voter"]}`))
+	}))
+	defer server.Close()
+	defer func(endpoint string) { discordgo.EndpointGuilds = endpoint }(discordgo.EndpointGuilds)
+	discordgo.EndpointGuilds = server.URL + "/guilds/"
+
+	session, _ := discordgo.New("Bot test")
+	session.State.User = &discordgo.User{ID: "bot"}
+	session.State.GuildAdd(&discordgo.Guild{ID: "guild"})
+	session.State.MemberAdd(&discordgo.Member{GuildID: "guild", User: &discordgo.User{ID: "cached"}, Roles: []string{"voter"}})
+
+	bot := &Bot{
+		config: &config.Config{Discord: config.DiscordConfig{ChannelID: "gov", GuildID: "guild", AllowedRole: "voter"}},
+		logger: zaptest.NewLogger(t),
+	}
+
+	if !bot.authorizeUser(session, "cached", "", nil) || lookups != 0 {
+		t.Errorf("Expected a cached member to be authorized without an API call, got %d lookups", lookups)
+	}
+	if !bot.authorizeUser(session, "fetched", "", nil) || lookups != 1 {
+		t.Errorf("Expected an uncached member to be looked up once, got %d lookups", lookups)
+	}
+
+	// Commands outside the configured channels never reach the role check
+	bot.messageHandler(session, &discordgo.MessageCreate{Message: &discordgo.Message{
+		ChannelID: "general", Content: "!prop-help", Author: &discordgo.User{ID: "stranger"},
+	}})
+	if lookups != 1 {
+		t.Errorf("Expected no role lookup for a message in another channel, got %d lookups", lookups)
+	}
+}
+
+func TestSendProposalNotificationKeepsScannerUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"announced","channel_id":"gov"}`))
+	}))
+	defer server.Close()
+	defer func(endpoint string) { discordgo.EndpointChannels = endpoint }(discordgo.EndpointChannels)
+	discordgo.EndpointChannels = server.URL + "/channels/"
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+
+	queued := models.Proposal{ChainID: "test-1", ProposalID: "1", Title: "Queued", Status: "PROPOSAL_STATUS_VOTING_PERIOD"}
+	db.Create(&queued)
+	// The scanner records a status change while the notification is being sent
+	db.Model(&models.Proposal{}).Where("id = ?", queued.ID).Updates(map[string]interface{}{
+		"status": "PROPOSAL_STATUS_PASSED", "previous_status": "PROPOSAL_STATUS_VOTING_PERIOD",
+		"status_change_pending": true, "final_tally_yes": "100",
+	})
+
+	session, _ := discordgo.New("Bot test")
+	bot := &Bot{db: db, session: session, config: &config.Config{Discord: config.DiscordConfig{ChannelID: "gov"}}, logger: zaptest.NewLogger(t)}
+	bot.sendProposalNotification(queued)
+
+	var stored models.Proposal
+	db.First(&stored, queued.ID)
+	if !stored.NotificationSent || !stored.Announced || stored.NotificationMessageID != "announced" {
+		t.Errorf("Expected the notification to be recorded, got %+v", stored)
+	}
+	if stored.Status != "PROPOSAL_STATUS_PASSED" || !stored.StatusChangePending || stored.FinalTallyYes != "100" {
+		t.Errorf("Expected the scanner's update to be kept, got %+v", stored)
+	}
+}
+
+func TestAutoVoteRecordFailure(t *testing.T) {
+	var posts, deletes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			posts++
+			fmt.Fprintf(w, `{"id":"notice-%d","channel_id":"gov"}`, posts)
+		case http.MethodDelete:
+			deletes++
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	defer func(endpoint string) { discordgo.EndpointChannels = endpoint }(discordgo.EndpointChannels)
+	discordgo.EndpointChannels = server.URL + "/channels/"
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+	db.Callback().Create().Before("gorm:create").Register("fail_auto_votes", func(tx *gorm.DB) {
+		if tx.Statement.Table == "auto_votes" {
+			tx.AddError(fmt.Errorf("database is locked"))
+		}
+	})
+
+	now := time.Now()
+	later := now.Add(72 * time.Hour)
+	proposal := &models.Proposal{ChainID: "test-1", ProposalID: "1", VotingEnd: &later, NotificationChannelID: "gov", NotificationMessageID: "original"}
+	session, _ := discordgo.New("Bot test")
+	bot := &Bot{
+		db:      db,
+		session: session,
+		config:  &config.Config{AutoVote: config.AutoVoteConfig{Enabled: true, Delay: time.Hour}},
+		logger:  zaptest.NewLogger(t),
+	}
+
+	bot.scheduleAutoVote(proposal, &config.AutoVoteRule{Type: "*", Option: "yes"}, now)
+	if posts != 1 || deletes != 1 {
+		t.Errorf("Expected the unrecorded announcement to be deleted, got %d posts and %d deletes", posts, deletes)
+	}
+}
+
+func TestAutoVoteRuleNoLongerApplies(t *testing.T) {
+	var posts []discordgo.MessageSend
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`[]`))
+			return
+		}
+		var data discordgo.MessageSend
+		json.NewDecoder(r.Body).Decode(&data)
+		posts = append(posts, data)
+		w.Write([]byte(`{"id":"reply","channel_id":"gov"}`))
+	}))
+	defer server.Close()
+	defer func(endpoint string) { discordgo.EndpointChannels = endpoint }(discordgo.EndpointChannels)
+	discordgo.EndpointChannels = server.URL + "/channels/"
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+
+	now := time.Now()
+	later := now.Add(72 * time.Hour)
+	db.Create(&models.Proposal{ChainID: "test-1", ProposalID: "1", ProposalType: "/cosmos.params.v1beta1.ParameterChangeProposal",
+		Status: "PROPOSAL_STATUS_VOTING_PERIOD", VotingEnd: &later})
+	yesOnParams := config.AutoVoteRule{Type: "ParameterChange", Option: "yes"}
+	autoVote := models.AutoVote{ChainID: "test-1", ProposalID: "1", Option: "yes", Rule: yesOnParams.String(),
+		Status: models.AutoVotePending, ChannelID: "gov", MessageID: "notice", DueAt: now}
+	db.Create(&autoVote)
+
+	// The rule was changed to vote no before the restart; the yes vote must not be cast
+	chain := &config.ChainConfig{ChainID: "test-1", AutoVote: []config.AutoVoteRule{{Type: "ParameterChange", Option: "no"}}}
+	session, _ := discordgo.New("Bot test")
+	bot := &Bot{
+		db:      db,
+		session: session,
+		config:  &config.Config{AutoVote: config.AutoVoteConfig{Enabled: true, Delay: time.Hour}},
+		chains:  map[string]*config.ChainConfig{"test-1": chain},
+		logger:  zaptest.NewLogger(t),
+	}
+	bot.castDueAutoVotes(now.Add(time.Minute))
+
+	db.First(&autoVote, autoVote.ID)
+	if autoVote.Status != models.AutoVoteSkipped || !strings.Contains(autoVote.Detail, "no longer applies") {
+		t.Errorf("Expected the auto-vote to be skipped, got %+v", autoVote)
+	}
+	if len(posts) != 1 || !strings.Contains(posts[0].Content, "not cast") {
+		t.Errorf("Expected the skip to be announced, got %+v", posts)
+	}
+}
+
+func TestAutoVoteReactionVeto(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"reply","channel_id":"gov"}`))
+	}))
+	defer server.Close()
+	defer func(endpoint string) { discordgo.EndpointChannels = endpoint }(discordgo.EndpointChannels)
+	discordgo.EndpointChannels = server.URL + "/channels/"
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+	autoVote := models.AutoVote{ChainID: "test-1", ProposalID: "1", Option: "yes", Status: models.AutoVotePending, ChannelID: "gov", MessageID: "notice", DueAt: time.Now().Add(time.Hour)}
+	db.Create(&autoVote)
+
+	session, _ := discordgo.New("Bot test")
+	bot := &Bot{db: db, session: session, config: &config.Config{Discord: config.DiscordConfig{AllowedUser: "operator"}}, logger: zaptest.NewLogger(t)}
+
+	react := func(userID, emoji string) {
+		bot.messageReactionAddHandler(session, &discordgo.MessageReactionAdd{MessageReaction: &discordgo.MessageReaction{
+			UserID: userID, MessageID: "notice", ChannelID: "gov", Emoji: discordgo.Emoji{Name: emoji},
+		}})
+		db.First(&autoVote, autoVote.ID)
+	}
+
+	if react("operator", "👍"); autoVote.Status != models.AutoVotePending {
+		t.Fatalf("Expected other reactions to be ignored, got %+v", autoVote)
+	}
+	if react("operator", vetoEmoji); autoVote.Status != models.AutoVoteVetoed || autoVote.Detail != "vetoed by <@operator>" {
+		t.Errorf("Expected the operator's reaction to veto the auto-vote, got %+v", autoVote)
+	}
+}
+
+func TestDepositAndAuthzVoteRequireConfirmation(t *testing.T) {
+	var posts []discordgo.MessageSend
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var data discordgo.MessageSend
+		json.NewDecoder(r.Body).Decode(&data)
+		posts = append(posts, data)
+		w.Write([]byte(`{"id":"prompt","channel_id":"gov"}`))
+	}))
+	defer server.Close()
+	defer func(endpoint string) { discordgo.EndpointChannels = endpoint }(discordgo.EndpointChannels)
+	discordgo.EndpointChannels = server.URL + "/channels/"
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database schema: %v", err)
+	}
+	db.Create(&models.Proposal{ChainID: "test-1", ProposalID: "1", Title: "Upgrade"})
+
+	cfg := &config.Config{
+		Discord:  config.DiscordConfig{Token: "test-token", ChannelID: "gov", RequireConfirmation: true},
+		Security: config.SecurityConfig{VoteSecret: "test-secret"},
+		Chains: []config.ChainConfig{{
+			ChainID: "test-1",
+			Denom:   "utest",
+			Authz: config.AuthzConfig{Enabled: true, Granters: []config.AuthzGranter{
+				{Address: "test1alice", Name: "alice"},
+				{Address: "test1bob", Name: "bob"},
+			}},
+		}},
+	}
+	logger := zaptest.NewLogger(t)
+	bot, err := NewBot(db, cfg, logger, voting.NewVoter(cfg, logger))
+	if err != nil {
+		t.Fatalf("Failed to create bot: %v", err)
+	}
+
+	bot.handleDepositCommand("gov", "user1", []string{"test-1", "1", "1000000utest", "test-secret"})
+	bot.handleVoteAuthzCommand("gov", "user1", []string{"test-1", "1", "yes", "all", "test-secret"})
+
+	if len(posts) != 2 {
+		t.Fatalf("Expected only the two confirmation prompts to be posted, got %d", len(posts))
+	}
+	for i, title := range []string{"💰 Confirm Deposit", "🗳️ Confirm Authz Vote"} {
+		if len(posts[i].Embeds) != 1 || posts[i].Embeds[0].Title != title || len(posts[i].Components) != 1 {
+			t.Errorf("Expected a %q prompt with buttons, got %+v", title, posts[i])
+		}
+	}
+	if got := posts[1].Embeds[0].Fields[3].Value; got != "alice, bob" {
+		t.Errorf("Expected the prompt to name every granter, got %q", got)
+	}
+
+	actions := make(map[string]bool)
+	for _, pending := range bot.pendingVotes {
+		actions[pending.Action] = pending.Submit != nil
+	}
+	if !actions["Deposit"] || !actions["Authz vote"] {
+		t.Errorf("Expected pending deposit and authz vote awaiting confirmation, got %v", actions)
+	}
+}
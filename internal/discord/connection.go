@@ -0,0 +1,91 @@
+package discord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// staleHeartbeat is how long the gateway may go without acknowledging a heartbeat (sent
+// every ~41s) before the connection is considered dead
+const staleHeartbeat = 2 * time.Minute
+
+// watchConnection checks the gateway connection every interval. A connection that is
+// unhealthy on two checks in a row - discordgo gets one interval to recover by itself - is
+// closed and reopened
+func (b *Bot) watchConnection(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	unhealthy := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.session.RLock()
+			ready, lastAck := b.session.DataReady, b.session.LastHeartbeatAck
+			b.session.RUnlock()
+
+			if b.health != nil && !lastAck.IsZero() {
+				b.health.SetDiscordHeartbeat(lastAck)
+			}
+
+			problem := connectionProblem(ready, lastAck, time.Now())
+			if problem == "" {
+				unhealthy = 0
+				continue
+			}
+
+			unhealthy++
+			b.logger.Warn("Discord connection unhealthy",
+				zap.String("problem", problem),
+				zap.Int("consecutive_checks", unhealthy),
+			)
+			b.markDisconnected()
+
+			if unhealthy >= 2 && ctx.Err() == nil {
+				b.reconnect()
+				unhealthy = 0
+			}
+		}
+	}
+}
+
+// connectionProblem describes why the gateway connection looks dead, or returns "" when it
+// is healthy
+func connectionProblem(ready bool, lastAck, now time.Time) string {
+	if !ready {
+		return "gateway session is not ready"
+	}
+	if !lastAck.IsZero() && now.Sub(lastAck) > staleHeartbeat {
+		return fmt.Sprintf("no heartbeat acknowledged for %s", now.Sub(lastAck).Round(time.Second))
+	}
+	return ""
+}
+
+// reconnect closes and reopens the Discord session
+func (b *Bot) reconnect() {
+	b.logger.Warn("Reconnecting to Discord")
+	if b.health != nil {
+		b.health.RecordDiscordReconnect()
+	}
+
+	if err := b.session.Close(); err != nil {
+		b.logger.Debug("Closing the Discord session failed", zap.Error(err))
+	}
+	if err := b.session.Open(); err != nil {
+		if errors.Is(err, discordgo.ErrWSAlreadyOpen) {
+			// discordgo's own reconnect got there first
+			b.logger.Info("Discord session was already reopened")
+			return
+		}
+		b.logger.Error("Failed to reconnect to Discord, retrying on the next check", zap.Error(err))
+		return
+	}
+	b.logger.Info("Reconnected to Discord")
+}
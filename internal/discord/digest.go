@@ -0,0 +1,124 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"prop-voter/internal/cron"
+	"prop-voter/internal/models"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// digestLimit caps the proposals listed in one digest; Discord allows 25 embed fields
+const digestLimit = 25
+
+// runDigest posts the voting digest each time the schedule fires
+func (b *Bot) runDigest(ctx context.Context, schedule *cron.Schedule) {
+	for {
+		next := schedule.Next(time.Now())
+		if next.IsZero() {
+			b.logger.Warn("Digest schedule never fires, digest disabled",
+				zap.String("schedule", b.config.Digest.Schedule),
+			)
+			return
+		}
+		b.logger.Debug("Next digest scheduled", zap.Time("at", next))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			b.sendDigest(time.Now())
+		}
+	}
+}
+
+// findVotingProposals returns every unarchived proposal in its voting period with its vote,
+// soonest deadline first (proposals without a known deadline last)
+func (b *Bot) findVotingProposals() ([]models.Proposal, error) {
+	var proposals []models.Proposal
+	err := b.db.Preload("Vote").Scopes(models.NotArchived, models.InVotingPeriod).
+		Order("voting_end IS NULL, voting_end ASC").
+		Find(&proposals).Error
+	return proposals, err
+}
+
+// sendDigest posts the digest of proposals in voting to the global channel
+func (b *Bot) sendDigest(now time.Time) {
+	proposals, err := b.findVotingProposals()
+	if err != nil {
+		b.logger.Error("Failed to fetch proposals for the digest", zap.Error(err))
+		return
+	}
+
+	b.sendEmbed(b.config.Discord.ChannelID, b.buildDigestEmbed(proposals, now))
+	b.logger.Info("Digest sent", zap.Int("proposals", len(proposals)))
+}
+
+// buildDigestEmbed summarizes proposals in voting across chains with their deadlines and
+// whether the bot has voted
+func (b *Bot) buildDigestEmbed(proposals []models.Proposal, now time.Time) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:     "📅 Governance Digest",
+		Color:     0x0099ff, // Blue
+		Timestamp: now.Format(time.RFC3339),
+	}
+	if len(proposals) == 0 {
+		embed.Description = "No proposals are in their voting period."
+		return embed
+	}
+
+	unvoted := 0
+	for _, proposal := range proposals {
+		if proposal.Vote == nil {
+			unvoted++
+		}
+	}
+	embed.Description = fmt.Sprintf("**%d** proposals in voting, **%d** without a vote", len(proposals), unvoted)
+	if unvoted > 0 {
+		embed.Color = 0xff9900 // Orange
+	}
+
+	for i, proposal := range proposals {
+		if i == digestLimit {
+			embed.Footer = &discordgo.MessageEmbedFooter{
+				Text: fmt.Sprintf("Showing the %d soonest deadlines of %d", digestLimit, len(proposals)),
+			}
+			break
+		}
+
+		name := fmt.Sprintf("%s #%s - %s", proposal.ChainID, proposal.ProposalID, proposal.Title)
+		if proposal.Expedited {
+			name = "⚡ " + name
+		}
+		if len(name) > 256 {
+			name = name[:253] + "..."
+		}
+
+		var value strings.Builder
+		if proposal.VotingEnd != nil {
+			value.WriteString(fmt.Sprintf("⏰ Ends <t:%d:R> (<t:%d:f>)\n", proposal.VotingEnd.Unix(), proposal.VotingEnd.Unix()))
+		} else {
+			value.WriteString("⏰ Voting end unknown\n")
+		}
+		if proposal.Vote != nil {
+			value.WriteString(fmt.Sprintf("✅ Voted **%s**", strings.ToUpper(proposal.Vote.Option)))
+		} else {
+			value.WriteString("⚠️ Not voted yet")
+		}
+
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   name,
+			Value:  value.String(),
+			Inline: false,
+		})
+	}
+
+	return embed
+}
@@ -0,0 +1,100 @@
+package discord
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"prop-voter/internal/export"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// maxAttachmentSize is Discord's upload limit for bots in servers without boosts
+const maxAttachmentSize = 25 << 20
+
+// exportVotes exports the vote history as CSV (default) or JSON and attaches it to a message
+// Usage: !prop-export [csv|json] [chain]
+func (b *Bot) exportVotes(channelID string, args []string) {
+	format := export.FormatCSV
+	if len(args) > 0 {
+		if parsed, err := export.ParseFormat(args[0]); err == nil {
+			format = parsed
+			args = args[1:]
+		}
+	}
+
+	var chainID string
+	if len(args) > 0 {
+		chainID = args[0]
+	}
+
+	// Stream through a temp file so large histories are never held in memory
+	file, err := os.CreateTemp("", "prop-voter-votes-*."+string(format))
+	if err != nil {
+		b.logger.Error("Failed to create vote export file", zap.Error(err))
+		b.sendMessage(channelID, "❌ Failed to export vote history")
+		return
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	count, err := export.WriteVotes(b.db, file, format, chainID)
+	if err != nil {
+		b.logger.Error("Failed to export votes", zap.Error(err))
+		b.sendMessage(channelID, "❌ Failed to export vote history")
+		return
+	}
+
+	if count == 0 {
+		b.sendMessage(channelID, "No votes found")
+		return
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		b.logger.Error("Failed to stat vote export file", zap.Error(err))
+		b.sendMessage(channelID, "❌ Failed to export vote history")
+		return
+	}
+	if info.Size() > maxAttachmentSize {
+		b.sendMessage(channelID, fmt.Sprintf("❌ The export of %d votes is too large to attach; use `prop-voter -export <file>` instead", count))
+		return
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		b.logger.Error("Failed to rewind vote export file", zap.Error(err))
+		b.sendMessage(channelID, "❌ Failed to export vote history")
+		return
+	}
+
+	name := fmt.Sprintf("votes-%s.%s", time.Now().UTC().Format("20060102"), format)
+	if chainID != "" {
+		name = fmt.Sprintf("votes-%s-%s.%s", chainID, time.Now().UTC().Format("20060102"), format)
+	}
+
+	_, err = b.session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("🗂️ Exported %d votes", count),
+		Files: []*discordgo.File{{
+			Name:        name,
+			ContentType: exportContentType(format),
+			Reader:      file,
+		}},
+	})
+	if err != nil {
+		b.logger.Error("Failed to send vote export",
+			zap.String("channel", channelID),
+			zap.Error(err),
+		)
+	}
+}
+
+// exportContentType returns the MIME type of an export format
+func exportContentType(format export.Format) string {
+	if format == export.FormatJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
@@ -0,0 +1,158 @@
+package discord
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Defaults used when the security lockout settings are unset
+const (
+	defaultMaxSecretAttempts   = 5
+	defaultSecretAttemptWindow = 10 * time.Minute
+	defaultSecretLockout       = 30 * time.Minute
+)
+
+// secretHash is a salted SHA-256 of the vote secret, so the plaintext isn't kept in memory
+type secretHash struct {
+	salt []byte
+	sum  []byte
+}
+
+// newSecretHash hashes secret with a random salt
+func newSecretHash(secret string) (secretHash, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return secretHash{}, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return secretHash{salt: salt, sum: hashSecret(salt, secret)}, nil
+}
+
+// matches reports whether candidate hashes to the stored secret, comparing in constant time
+func (h secretHash) matches(candidate string) bool {
+	if h.sum == nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(hashSecret(h.salt, candidate), h.sum) == 1
+}
+
+func hashSecret(salt []byte, secret string) []byte {
+	hasher := sha256.New()
+	hasher.Write(salt)
+	hasher.Write([]byte(secret))
+	return hasher.Sum(nil)
+}
+
+// secretGuard tracks failed vote secret attempts per user and locks out repeat offenders
+// State is kept in memory only, so a restart clears any lockouts
+type secretGuard struct {
+	mu          sync.Mutex
+	failures    map[string][]time.Time // user ID -> recent failed attempts
+	lockedUntil map[string]time.Time   // user ID -> end of lockout
+}
+
+// lockout returns when the user's lockout ends, if they are currently locked out
+func (g *secretGuard) lockout(userID string, now time.Time) (time.Time, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	until, ok := g.lockedUntil[userID]
+	if !ok {
+		return time.Time{}, false
+	}
+	if !now.Before(until) {
+		delete(g.lockedUntil, userID)
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// recordFailure records an invalid attempt and locks the user out once maxAttempts failures
+// fall within window. It returns the number of recent failures and whether a lockout started
+func (g *secretGuard) recordFailure(userID string, now time.Time, maxAttempts int, window, lockout time.Duration) (int, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.failures == nil {
+		g.failures = make(map[string][]time.Time)
+	}
+
+	// Only count attempts inside the window
+	recent := []time.Time{now}
+	for _, attempt := range g.failures[userID] {
+		if now.Sub(attempt) < window {
+			recent = append(recent, attempt)
+		}
+	}
+
+	if len(recent) < maxAttempts {
+		g.failures[userID] = recent
+		return len(recent), false
+	}
+
+	if g.lockedUntil == nil {
+		g.lockedUntil = make(map[string]time.Time)
+	}
+	g.lockedUntil[userID] = now.Add(lockout)
+	delete(g.failures, userID)
+	return len(recent), true
+}
+
+// reset clears the user's failed attempts after a valid secret
+func (g *secretGuard) reset(userID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.failures, userID)
+}
+
+// checkVoteSecret verifies a vote secret, rejecting users who are locked out after repeated
+// invalid attempts and alerting the channel when a new lockout starts
+func (b *Bot) checkVoteSecret(channelID, userID, secret string) bool {
+	now := time.Now()
+
+	if until, locked := b.secrets.lockout(userID, now); locked {
+		b.sendMessage(channelID, fmt.Sprintf("🔒 Too many invalid secret attempts - voting is locked for you until <t:%d:R>", until.Unix()))
+		b.logger.Warn("Vote rejected for locked out user", zap.String("user_id", userID), zap.Time("locked_until", until))
+		return false
+	}
+
+	if b.voteSecret.matches(secret) {
+		b.secrets.reset(userID)
+		return true
+	}
+
+	maxAttempts := b.config.Security.MaxSecretAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxSecretAttempts
+	}
+	window := b.config.Security.SecretAttemptWindow
+	if window <= 0 {
+		window = defaultSecretAttemptWindow
+	}
+	lockout := b.config.Security.SecretLockout
+	if lockout <= 0 {
+		lockout = defaultSecretLockout
+	}
+
+	attempts, locked := b.secrets.recordFailure(userID, now, maxAttempts, window, lockout)
+	if !locked {
+		b.sendMessage(channelID, "❌ Invalid secret")
+		return false
+	}
+
+	b.logger.Warn("User locked out after repeated invalid vote secrets",
+		zap.String("user_id", userID),
+		zap.Int("attempts", attempts),
+		zap.Duration("window", window),
+		zap.Duration("lockout", lockout),
+	)
+	b.sendMessage(channelID, fmt.Sprintf("🚨 **Security alert:** <@%s> entered an invalid vote secret %d times within %s. Votes from this user are locked for %s.",
+		userID, attempts, window, lockout))
+	return false
+}
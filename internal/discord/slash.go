@@ -0,0 +1,218 @@
+package discord
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// slashCommands returns the application (slash) commands registered by the bot
+func (b *Bot) slashCommands() []*discordgo.ApplicationCommand {
+	chainOption := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "chain",
+		Description: "Chain ID (e.g. cosmoshub-4)",
+		Required:    true,
+	}
+	proposalOption := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "proposal_id",
+		Description: "Proposal ID",
+		Required:    true,
+	}
+	minPage := float64(1)
+
+	return []*discordgo.ApplicationCommand{
+		{
+			Name:        "proposals",
+			Description: "List recent proposals",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "chain",
+					Description: "Only show proposals for this chain ID",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "page",
+					Description: "Page of results to show (default 1)",
+					Required:    false,
+					MinValue:    &minPage,
+				},
+			},
+		},
+		{
+			Name:        "vote",
+			Description: "Vote on a proposal",
+			Options: []*discordgo.ApplicationCommandOption{
+				chainOption,
+				proposalOption,
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "option",
+					Description: "Vote option",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "yes", Value: "yes"},
+						{Name: "no", Value: "no"},
+						{Name: "abstain", Value: "abstain"},
+						{Name: "no_with_veto", Value: "no_with_veto"},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "secret",
+					Description: "Your configured vote secret (the response is only visible to you)",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "status",
+			Description: "Show voting status for a proposal",
+			Options:     []*discordgo.ApplicationCommandOption{chainOption, proposalOption},
+		},
+		{
+			Name:        "tally",
+			Description: "Show the live vote tally for a proposal",
+			Options:     []*discordgo.ApplicationCommandOption{chainOption, proposalOption},
+		},
+	}
+}
+
+// registerSlashCommands registers the bot's slash commands with Discord
+// Commands are registered to the configured guild if set (instant), otherwise globally
+func (b *Bot) registerSlashCommands() {
+	if b.session.State == nil || b.session.State.User == nil {
+		b.logger.Warn("Discord session has no user state, skipping slash command registration")
+		return
+	}
+
+	appID := b.session.State.User.ID
+	for _, cmd := range b.slashCommands() {
+		if _, err := b.session.ApplicationCommandCreate(appID, b.config.Discord.GuildID, cmd); err != nil {
+			b.logger.Warn("Failed to register slash command",
+				zap.String("command", cmd.Name),
+				zap.Error(err),
+			)
+			continue
+		}
+		b.logger.Debug("Registered slash command", zap.String("command", cmd.Name))
+	}
+}
+
+// handleSlashCommand dispatches slash commands to the existing command logic
+func (b *Bot) handleSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Resolve the invoking user (guild interactions carry Member, DMs carry User)
+	var userID string
+	if i.Member != nil && i.Member.User != nil {
+		userID = i.Member.User.ID
+	} else if i.User != nil {
+		userID = i.User.ID
+	}
+
+	if !b.authorizeUser(s, userID, i.GuildID, i.Member) {
+		b.logger.Warn("Unauthorized user attempted to use slash command",
+			zap.String("user_id", userID),
+			zap.String("command", i.ApplicationCommandData().Name),
+		)
+		b.respondWithError(s, i, "You are not authorized to use this bot")
+		return
+	}
+
+	if !b.isCommandChannel(i.ChannelID) {
+		b.respondWithError(s, i, "Commands can only be used in the configured channels")
+		return
+	}
+
+	data := i.ApplicationCommandData()
+	options := make(map[string]string, len(data.Options))
+	for _, opt := range data.Options {
+		switch opt.Type {
+		case discordgo.ApplicationCommandOptionString:
+			options[opt.Name] = opt.StringValue()
+		case discordgo.ApplicationCommandOptionInteger:
+			options[opt.Name] = strconv.FormatInt(opt.IntValue(), 10)
+		}
+	}
+
+	switch data.Name {
+	case "proposals":
+		var args []string
+		if chain := options["chain"]; chain != "" {
+			args = append(args, chain)
+		}
+		if page := options["page"]; page != "" {
+			args = append(args, page)
+		}
+		b.runSlashCommand(s, i, func() { b.listProposals(i.ChannelID, args) })
+	case "vote":
+		args := []string{options["chain"], options["proposal_id"], options["option"], options["secret"]}
+		b.runSlashCommand(s, i, func() { b.handleVoteCommand(i.ChannelID, userID, args) })
+	case "status":
+		args := []string{options["chain"], options["proposal_id"]}
+		b.runSlashCommand(s, i, func() { b.showStatus(i.ChannelID, args) })
+	case "tally":
+		b.handleTallySlashCommand(s, i, options["chain"], options["proposal_id"])
+	default:
+		b.respondWithError(s, i, fmt.Sprintf("Unknown command: %s", data.Name))
+	}
+}
+
+// runSlashCommand acknowledges a slash command ephemerally (so options such as the vote
+// secret are never echoed publicly), runs the legacy handler, then closes the interaction
+func (b *Bot) runSlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate, run func()) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		b.logger.Error("Failed to defer slash command response", zap.Error(err))
+		return
+	}
+
+	run()
+
+	done := "✅ Done - see the channel for results"
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &done}); err != nil {
+		b.logger.Error("Failed to complete slash command response", zap.Error(err))
+	}
+}
+
+// handleTallySlashCommand queries and displays the vote tally for a proposal
+func (b *Bot) handleTallySlashCommand(s *discordgo.Session, i *discordgo.InteractionCreate, chainID, proposalID string) {
+	chainConfig := b.chains[chainID]
+
+	if chainConfig == nil {
+		b.respondWithError(s, i, fmt.Sprintf("Chain configuration not found for %s", chainID))
+		return
+	}
+
+	// Defer the response to give us time to query the chain
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+	if err != nil {
+		b.logger.Error("Failed to defer interaction response", zap.Error(err))
+		return
+	}
+
+	tally, err := b.queryVoteTally(chainConfig, proposalID)
+	if err != nil {
+		b.followupWithError(s, i, fmt.Sprintf("Failed to query vote tally: %v", err))
+		return
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Embeds:     []*discordgo.MessageEmbed{b.buildTallyEmbed(chainConfig, proposalID, tally)},
+		Components: tallyRefreshButtons(chainID, proposalID),
+	})
+	if err != nil {
+		b.logger.Error("Failed to send vote tally response", zap.Error(err))
+	}
+}
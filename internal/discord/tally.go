@@ -0,0 +1,350 @@
+package discord
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"prop-voter/config"
+
+	"github.com/bwmarrin/discordgo"
+	"go.uber.org/zap"
+)
+
+// tallyParamsTTL is how long a chain's gov tallying params are reused before refetching
+const tallyParamsTTL = time.Hour
+
+// tallyParams are a chain's gov tallying thresholds, as fractions
+type tallyParams struct {
+	Quorum        *big.Rat
+	Threshold     *big.Rat
+	VetoThreshold *big.Rat
+
+	// Expedited proposals need a higher threshold (and on some chains quorum); nil when the
+	// chain doesn't report one, in which case the regular value applies
+	ExpeditedQuorum    *big.Rat
+	ExpeditedThreshold *big.Rat
+
+	FetchedAt time.Time
+}
+
+// forProposal returns the thresholds that apply to a proposal, expedited or not
+func (p *tallyParams) forProposal(expedited bool) *tallyParams {
+	if !expedited {
+		return p
+	}
+	applied := *p
+	if p.ExpeditedQuorum != nil {
+		applied.Quorum = p.ExpeditedQuorum
+	}
+	if p.ExpeditedThreshold != nil {
+		applied.Threshold = p.ExpeditedThreshold
+	}
+	return &applied
+}
+
+// TallyContext compares a live tally with the chain's quorum and pass thresholds
+type TallyContext struct {
+	Turnout       float64 // Share of bonded tokens that voted
+	Quorum        float64
+	YesRatio      float64 // Yes share of non-abstain votes
+	Threshold     float64
+	VetoRatio     float64 // No-with-veto share of all votes
+	VetoThreshold float64
+}
+
+// QuorumReached reports whether turnout meets the quorum
+func (c *TallyContext) QuorumReached() bool {
+	return c.Turnout >= c.Quorum
+}
+
+// Vetoed reports whether no-with-veto votes exceed the veto threshold
+func (c *TallyContext) Vetoed() bool {
+	return c.VetoRatio > c.VetoThreshold
+}
+
+// Passing reports whether the proposal would pass if voting ended now
+func (c *TallyContext) Passing() bool {
+	return c.QuorumReached() && !c.Vetoed() && c.YesRatio > c.Threshold
+}
+
+// Summary explains the current outcome, naming the first rule that fails it
+func (c *TallyContext) Summary() string {
+	switch {
+	case !c.QuorumReached():
+		return "❌ FAILING (quorum not reached)"
+	case c.Vetoed():
+		return "❌ FAILING (vetoed)"
+	case !c.Passing():
+		return "❌ FAILING (below threshold)"
+	default:
+		return "✅ PASSING"
+	}
+}
+
+// tallyContextFields renders quorum and threshold fields for a tally embed
+func tallyContextFields(c *TallyContext) []*discordgo.MessageEmbedField {
+	return []*discordgo.MessageEmbedField{
+		{
+			Name:   "🗳️ Quorum",
+			Value:  fmt.Sprintf("%.1f%% / %.1f%% required", c.Turnout*100, c.Quorum*100),
+			Inline: true,
+		},
+		{
+			Name:   "📈 Yes Ratio",
+			Value:  fmt.Sprintf("%.1f%% / %.1f%% threshold", c.YesRatio*100, c.Threshold*100),
+			Inline: true,
+		},
+		{
+			Name:   "🚫 Veto",
+			Value:  fmt.Sprintf("%.1f%% / %.1f%% threshold", c.VetoRatio*100, c.VetoThreshold*100),
+			Inline: true,
+		},
+		{
+			Name:   "Currently",
+			Value:  c.Summary(),
+			Inline: false,
+		},
+	}
+}
+
+// queryTallyContext compares a raw tally with the chain's gov params and bonded tokens,
+// using the expedited thresholds for expedited proposals
+func (b *Bot) queryTallyContext(chainConfig *config.ChainConfig, tally *TallyResponse, expedited bool) (*TallyContext, error) {
+	params, err := b.getTallyParams(chainConfig)
+	if err != nil {
+		return nil, err
+	}
+	params = params.forProposal(expedited)
+
+	bonded, err := b.fetchBondedTokens(chainConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeTallyContext(tally, bonded, params)
+}
+
+// computeTallyContext applies the gov module's tallying rules to a raw tally: quorum is
+// checked against bonded tokens, veto against all votes and the threshold against non-abstain votes
+func computeTallyContext(tally *TallyResponse, bonded *big.Rat, params *tallyParams) (*TallyContext, error) {
+	var votes [4]*big.Rat
+	for i, amount := range []string{tally.Yes, tally.No, tally.Abstain, tally.NoWithVeto} {
+		if amount == "" {
+			amount = "0"
+		}
+		value, ok := new(big.Rat).SetString(amount)
+		if !ok {
+			return nil, fmt.Errorf("invalid tally amount %q", amount)
+		}
+		votes[i] = value
+	}
+	yes, abstain, veto := votes[0], votes[2], votes[3]
+
+	total := new(big.Rat)
+	for _, value := range votes {
+		total.Add(total, value)
+	}
+	nonAbstain := new(big.Rat).Sub(total, abstain)
+
+	return &TallyContext{
+		Turnout:       ratio(total, bonded),
+		Quorum:        ratFloat(params.Quorum),
+		YesRatio:      ratio(yes, nonAbstain),
+		Threshold:     ratFloat(params.Threshold),
+		VetoRatio:     ratio(veto, total),
+		VetoThreshold: ratFloat(params.VetoThreshold),
+	}, nil
+}
+
+// ratio returns a/b as a float, or 0 when b is zero
+func ratio(a, b *big.Rat) float64 {
+	if b.Sign() == 0 {
+		return 0
+	}
+	return ratFloat(new(big.Rat).Quo(a, b))
+}
+
+// ratFloat converts a ratio to the nearest float for display
+func ratFloat(r *big.Rat) float64 {
+	f, _ := r.Float64()
+	return f
+}
+
+// getTallyParams returns the chain's tallying params, fetching them at most once per tallyParamsTTL
+func (b *Bot) getTallyParams(chainConfig *config.ChainConfig) (*tallyParams, error) {
+	chainID := chainConfig.GetChainID()
+
+	b.tallyParamsMu.Lock()
+	cached, ok := b.tallyParams[chainID]
+	b.tallyParamsMu.Unlock()
+	if ok && time.Since(cached.FetchedAt) < tallyParamsTTL {
+		return cached, nil
+	}
+
+	params, err := b.fetchTallyParams(chainConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	b.tallyParamsMu.Lock()
+	if b.tallyParams == nil {
+		b.tallyParams = make(map[string]*tallyParams)
+	}
+	b.tallyParams[chainID] = params
+	b.tallyParamsMu.Unlock()
+
+	return params, nil
+}
+
+// fetchTallyParams queries the gov tallying params, trying gov v1 first and falling back to v1beta1
+func (b *Bot) fetchTallyParams(chainConfig *config.ChainConfig) (*tallyParams, error) {
+	type rawParams struct {
+		Quorum             string `json:"quorum"`
+		Threshold          string `json:"threshold"`
+		VetoThreshold      string `json:"veto_threshold"`
+		ExpeditedQuorum    string `json:"expedited_quorum"`    // Only on chains with a separate expedited quorum
+		ExpeditedThreshold string `json:"expedited_threshold"` // gov v1 (SDK v0.50+)
+	}
+	var response struct {
+		Params      *rawParams `json:"params"`       // gov v1 (SDK v0.47+)
+		TallyParams *rawParams `json:"tally_params"` // gov v1beta1 and older v1
+	}
+
+	var lastErr error
+	for _, version := range []string{"v1", "v1beta1"} {
+		response.Params, response.TallyParams = nil, nil
+		if err := b.getChainJSON(chainConfig, fmt.Sprintf("/cosmos/gov/%s/params/tallying", version), &response); err != nil {
+			lastErr = err
+			continue
+		}
+
+		raw := response.Params
+		if raw == nil || raw.Quorum == "" {
+			raw = response.TallyParams
+		}
+		if raw == nil || raw.Quorum == "" {
+			lastErr = fmt.Errorf("no tallying params in gov %s response", version)
+			continue
+		}
+
+		var values [3]*big.Rat
+		var err error
+		for i, value := range []string{raw.Quorum, raw.Threshold, raw.VetoThreshold} {
+			if values[i], err = parseGovDec(value); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			lastErr = fmt.Errorf("invalid gov %s tallying params: %w", version, err)
+			continue
+		}
+
+		params := &tallyParams{
+			Quorum:        values[0],
+			Threshold:     values[1],
+			VetoThreshold: values[2],
+			FetchedAt:     time.Now(),
+		}
+		if raw.ExpeditedQuorum != "" {
+			if params.ExpeditedQuorum, err = parseGovDec(raw.ExpeditedQuorum); err != nil {
+				lastErr = fmt.Errorf("invalid gov %s tallying params: %w", version, err)
+				continue
+			}
+		}
+		if raw.ExpeditedThreshold != "" {
+			if params.ExpeditedThreshold, err = parseGovDec(raw.ExpeditedThreshold); err != nil {
+				lastErr = fmt.Errorf("invalid gov %s tallying params: %w", version, err)
+				continue
+			}
+		}
+		return params, nil
+	}
+
+	return nil, fmt.Errorf("failed to query tallying params: %w", lastErr)
+}
+
+// parseGovDec parses a gov param decimal. The v1beta1 gateway encodes them as base64 bytes
+// of the decimal string on some SDK versions, so that form is accepted too
+func parseGovDec(value string) (*big.Rat, error) {
+	if r, ok := new(big.Rat).SetString(value); ok {
+		return r, nil
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(value); err == nil {
+		if r, ok := new(big.Rat).SetString(string(decoded)); ok {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid decimal %q", value)
+}
+
+// fetchBondedTokens queries the staking pool's bonded token total
+func (b *Bot) fetchBondedTokens(chainConfig *config.ChainConfig) (*big.Rat, error) {
+	var response struct {
+		Pool struct {
+			BondedTokens string `json:"bonded_tokens"`
+		} `json:"pool"`
+	}
+	if err := b.getChainJSON(chainConfig, "/cosmos/staking/v1beta1/pool", &response); err != nil {
+		return nil, fmt.Errorf("failed to query staking pool: %w", err)
+	}
+
+	bonded, ok := new(big.Rat).SetString(response.Pool.BondedTokens)
+	if !ok {
+		return nil, fmt.Errorf("invalid bonded tokens %q", response.Pool.BondedTokens)
+	}
+	return bonded, nil
+}
+
+// getChainJSON GETs path from the chain's REST endpoints in order and decodes the JSON
+// response into out, moving to the next endpoint on connection errors or 5xx responses
+func (b *Bot) getChainJSON(chainConfig *config.ChainConfig, path string, out interface{}) error {
+	endpoints := chainConfig.GetRESTEndpoints()
+	if len(endpoints) == 0 {
+		return fmt.Errorf("no REST endpoint configured for chain %s", chainConfig.GetName())
+	}
+
+	var lastErr error
+	for _, endpoint := range endpoints {
+		resp, err := b.restGet(b.config.AuthEndpoints.AppendQuery(strings.TrimSuffix(endpoint, "/") + path))
+		if err != nil {
+			lastErr = err
+			b.logger.Warn("REST endpoint unreachable, trying next",
+				zap.String("chain", chainConfig.GetName()),
+				zap.String("endpoint", endpoint),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("API returned status %d", resp.StatusCode)
+			b.logger.Warn("REST endpoint returned server error, trying next",
+				zap.String("chain", chainConfig.GetName()),
+				zap.String("endpoint", endpoint),
+				zap.Int("status", resp.StatusCode),
+			)
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("API returned status %d", resp.StatusCode)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		return nil
+	}
+
+	return lastErr
+}
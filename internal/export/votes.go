@@ -0,0 +1,208 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"prop-voter/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Format is a vote history export format
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// ParseFormat validates an export format name (case-insensitive)
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case FormatCSV, FormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown export format %q (use csv or json)", name)
+	}
+}
+
+// FormatFromPath picks the export format from a file extension (.csv or .json)
+func FormatFromPath(path string) (Format, error) {
+	return ParseFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// VoteRecord is one exported vote, joined with its proposal title
+type VoteRecord struct {
+	ChainID     string    `json:"chain_id"`
+	ProposalID  string    `json:"proposal_id"`
+	Title       string    `json:"title"`
+	Option      string    `json:"option"`
+	TxHash      string    `json:"tx_hash"`
+	IsAuthzVote bool      `json:"authz"`
+	GranterAddr string    `json:"granter_addr,omitempty"`
+	GranterName string    `json:"granter_name,omitempty"`
+	VotedBy     string    `json:"voted_by,omitempty"`
+	VotedAt     time.Time `json:"voted_at"`
+}
+
+// csvHeader lists the CSV columns, in VoteRecord order
+var csvHeader = []string{
+	"chain_id", "proposal_id", "title", "option", "tx_hash",
+	"authz", "granter_addr", "granter_name", "voted_by", "voted_at",
+}
+
+// WriteVotes streams the vote history (oldest first, optionally for one chain) to w and
+// returns the number of votes written; rows are read one at a time so large histories
+// are never held in memory
+func WriteVotes(db *gorm.DB, w io.Writer, format Format, chainID string) (int, error) {
+	query := db.Model(&models.Vote{}).
+		Select("votes.chain_id, votes.proposal_id, proposals.title, votes.option, votes.tx_hash, " +
+			"votes.is_authz_vote, votes.granter_addr, votes.granter_name, votes.voted_by, votes.voted_at").
+		Joins("LEFT JOIN proposals ON proposals.chain_id = votes.chain_id AND proposals.proposal_id = votes.proposal_id").
+		Order("votes.voted_at ASC, votes.id ASC")
+	if chainID != "" {
+		query = query.Where("votes.chain_id = ?", chainID)
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return 0, fmt.Errorf("failed to query votes: %w", err)
+	}
+	defer rows.Close()
+
+	var writer recordWriter
+	switch format {
+	case FormatCSV:
+		writer = newCSVWriter(w)
+	case FormatJSON:
+		writer = &jsonWriter{w: w}
+	default:
+		return 0, fmt.Errorf("unknown export format %q (use csv or json)", format)
+	}
+
+	if err := writer.begin(); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for rows.Next() {
+		var record VoteRecord
+		if err := db.ScanRows(rows, &record); err != nil {
+			return count, fmt.Errorf("failed to read vote: %w", err)
+		}
+		if err := writer.write(record); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("failed to read votes: %w", err)
+	}
+
+	return count, writer.end()
+}
+
+// recordWriter encodes vote records one at a time
+type recordWriter interface {
+	begin() error
+	write(record VoteRecord) error
+	end() error
+}
+
+// csvWriter writes a header row followed by one row per vote
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) begin() error {
+	if err := c.w.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	return nil
+}
+
+func (c *csvWriter) write(record VoteRecord) error {
+	err := c.w.Write([]string{
+		csvCell(record.ChainID),
+		csvCell(record.ProposalID),
+		csvCell(record.Title),
+		csvCell(record.Option),
+		csvCell(record.TxHash),
+		strconv.FormatBool(record.IsAuthzVote),
+		csvCell(record.GranterAddr),
+		csvCell(record.GranterName),
+		csvCell(record.VotedBy),
+		record.VotedAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write CSV row: %w", err)
+	}
+	return nil
+}
+
+// csvCell prefixes values that spreadsheets would evaluate as a formula (proposal titles are
+// chosen by whoever submits the proposal) with a quote, so they open as plain text
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (c *csvWriter) end() error {
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// jsonWriter writes a JSON array, one element per vote
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonWriter) begin() error {
+	_, err := io.WriteString(j.w, "[")
+	return err
+}
+
+func (j *jsonWriter) write(record VoteRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode vote: %w", err)
+	}
+
+	separator := ",\n  "
+	if j.count == 0 {
+		separator = "\n  "
+	}
+	j.count++
+
+	if _, err := io.WriteString(j.w, separator); err != nil {
+		return err
+	}
+	_, err = j.w.Write(data)
+	return err
+}
+
+func (j *jsonWriter) end() error {
+	closing := "\n]\n"
+	if j.count == 0 {
+		closing = "]\n"
+	}
+	_, err := io.WriteString(j.w, closing)
+	return err
+}
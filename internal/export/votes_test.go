@@ -0,0 +1,147 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"prop-voter/internal/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	votedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	db.Create(&models.Proposal{ChainID: "cosmoshub-4", ProposalID: "900", Title: "Raise, the \"cap\""})
+	db.Create(&models.Vote{ChainID: "cosmoshub-4", ProposalID: "900", Option: "yes", TxHash: "AAA", VotedAt: votedAt, VotedBy: "123"})
+	db.Create(&models.Vote{
+		ChainID: "osmosis-1", ProposalID: "700", Option: "no", TxHash: "BBB", VotedAt: votedAt.Add(time.Hour),
+		IsAuthzVote: true, GranterAddr: "osmo1granter", GranterName: "Validator",
+	})
+	return db
+}
+
+func TestWriteVotesCSV(t *testing.T) {
+	db := setupTestDB(t)
+
+	var buf bytes.Buffer
+	count, err := WriteVotes(db, &buf, FormatCSV, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 votes, got %d", count)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected header plus 2 rows, got %d rows", len(records))
+	}
+
+	want := []string{"cosmoshub-4", "900", "Raise, the \"cap\"", "yes", "AAA", "false", "", "", "123", "2024-05-01T12:00:00Z"}
+	for i, field := range want {
+		if records[1][i] != field {
+			t.Errorf("Column %s: expected %q, got %q", csvHeader[i], field, records[1][i])
+		}
+	}
+
+	// Votes without a stored proposal still export, with an empty title
+	if records[2][2] != "" || records[2][5] != "true" || records[2][6] != "osmo1granter" {
+		t.Errorf("Unexpected authz row: %v", records[2])
+	}
+}
+
+func TestWriteVotesCSVEscapesFormulas(t *testing.T) {
+	db := setupTestDB(t)
+	db.Create(&models.Proposal{ChainID: "juno-1", ProposalID: "5", Title: "=HYPERLINK(\"http://evil\")"})
+	db.Create(&models.Vote{ChainID: "juno-1", ProposalID: "5", Option: "yes", TxHash: "CCC", VotedAt: time.Now(), GranterName: "@admin"})
+
+	var buf bytes.Buffer
+	if _, err := WriteVotes(db, &buf, FormatCSV, "juno-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected header plus 1 row, got %d rows", len(records))
+	}
+	if records[1][2] != "'=HYPERLINK(\"http://evil\")" || records[1][7] != "'@admin" {
+		t.Errorf("Expected formula-like cells to be prefixed with a quote, got %v", records[1])
+	}
+
+	for _, value := range []string{"+1", "-1", "=1", "@x"} {
+		if got := csvCell(value); got != "'"+value {
+			t.Errorf("csvCell(%q) = %q, expected a quote prefix", value, got)
+		}
+	}
+	for _, value := range []string{"", "Raise the cap", "osmo1granter", "1+1"} {
+		if got := csvCell(value); got != value {
+			t.Errorf("csvCell(%q) = %q, expected it unchanged", value, got)
+		}
+	}
+}
+
+func TestWriteVotesJSON(t *testing.T) {
+	db := setupTestDB(t)
+
+	var buf bytes.Buffer
+	count, err := WriteVotes(db, &buf, FormatJSON, "osmosis-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 vote for osmosis-1, got %d", count)
+	}
+
+	var records []VoteRecord
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("Failed to parse JSON: %v\n%s", err, buf.String())
+	}
+	if len(records) != 1 || records[0].TxHash != "BBB" || !records[0].IsAuthzVote || records[0].GranterName != "Validator" {
+		t.Errorf("Unexpected records: %+v", records)
+	}
+
+	buf.Reset()
+	if _, err := WriteVotes(db, &buf, FormatJSON, "juno-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil || len(records) != 0 {
+		t.Errorf("Expected an empty JSON array, got %q", buf.String())
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    Format
+		wantErr bool
+	}{
+		{"votes.csv", FormatCSV, false},
+		{"/tmp/votes.JSON", FormatJSON, false},
+		{"votes.txt", "", true},
+		{"votes", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := FormatFromPath(tt.path)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("FormatFromPath(%q) = %q, %v; want %q (error %v)", tt.path, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
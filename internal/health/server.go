@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"prop-voter/config"
+	"prop-voter/internal/models"
+	"prop-voter/internal/version"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -23,18 +25,23 @@ type Server struct {
 	startTime  time.Time
 	lastScan   time.Time
 	scanErrors int64
+	status     *Status // Published by the scanner and Discord bot
 }
 
 // HealthResponse represents the health check response
 type HealthResponse struct {
-	Status      string            `json:"status"`
-	Timestamp   time.Time         `json:"timestamp"`
-	Uptime      string            `json:"uptime"`
-	Version     string            `json:"version,omitempty"`
-	Services    map[string]string `json:"services"`
-	Metrics     HealthMetrics     `json:"metrics"`
-	LastScan    *time.Time        `json:"last_scan,omitempty"`
-	Environment map[string]string `json:"environment"`
+	Status      string                 `json:"status"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Uptime      string                 `json:"uptime"`
+	Version     string                 `json:"version,omitempty"`
+	Commit      string                 `json:"commit,omitempty"`
+	BuildDate   string                 `json:"build_date,omitempty"`
+	Services    map[string]string      `json:"services"`
+	Metrics     HealthMetrics          `json:"metrics"`
+	LastScan    *time.Time             `json:"last_scan,omitempty"`
+	Chains      map[string]ChainStatus `json:"chains,omitempty"` // Scan state keyed by chain ID
+	Discord     *DiscordState          `json:"discord,omitempty"`
+	Environment map[string]string      `json:"environment"`
 }
 
 // HealthMetrics represents system metrics
@@ -44,6 +51,9 @@ type HealthMetrics struct {
 	ScanErrors   int64 `json:"scan_errors"`
 	TotalChains  int   `json:"total_chains"`
 	ActiveChains int   `json:"active_chains"`
+
+	// Proposals not yet announced; omitted when the database can't be queried
+	PendingNotifications *int64 `json:"pending_notifications,omitempty"`
 }
 
 // NewServer creates a new health check server
@@ -53,9 +63,15 @@ func NewServer(config *config.Config, db *gorm.DB, logger *zap.Logger) *Server {
 		db:        db,
 		logger:    logger,
 		startTime: time.Now(),
+		status:    NewStatus(),
 	}
 }
 
+// Status returns the shared state the scanner and Discord bot publish for /health
+func (s *Server) Status() *Status {
+	return s.status
+}
+
 // Start starts the health check server
 func (s *Server) Start(ctx context.Context) error {
 	if !s.config.Health.Enabled {
@@ -100,12 +116,13 @@ func (s *Server) Start(ctx context.Context) error {
 	return nil
 }
 
-// healthHandler handles the main health check endpoint
+// healthHandler handles the main health check endpoint. It is a liveness check: it answers
+// 200 whenever the process is running, and the body's status and services describe
+// dependency health (use /ready to gate traffic on them)
 func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	status := "healthy"
-	statusCode := http.StatusOK
 
 	// Check database connection
 	services := make(map[string]string)
@@ -113,26 +130,30 @@ func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		services["database"] = "error: " + err.Error()
 		status = "unhealthy"
-		statusCode = http.StatusServiceUnavailable
 	} else {
 		if err := sqlDB.Ping(); err != nil {
 			services["database"] = "error: " + err.Error()
 			status = "unhealthy"
-			statusCode = http.StatusServiceUnavailable
 		} else {
 			services["database"] = "healthy"
 		}
 	}
 
-	// Check Discord connection (basic)
-	if s.config.Discord.Token == "" {
+	// Check Discord connection, as last reported by the bot
+	connected, known, changedAt := s.status.Discord()
+	switch {
+	case s.config.Discord.Token == "":
 		services["discord"] = "not configured"
 		status = "degraded"
-		if statusCode == http.StatusOK {
-			statusCode = http.StatusPartialContent
-		}
-	} else {
+	case !known:
 		services["discord"] = "configured"
+	case connected:
+		services["discord"] = "connected"
+	default:
+		services["discord"] = "disconnected since " + changedAt.UTC().Format(time.RFC3339)
+		if status == "healthy" {
+			status = "degraded"
+		}
 	}
 
 	// Check chains configuration
@@ -146,13 +167,25 @@ func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
 	if activeChains == 0 {
 		services["chains"] = "no active chains"
 		status = "degraded"
-		if statusCode == http.StatusOK {
-			statusCode = http.StatusPartialContent
-		}
 	} else {
 		services["chains"] = fmt.Sprintf("%d configured", activeChains)
 	}
 
+	chains := s.status.Chains()
+	lastScan := s.lastScan
+	failing := 0
+	for _, chain := range chains {
+		if chain.Failing() {
+			failing++
+		}
+		if chain.LastScan != nil && chain.LastScan.After(lastScan) {
+			lastScan = *chain.LastScan
+		}
+	}
+	if failing > 0 && activeChains > 0 {
+		services["chains"] += fmt.Sprintf(", %d failing to scan", failing)
+	}
+
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
 
@@ -160,14 +193,20 @@ func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
 		Status:    status,
 		Timestamp: time.Now(),
 		Uptime:    time.Since(s.startTime).String(),
+		Version:   version.Version,
+		Commit:    version.Commit,
+		BuildDate: version.BuildDate,
 		Services:  services,
 		Metrics: HealthMetrics{
-			GoRoutines:   runtime.NumGoroutine(),
-			MemoryMB:     int(m.Alloc / 1024 / 1024),
-			ScanErrors:   s.scanErrors,
-			TotalChains:  len(s.config.Chains),
-			ActiveChains: activeChains,
+			GoRoutines:           runtime.NumGoroutine(),
+			MemoryMB:             int(m.Alloc / 1024 / 1024),
+			ScanErrors:           s.scanErrors + s.status.ScanErrors(),
+			TotalChains:          len(s.config.Chains),
+			ActiveChains:         activeChains,
+			PendingNotifications: s.pendingNotifications(),
 		},
+		Chains:  chains,
+		Discord: s.status.DiscordState(),
 		Environment: map[string]string{
 			"go_version": runtime.Version(),
 			"os":         runtime.GOOS,
@@ -175,14 +214,27 @@ func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	if !s.lastScan.IsZero() {
-		response.LastScan = &s.lastScan
+	if !lastScan.IsZero() {
+		response.LastScan = &lastScan
 	}
 
-	w.WriteHeader(statusCode)
+	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
 
+// pendingNotifications counts proposals the notifier has yet to announce, or nil when the
+// database can't be queried
+func (s *Server) pendingNotifications() *int64 {
+	var count int64
+	err := s.db.Model(&models.Proposal{}).Scopes(models.NotArchived).
+		Where("notification_sent = ?", false).Count(&count).Error
+	if err != nil {
+		s.logger.Debug("Failed to count pending notifications", zap.Error(err))
+		return nil
+	}
+	return &count
+}
+
 // metricsHandler provides Prometheus-style metrics
 func (s *Server) metricsHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain")
@@ -192,6 +244,11 @@ func (s *Server) metricsHandler(w http.ResponseWriter, r *http.Request) {
 
 	uptime := time.Since(s.startTime).Seconds()
 
+	discordConnected := 0
+	if connected, _, _ := s.status.Discord(); connected {
+		discordConnected = 1
+	}
+
 	metrics := fmt.Sprintf(`# HELP prop_voter_uptime_seconds Total uptime in seconds
 # TYPE prop_voter_uptime_seconds counter
 prop_voter_uptime_seconds %f
@@ -211,40 +268,59 @@ prop_voter_scan_errors_total %d
 # HELP prop_voter_chains_configured Number of configured chains
 # TYPE prop_voter_chains_configured gauge
 prop_voter_chains_configured %d
+
+# HELP prop_voter_discord_connected Whether the Discord gateway connection is up
+# TYPE prop_voter_discord_connected gauge
+prop_voter_discord_connected %d
+
+# HELP prop_voter_discord_reconnects_total Reconnects forced by the Discord connection watchdog
+# TYPE prop_voter_discord_reconnects_total counter
+prop_voter_discord_reconnects_total %d
 `,
 		uptime,
 		runtime.NumGoroutine(),
 		m.Alloc,
-		s.scanErrors,
+		s.scanErrors+s.status.ScanErrors(),
 		len(s.config.Chains),
+		discordConnected,
+		s.status.DiscordReconnects(),
 	)
 
 	w.Write([]byte(metrics))
 }
 
-// readinessHandler checks if the service is ready to serve traffic
+// readinessHandler checks if the service is ready to serve traffic: the database is reachable
+// and fully migrated, the Discord session is open and the startup binary setup has run
 func (s *Server) readinessHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	// Check if essential services are ready
-	ready := true
 	checks := make(map[string]bool)
 
 	// Database ready
 	sqlDB, err := s.db.DB()
-	if err != nil || sqlDB.Ping() != nil {
-		checks["database"] = false
-		ready = false
+	checks["database"] = err == nil && sqlDB.Ping() == nil
+
+	// Schema migrated
+	if checks["database"] {
+		pending, err := models.PendingMigrations(s.db)
+		checks["migrations"] = err == nil && len(pending) == 0
 	} else {
-		checks["database"] = true
+		checks["migrations"] = false
 	}
 
 	// Configuration ready
-	if len(s.config.Chains) == 0 {
-		checks["configuration"] = false
-		ready = false
-	} else {
-		checks["configuration"] = true
+	checks["configuration"] = len(s.config.Chains) > 0
+
+	// Discord session open
+	connected, known, _ := s.status.Discord()
+	checks["discord"] = known && connected
+
+	// Binaries set up
+	checks["binaries"] = s.status.BinariesReady()
+
+	ready := true
+	for _, ok := range checks {
+		ready = ready && ok
 	}
 
 	response := map[string]interface{}{
@@ -3,12 +3,16 @@ package health
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"prop-voter/config"
+	"prop-voter/internal/models"
+	"prop-voter/internal/version"
 
 	"go.uber.org/zap/zaptest"
 	"gorm.io/driver/sqlite"
@@ -83,6 +87,10 @@ func TestHealthHandler(t *testing.T) {
 	if response.Metrics.ActiveChains != 1 {
 		t.Errorf("Expected 1 active chain, got %d", response.Metrics.ActiveChains)
 	}
+
+	if response.Version != version.Version || response.Commit != version.Commit || response.BuildDate != version.BuildDate {
+		t.Errorf("Expected the build details, got version %q commit %q build date %q", response.Version, response.Commit, response.BuildDate)
+	}
 }
 
 func TestHealthHandlerNoDiscordToken(t *testing.T) {
@@ -94,8 +102,8 @@ func TestHealthHandlerNoDiscordToken(t *testing.T) {
 
 	server.healthHandler(w, req)
 
-	if w.Code != http.StatusPartialContent {
-		t.Errorf("Expected status %d, got %d", http.StatusPartialContent, w.Code)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected liveness status %d, got %d", http.StatusOK, w.Code)
 	}
 
 	var response HealthResponse
@@ -121,8 +129,8 @@ func TestHealthHandlerNoChains(t *testing.T) {
 
 	server.healthHandler(w, req)
 
-	if w.Code != http.StatusPartialContent {
-		t.Errorf("Expected status %d, got %d", http.StatusPartialContent, w.Code)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected liveness status %d, got %d", http.StatusOK, w.Code)
 	}
 
 	var response HealthResponse
@@ -180,8 +188,19 @@ func TestMetricsHandler(t *testing.T) {
 	}
 }
 
+// setupReadyServer returns a test server whose readiness dependencies are all satisfied
+func setupReadyServer(t *testing.T) (*Server, *gorm.DB) {
+	server, db := setupTestServer(t)
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	server.Status().SetDiscordConnected(true, time.Now())
+	server.Status().SetBinariesReady()
+	return server, db
+}
+
 func TestReadinessHandler(t *testing.T) {
-	server, _ := setupTestServer(t)
+	server, _ := setupReadyServer(t)
 
 	req := httptest.NewRequest("GET", "/ready", nil)
 	w := httptest.NewRecorder()
@@ -211,13 +230,15 @@ func TestReadinessHandler(t *testing.T) {
 		t.Error("Expected database check to be true")
 	}
 
-	if checks["configuration"] != true {
-		t.Error("Expected configuration check to be true")
+	for _, check := range []string{"configuration", "migrations", "discord", "binaries"} {
+		if checks[check] != true {
+			t.Errorf("Expected %s check to be true", check)
+		}
 	}
 }
 
 func TestReadinessHandlerNotReady(t *testing.T) {
-	server, _ := setupTestServer(t)
+	server, _ := setupReadyServer(t)
 	server.config.Chains = []config.ChainConfig{} // Remove chains to make it not ready
 
 	req := httptest.NewRequest("GET", "/ready", nil)
@@ -249,6 +270,199 @@ func TestReadinessHandlerNotReady(t *testing.T) {
 	}
 }
 
+func TestReadinessHandlerDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   string
+		prepare func(t *testing.T) *Server
+	}{
+		{
+			name:  "schema not migrated",
+			check: "migrations",
+			prepare: func(t *testing.T) *Server {
+				server, _ := setupTestServer(t)
+				server.Status().SetDiscordConnected(true, time.Now())
+				server.Status().SetBinariesReady()
+				return server
+			},
+		},
+		{
+			name:  "discord not yet connected",
+			check: "discord",
+			prepare: func(t *testing.T) *Server {
+				server, db := setupTestServer(t)
+				if err := models.InitDB(db); err != nil {
+					t.Fatalf("Failed to initialize database: %v", err)
+				}
+				server.Status().SetBinariesReady()
+				return server
+			},
+		},
+		{
+			name:  "discord disconnected",
+			check: "discord",
+			prepare: func(t *testing.T) *Server {
+				server, _ := setupReadyServer(t)
+				server.Status().SetDiscordConnected(false, time.Now())
+				return server
+			},
+		},
+		{
+			name:  "binaries not set up",
+			check: "binaries",
+			prepare: func(t *testing.T) *Server {
+				server, db := setupTestServer(t)
+				if err := models.InitDB(db); err != nil {
+					t.Fatalf("Failed to initialize database: %v", err)
+				}
+				server.Status().SetDiscordConnected(true, time.Now())
+				return server
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := tt.prepare(t)
+
+			w := httptest.NewRecorder()
+			server.readinessHandler(w, httptest.NewRequest("GET", "/ready", nil))
+
+			if w.Code != http.StatusServiceUnavailable {
+				t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+			}
+
+			var response struct {
+				Ready  bool            `json:"ready"`
+				Checks map[string]bool `json:"checks"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.Ready {
+				t.Error("Expected ready to be false")
+			}
+			if ok, present := response.Checks[tt.check]; !present || ok {
+				t.Errorf("Expected %s check to be false, got %v", tt.check, response.Checks)
+			}
+		})
+	}
+}
+
+func TestHealthHandlerPublishedStatus(t *testing.T) {
+	server, db := setupTestServer(t)
+	if err := models.InitDB(db); err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	db.Create(&models.Proposal{ChainID: "test-1", ProposalID: "1", NotificationSent: false})
+	db.Create(&models.Proposal{ChainID: "test-1", ProposalID: "2", NotificationSent: true})
+
+	scanned := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	server.Status().RecordScan("test-1", scanned, nil)
+	server.Status().RecordScan("test-1", scanned.Add(time.Minute), errors.New("rest unreachable"))
+	server.Status().SetDiscordConnected(false, scanned)
+
+	w := httptest.NewRecorder()
+	server.healthHandler(w, httptest.NewRequest("GET", "/health", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected liveness status %d while Discord is disconnected, got %d", http.StatusOK, w.Code)
+	}
+
+	var response HealthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	chain := response.Chains["test-1"]
+	if chain.LastScan == nil || !chain.LastScan.Equal(scanned) || chain.LastError != "rest unreachable" || !chain.Failing() {
+		t.Errorf("Unexpected chain status: %+v", chain)
+	}
+	if response.LastScan == nil || !response.LastScan.Equal(scanned) {
+		t.Errorf("Expected the last successful scan %v, got %v", scanned, response.LastScan)
+	}
+	if response.Metrics.ScanErrors != 1 {
+		t.Errorf("Expected 1 scan error, got %d", response.Metrics.ScanErrors)
+	}
+	if response.Metrics.PendingNotifications == nil || *response.Metrics.PendingNotifications != 1 {
+		t.Errorf("Expected 1 pending notification, got %v", response.Metrics.PendingNotifications)
+	}
+	if !strings.HasPrefix(response.Services["discord"], "disconnected") {
+		t.Errorf("Expected Discord to be reported disconnected, got %q", response.Services["discord"])
+	}
+	if !strings.Contains(response.Services["chains"], "1 failing") {
+		t.Errorf("Expected the failing chain to be counted, got %q", response.Services["chains"])
+	}
+}
+
+func TestHealthHandlerDiscordState(t *testing.T) {
+	server, _ := setupTestServer(t)
+
+	w := httptest.NewRecorder()
+	server.healthHandler(w, httptest.NewRequest("GET", "/health", nil))
+	var response HealthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Discord != nil {
+		t.Errorf("Expected no Discord state before the first connection event, got %+v", response.Discord)
+	}
+
+	dropped := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	server.Status().SetDiscordConnected(false, dropped)
+	server.Status().SetDiscordHeartbeat(dropped.Add(-3 * time.Minute))
+	server.Status().RecordDiscordReconnect()
+
+	w = httptest.NewRecorder()
+	server.healthHandler(w, httptest.NewRequest("GET", "/health", nil))
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	state := response.Discord
+	if state == nil || state.Connected || !state.ChangedAt.Equal(dropped) || state.Reconnects != 1 ||
+		state.LastHeartbeat == nil || !state.LastHeartbeat.Equal(dropped.Add(-3*time.Minute)) {
+		t.Errorf("Unexpected Discord state: %+v", state)
+	}
+
+	w = httptest.NewRecorder()
+	server.metricsHandler(w, httptest.NewRequest("GET", "/metrics", nil))
+	for _, metric := range []string{"prop_voter_discord_connected 0", "prop_voter_discord_reconnects_total 1"} {
+		if !strings.Contains(w.Body.String(), metric) {
+			t.Errorf("Expected %q in the metrics", metric)
+		}
+	}
+}
+
+func TestHealthHandlerDatabaseDown(t *testing.T) {
+	server, db := setupTestServer(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get database handle: %v", err)
+	}
+	sqlDB.Close()
+
+	w := httptest.NewRecorder()
+	server.healthHandler(w, httptest.NewRequest("GET", "/health", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected liveness status %d with the database down, got %d", http.StatusOK, w.Code)
+	}
+
+	var response HealthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Status != "unhealthy" {
+		t.Errorf("Expected status 'unhealthy', got %q", response.Status)
+	}
+
+	w = httptest.NewRecorder()
+	server.readinessHandler(w, httptest.NewRequest("GET", "/ready", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected readiness status %d with the database down, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
 func TestUpdateScanMetrics(t *testing.T) {
 	server, _ := setupTestServer(t)
 
@@ -0,0 +1,163 @@
+package health
+
+import (
+	"sync"
+	"time"
+)
+
+// Status is runtime state published by the scanner and Discord bot and reported by /health
+type Status struct {
+	mu         sync.RWMutex
+	chains     map[string]ChainStatus // Keyed by chain ID
+	scanErrors int64
+
+	discordKnown     bool // False until the bot reports its first connection event
+	discordConnected bool
+	discordChangedAt time.Time
+	discordHeartbeat time.Time // Last heartbeat the gateway acknowledged, as seen by the watchdog
+	discordReconnect int64     // Reconnects forced by the watchdog
+
+	binariesReady bool // Set once the startup binary setup has run
+}
+
+// ChainStatus is the scan state of one chain
+type ChainStatus struct {
+	LastScan    *time.Time `json:"last_scan,omitempty"` // Last successful scan
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+// Failing reports whether the chain's most recent scan failed
+func (c ChainStatus) Failing() bool {
+	return c.LastErrorAt != nil && (c.LastScan == nil || c.LastErrorAt.After(*c.LastScan))
+}
+
+// NewStatus creates an empty status
+func NewStatus() *Status {
+	return &Status{chains: make(map[string]ChainStatus)}
+}
+
+// RecordScan records the outcome of scanning a chain; the last error is kept after a later
+// success so it can still be inspected, and Failing tells whether it is current
+func (s *Status) RecordScan(chainID string, at time.Time, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chain := s.chains[chainID]
+	if err != nil {
+		chain.LastError = err.Error()
+		chain.LastErrorAt = &at
+		s.scanErrors++
+	} else {
+		chain.LastScan = &at
+	}
+	s.chains[chainID] = chain
+}
+
+// SetDiscordConnected records a Discord gateway connect or disconnect
+func (s *Status) SetDiscordConnected(connected bool, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.discordKnown = true
+	s.discordConnected = connected
+	s.discordChangedAt = at
+}
+
+// Discord returns the Discord connection state and when it last changed; known is false
+// until the bot has connected or disconnected at least once
+func (s *Status) Discord() (connected, known bool, changedAt time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.discordConnected, s.discordKnown, s.discordChangedAt
+}
+
+// SetDiscordHeartbeat records the last heartbeat the Discord gateway acknowledged
+func (s *Status) SetDiscordHeartbeat(at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.discordHeartbeat = at
+}
+
+// RecordDiscordReconnect counts a reconnect forced by the bot's connection watchdog
+func (s *Status) RecordDiscordReconnect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.discordReconnect++
+}
+
+// DiscordState is the Discord gateway connection as reported by /health
+type DiscordState struct {
+	Connected     bool       `json:"connected"`
+	ChangedAt     time.Time  `json:"changed_at"`               // When the bot last connected or lost the connection
+	LastHeartbeat *time.Time `json:"last_heartbeat,omitempty"` // Last heartbeat acknowledged by Discord
+	Reconnects    int64      `json:"reconnects"`               // Reconnects forced by the watchdog
+}
+
+// DiscordState returns the connection details, or nil until the bot has reported a
+// connection event
+func (s *Status) DiscordState() *DiscordState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.discordKnown {
+		return nil
+	}
+	state := &DiscordState{
+		Connected:  s.discordConnected,
+		ChangedAt:  s.discordChangedAt,
+		Reconnects: s.discordReconnect,
+	}
+	if !s.discordHeartbeat.IsZero() {
+		heartbeat := s.discordHeartbeat
+		state.LastHeartbeat = &heartbeat
+	}
+	return state
+}
+
+// DiscordReconnects returns how many reconnects the watchdog has forced
+func (s *Status) DiscordReconnects() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.discordReconnect
+}
+
+// SetBinariesReady records that the startup binary setup has run
+func (s *Status) SetBinariesReady() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.binariesReady = true
+}
+
+// BinariesReady reports whether the startup binary setup has run
+func (s *Status) BinariesReady() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.binariesReady
+}
+
+// Chains returns a copy of every chain's scan state
+func (s *Status) Chains() map[string]ChainStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	chains := make(map[string]ChainStatus, len(s.chains))
+	for chainID, chain := range s.chains {
+		chains[chainID] = chain
+	}
+	return chains
+}
+
+// ScanErrors returns how many chain scans have failed
+func (s *Status) ScanErrors() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.scanErrors
+}
@@ -0,0 +1,144 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"prop-voter/config"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// NewLogger builds the application logger from the logging config. debug forces the debug
+// level and, unless a format is configured, the development console encoder; otherwise the
+// output matches zap's production preset. Use ForModule to derive subsystem loggers
+func NewLogger(cfg config.LoggingConfig, debug bool) (*zap.Logger, error) {
+	level, err := parseLevel(cfg.Level, zapcore.InfoLevel)
+	if err != nil {
+		return nil, err
+	}
+	if debug {
+		level = zapcore.DebugLevel
+	}
+
+	// The shared core admits the most verbose level any module asks for; each logger then
+	// filters to its own level
+	minLevel := level
+	for module, moduleLevel := range cfg.Modules {
+		parsed, err := parseLevel(moduleLevel, level)
+		if err != nil {
+			return nil, fmt.Errorf("module %s: %w", module, err)
+		}
+		if parsed < minLevel {
+			minLevel = parsed
+		}
+	}
+
+	format := cfg.Format
+	if format == "" {
+		format = config.LogFormatJSON
+		if debug {
+			format = config.LogFormatConsole
+		}
+	}
+
+	var encoder zapcore.Encoder
+	switch format {
+	case config.LogFormatJSON:
+		encoder = zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	case config.LogFormatConsole:
+		encoder = zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	var core zapcore.Core = zapcore.NewCore(encoder, outputSyncer(cfg), minLevel)
+
+	options := []zap.Option{zap.AddCaller(), zap.ErrorOutput(zapcore.Lock(os.Stderr))}
+	if debug {
+		options = append(options, zap.Development(), zap.AddStacktrace(zapcore.WarnLevel))
+	} else {
+		options = append(options, zap.AddStacktrace(zapcore.ErrorLevel))
+		core = zapcore.NewSamplerWithOptions(core, time.Second, 100, 100)
+	}
+
+	return zap.New(&levelCore{Core: core, level: level}, options...), nil
+}
+
+// ForModule returns the named logger for a subsystem, logging at its level from
+// logging.modules when one is set and at the root logger's level otherwise
+func ForModule(logger *zap.Logger, cfg config.LoggingConfig, module string) *zap.Logger {
+	named := logger.Named(module)
+
+	moduleLevel, ok := cfg.Modules[module]
+	if !ok {
+		return named
+	}
+	level, err := parseLevel(moduleLevel, zapcore.InfoLevel)
+	if err != nil {
+		named.Warn("Ignoring invalid module log level", zap.String("level", moduleLevel), zap.Error(err))
+		return named
+	}
+
+	return named.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if filtered, ok := core.(*levelCore); ok {
+			return &levelCore{Core: filtered.Core, level: level}
+		}
+		return core
+	}))
+}
+
+// parseLevel parses a configured level name, returning fallback when it is empty
+func parseLevel(name string, fallback zapcore.Level) (zapcore.Level, error) {
+	if name == "" {
+		return fallback, nil
+	}
+	level, err := zapcore.ParseLevel(strings.ToLower(name))
+	if err != nil {
+		return fallback, fmt.Errorf("invalid log level: %w", err)
+	}
+	return level, nil
+}
+
+// levelCore filters a core shared by every module logger down to one logger's level
+type levelCore struct {
+	zapcore.Core
+	level zapcore.Level
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// outputSyncer returns where log entries are written: a standard stream, or a file rotated
+// by lumberjack once it reaches max_size_mb
+func outputSyncer(cfg config.LoggingConfig) zapcore.WriteSyncer {
+	switch {
+	case cfg.Output == "stdout":
+		return zapcore.Lock(os.Stdout)
+	case !cfg.IsFileOutput():
+		return zapcore.Lock(os.Stderr)
+	}
+
+	return zapcore.AddSync(&lumberjack.Logger{
+		Filename:   cfg.Output,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxAge:     cfg.MaxAgeDays,
+		MaxBackups: cfg.MaxBackups,
+	})
+}
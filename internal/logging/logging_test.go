@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"prop-voter/config"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewLoggerFileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prop-voter.log")
+
+	logger, err := NewLogger(config.LoggingConfig{Level: "warn", Output: path, MaxSizeMB: 1}, false)
+	if err != nil {
+		t.Fatalf("Failed to build logger: %v", err)
+	}
+	logger.Info("dropped below the level")
+	logger.Warn("kept")
+	logger.Sync()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the log file to be written: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected one log line at warn level, got %d: %s", len(lines), data)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("Expected a JSON log line, got %q: %v", lines[0], err)
+	}
+	if entry["msg"] != "kept" || entry["level"] != "warn" {
+		t.Errorf("Unexpected log entry: %v", entry)
+	}
+}
+
+func TestNewLoggerDebugOverride(t *testing.T) {
+	logger, err := NewLogger(config.LoggingConfig{Level: "error"}, true)
+	if err != nil {
+		t.Fatalf("Failed to build logger: %v", err)
+	}
+	if !logger.Core().Enabled(zapcore.DebugLevel) {
+		t.Error("Expected -debug to override the configured level")
+	}
+
+	logger, err = NewLogger(config.LoggingConfig{}, false)
+	if err != nil {
+		t.Fatalf("Failed to build logger: %v", err)
+	}
+	if logger.Core().Enabled(zapcore.DebugLevel) || !logger.Core().Enabled(zapcore.InfoLevel) {
+		t.Error("Expected the default level to be info")
+	}
+
+	if _, err := NewLogger(config.LoggingConfig{Format: "text"}, false); err == nil {
+		t.Error("Expected an unknown format to be rejected")
+	}
+}
+
+func TestForModuleLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prop-voter.log")
+	cfg := config.LoggingConfig{
+		Level:   "info",
+		Output:  path,
+		Modules: map[string]string{"voter": "debug", "scanner": "warn"},
+	}
+
+	logger, err := NewLogger(cfg, false)
+	if err != nil {
+		t.Fatalf("Failed to build logger: %v", err)
+	}
+	root := logger
+	voter := ForModule(logger, cfg, "voter")
+	scanner := ForModule(logger, cfg, "scanner").With(zap.String("chain", "osmosis-1"))
+	discord := ForModule(logger, cfg, "discord")
+
+	root.Debug("root debug")
+	root.Info("root info")
+	voter.Debug("voter debug")
+	scanner.Info("scanner info")
+	scanner.Warn("scanner warn")
+	discord.Debug("discord debug")
+	discord.Info("discord info")
+	logger.Sync()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Expected a JSON log line, got %q: %v", line, err)
+		}
+		got = append(got, entry["msg"].(string))
+	}
+
+	want := []string{"root info", "voter debug", "scanner warn", "discord info"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected %v to be logged, got %v", want, got)
+	}
+}
@@ -0,0 +1,339 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SchemaMigration records a migration that has been applied to the database
+type SchemaMigration struct {
+	ID        string `gorm:"primaryKey"`
+	AppliedAt time.Time
+}
+
+// Migration is one ordered schema change; Rollback is optional
+type Migration struct {
+	ID       string
+	Migrate  func(tx *gorm.DB) error
+	Rollback func(tx *gorm.DB) error
+}
+
+// migrations are applied in order and must never be reordered or edited once released;
+// add schema changes by appending a new migration
+var migrations = []Migration{
+	{
+		// Tables as they existed before migrations were introduced. AutoMigrate only adds
+		// missing tables and columns, so this is safe on databases created by older versions
+		ID: "0001_baseline",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&Proposal{},
+				&Vote{},
+				&WalletInfo{},
+				&NotificationLog{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&NotificationLog{}, &WalletInfo{}, &Vote{}, &Proposal{})
+		},
+	},
+	{
+		// Databases created from the baseline above already have the column
+		ID: "0002_proposal_archived",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&Proposal{}, "Archived") {
+				return nil
+			}
+			if err := tx.Migrator().AddColumn(&Proposal{}, "Archived"); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&Proposal{}, "Archived")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Proposal{}, "Archived")
+		},
+	},
+	{
+		// Proposals are looked up by (chain_id, proposal_id) on every scan and by
+		// notification_sent by the notifier; duplicates are dropped (keeping the first row,
+		// which is the one the scanner updates) so the unique index can be created
+		ID: "0003_proposal_indexes",
+		Migrate: func(tx *gorm.DB) error {
+			err := tx.Exec(`DELETE FROM proposals WHERE id NOT IN (
+				SELECT MIN(id) FROM proposals GROUP BY chain_id, proposal_id)`).Error
+			if err != nil {
+				return err
+			}
+			if err := tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_proposals_chain_proposal ON proposals (chain_id, proposal_id)").Error; err != nil {
+				return err
+			}
+			return tx.Exec("CREATE INDEX IF NOT EXISTS idx_proposals_notification_sent ON proposals (notification_sent)").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_proposals_notification_sent").Error; err != nil {
+				return err
+			}
+			return tx.Exec("DROP INDEX IF EXISTS idx_proposals_chain_proposal").Error
+		},
+	},
+	{
+		ID: "0004_authz_grant_warnings",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&AuthzGrantWarning{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&AuthzGrantWarning{})
+		},
+	},
+	{
+		ID: "0005_proposal_status_changes",
+		Migrate: func(tx *gorm.DB) error {
+			for _, column := range []string{"PreviousStatus", "StatusChangePending"} {
+				if tx.Migrator().HasColumn(&Proposal{}, column) {
+					continue
+				}
+				if err := tx.Migrator().AddColumn(&Proposal{}, column); err != nil {
+					return err
+				}
+			}
+			return tx.Exec("CREATE INDEX IF NOT EXISTS idx_proposals_status_change_pending ON proposals (status_change_pending)").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_proposals_status_change_pending").Error; err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&Proposal{}, "StatusChangePending"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&Proposal{}, "PreviousStatus")
+		},
+	},
+	{
+		ID: "0006_proposal_final_tally",
+		Migrate: func(tx *gorm.DB) error {
+			for _, column := range finalTallyColumns {
+				if tx.Migrator().HasColumn(&Proposal{}, column) {
+					continue
+				}
+				if err := tx.Migrator().AddColumn(&Proposal{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range finalTallyColumns {
+				if err := tx.Migrator().DropColumn(&Proposal{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		ID: "0007_proposal_notification_message",
+		Migrate: func(tx *gorm.DB) error {
+			for _, column := range notificationMessageColumns {
+				if tx.Migrator().HasColumn(&Proposal{}, column) {
+					continue
+				}
+				if err := tx.Migrator().AddColumn(&Proposal{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range notificationMessageColumns {
+				if err := tx.Migrator().DropColumn(&Proposal{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		ID: "0008_vote_rationale",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&Vote{}, "Rationale") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&Vote{}, "Rationale")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Vote{}, "Rationale")
+		},
+	},
+	{
+		ID: "0009_auto_votes",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&AutoVote{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&AutoVote{})
+		},
+	},
+	{
+		ID: "0010_authz_warning_granter",
+		Migrate: func(tx *gorm.DB) error {
+			// Chains can have several granters, so warnings are kept per chain and granter
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_authz_grant_warnings_chain_id").Error; err != nil {
+				return err
+			}
+			return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_authz_grant_warnings_granter ON authz_grant_warnings (chain_id, granter)").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec("DROP INDEX IF EXISTS idx_authz_grant_warnings_granter").Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`DELETE FROM authz_grant_warnings WHERE id NOT IN (
+				SELECT MAX(id) FROM authz_grant_warnings GROUP BY chain_id)`).Error; err != nil {
+				return err
+			}
+			return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_authz_grant_warnings_chain_id ON authz_grant_warnings (chain_id)").Error
+		},
+	},
+	{
+		ID: "0011_proposal_message_types",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&Proposal{}, "MessageTypes") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&Proposal{}, "MessageTypes")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Proposal{}, "MessageTypes")
+		},
+	},
+	{
+		ID: "0012_proposal_announced",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&Proposal{}, "Announced") {
+				if err := tx.Migrator().AddColumn(&Proposal{}, "Announced"); err != nil {
+					return err
+				}
+			}
+			// Proposals with a recorded notification message were announced; older
+			// announcements can't be told apart from filtered ones and stay unmarked
+			return tx.Exec("UPDATE proposals SET announced = ? WHERE notification_message_id <> ''", true).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Proposal{}, "Announced")
+		},
+	},
+}
+
+// finalTallyColumns are the Proposal fields added by 0006_proposal_final_tally
+var finalTallyColumns = []string{"FinalTallyYes", "FinalTallyNo", "FinalTallyAbstain", "FinalTallyNoWithVeto"}
+
+// notificationMessageColumns are the Proposal fields added by 0007_proposal_notification_message
+var notificationMessageColumns = []string{"NotificationChannelID", "NotificationMessageID"}
+
+// RunMigrations applies all pending migrations in order, each in its own transaction
+func RunMigrations(db *gorm.DB) error {
+	return runMigrations(db, migrations)
+}
+
+func runMigrations(db *gorm.DB, migrations []Migration) error {
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range migrations {
+		if applied[migration.ID] {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Migrate(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{ID: migration.ID, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %s failed: %w", migration.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// RollbackLastMigration reverts the most recently applied migration and returns its ID
+func RollbackLastMigration(db *gorm.DB) (string, error) {
+	return rollbackLastMigration(db, migrations)
+}
+
+func rollbackLastMigration(db *gorm.DB, migrations []Migration) (string, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return "", err
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		if !applied[migration.ID] {
+			continue
+		}
+		if migration.Rollback == nil {
+			return "", fmt.Errorf("migration %s cannot be rolled back", migration.ID)
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Rollback(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{ID: migration.ID}).Error
+		})
+		if err != nil {
+			return "", fmt.Errorf("rollback of migration %s failed: %w", migration.ID, err)
+		}
+		return migration.ID, nil
+	}
+
+	return "", fmt.Errorf("no migrations to roll back")
+}
+
+// PendingMigrations returns the IDs of migrations not yet applied to the database
+func PendingMigrations(db *gorm.DB) ([]string, error) {
+	if !db.Migrator().HasTable(&SchemaMigration{}) {
+		pending := make([]string, 0, len(migrations))
+		for _, migration := range migrations {
+			pending = append(pending, migration.ID)
+		}
+		return pending, nil
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, migration := range migrations {
+		if !applied[migration.ID] {
+			pending = append(pending, migration.ID)
+		}
+	}
+	return pending, nil
+}
+
+// appliedMigrations returns the IDs of migrations recorded in the database
+func appliedMigrations(db *gorm.DB) (map[string]bool, error) {
+	var records []SchemaMigration
+	if err := db.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	applied := make(map[string]bool, len(records))
+	for _, record := range records {
+		applied[record.ID] = true
+	}
+	return applied, nil
+}
@@ -0,0 +1,214 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	return db
+}
+
+func TestRunMigrations(t *testing.T) {
+	db := setupTestDB(t)
+
+	if pending, err := PendingMigrations(db); err != nil || len(pending) != len(migrations) {
+		t.Fatalf("Expected every migration pending on a fresh database, got %v (err %v)", pending, err)
+	}
+
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if pending, err := PendingMigrations(db); err != nil || len(pending) != 0 {
+		t.Errorf("Expected no pending migrations, got %v (err %v)", pending, err)
+	}
+	// Running again is a no-op
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("Unexpected error on second run: %v", err)
+	}
+
+	var records []SchemaMigration
+	db.Find(&records)
+	if len(records) != len(migrations) || records[0].ID != "0001_baseline" {
+		t.Errorf("Expected every migration recorded once, got %+v", records)
+	}
+
+	for _, table := range []interface{}{&Proposal{}, &Vote{}, &WalletInfo{}, &NotificationLog{}} {
+		if !db.Migrator().HasTable(table) {
+			t.Errorf("Expected table for %T to exist", table)
+		}
+	}
+}
+
+func TestRunMigrationsOrderAndFailure(t *testing.T) {
+	db := setupTestDB(t)
+
+	var ran []string
+	step := func(id string, err error) Migration {
+		return Migration{
+			ID: id,
+			Migrate: func(tx *gorm.DB) error {
+				ran = append(ran, id)
+				return err
+			},
+			Rollback: func(tx *gorm.DB) error { return nil },
+		}
+	}
+
+	steps := []Migration{step("0001_a", nil), step("0002_b", fmt.Errorf("boom")), step("0003_c", nil)}
+	if err := runMigrations(db, steps); err == nil {
+		t.Fatal("Expected failing migration to return an error")
+	}
+	if len(ran) != 2 {
+		t.Errorf("Expected migrations after a failure to be skipped, ran %v", ran)
+	}
+
+	applied, _ := appliedMigrations(db)
+	if !applied["0001_a"] || applied["0002_b"] {
+		t.Errorf("Expected only the successful migration to be recorded, got %v", applied)
+	}
+
+	// Once fixed, the remaining migrations run and the applied one is not repeated
+	ran = nil
+	steps[1] = step("0002_b", nil)
+	if err := runMigrations(db, steps); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(ran) != 2 || ran[0] != "0002_b" || ran[1] != "0003_c" {
+		t.Errorf("Expected pending migrations to run in order, ran %v", ran)
+	}
+
+	id, err := rollbackLastMigration(db, steps)
+	if err != nil || id != "0003_c" {
+		t.Errorf("Expected to roll back 0003_c, got %q (%v)", id, err)
+	}
+	applied, _ = appliedMigrations(db)
+	if applied["0003_c"] || !applied["0002_b"] {
+		t.Errorf("Expected 0003_c to be unrecorded after rollback, got %v", applied)
+	}
+}
+
+func TestProposalIndexesMigration(t *testing.T) {
+	db := setupTestDB(t)
+
+	// Databases from before the migration may hold duplicate proposals
+	if err := runMigrations(db, migrations[:2]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	db.Create(&Proposal{ChainID: "cosmoshub-4", ProposalID: "1", Status: "PROPOSAL_STATUS_PASSED"})
+	db.Create(&Proposal{ChainID: "cosmoshub-4", ProposalID: "1", Status: "PROPOSAL_STATUS_VOTING_PERIOD"})
+	db.Create(&Proposal{ChainID: "osmosis-1", ProposalID: "1"})
+
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var proposals []Proposal
+	db.Where("chain_id = ?", "cosmoshub-4").Find(&proposals)
+	if len(proposals) != 1 || proposals[0].Status != "PROPOSAL_STATUS_PASSED" {
+		t.Errorf("Expected duplicates collapsed to the first row, got %+v", proposals)
+	}
+
+	if err := db.Create(&Proposal{ChainID: "osmosis-1", ProposalID: "1"}).Error; err == nil {
+		t.Error("Expected the unique index to reject a duplicate proposal")
+	}
+	if !db.Migrator().HasIndex(&Proposal{}, "idx_proposals_notification_sent") {
+		t.Error("Expected an index on notification_sent")
+	}
+}
+
+func TestAuthzWarningGranterMigration(t *testing.T) {
+	db := setupTestDB(t)
+
+	// Databases from before the migration have one warning per chain
+	if err := runMigrations(db, migrations[:9]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	db.Exec("DROP INDEX idx_authz_grant_warnings_granter")
+	db.Exec("CREATE UNIQUE INDEX idx_authz_grant_warnings_chain_id ON authz_grant_warnings (chain_id)")
+	db.Create(&AuthzGrantWarning{ChainID: "cosmoshub-4", Granter: "cosmos1granter"})
+
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := db.Create(&AuthzGrantWarning{ChainID: "cosmoshub-4", Granter: "cosmos1other"}).Error; err != nil {
+		t.Errorf("Expected a warning per granter, got %v", err)
+	}
+	if err := db.Create(&AuthzGrantWarning{ChainID: "cosmoshub-4", Granter: "cosmos1granter"}).Error; err == nil {
+		t.Error("Expected the unique index to reject a second warning for the same granter")
+	}
+	if db.Migrator().HasIndex(&AuthzGrantWarning{}, "idx_authz_grant_warnings_chain_id") {
+		t.Error("Expected the per-chain index to be dropped")
+	}
+}
+
+func TestProposalAnnouncedMigration(t *testing.T) {
+	db := setupTestDB(t)
+
+	if err := runMigrations(db, migrations[:11]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	db.Create(&Proposal{ChainID: "cosmoshub-4", ProposalID: "1", NotificationSent: true, NotificationMessageID: "m1"})
+	db.Create(&Proposal{ChainID: "cosmoshub-4", ProposalID: "2", NotificationSent: true})
+
+	if err := RunMigrations(db); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var proposals []Proposal
+	db.Order("proposal_id").Find(&proposals)
+	if len(proposals) != 2 || !proposals[0].Announced || proposals[1].Announced {
+		t.Errorf("Expected only the proposal with a notification message to be marked announced, got %+v", proposals)
+	}
+}
+
+// benchmarkProposalLookup times the scanner's per-proposal lookup against a large table
+func benchmarkProposalLookup(b *testing.B, migrations []Migration) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		b.Fatalf("Failed to create test database: %v", err)
+	}
+	if err := runMigrations(db, migrations); err != nil {
+		b.Fatalf("Failed to migrate: %v", err)
+	}
+
+	const chains, perChain = 10, 2000
+	proposals := make([]Proposal, 0, chains*perChain)
+	for c := 0; c < chains; c++ {
+		for p := 0; p < perChain; p++ {
+			proposals = append(proposals, Proposal{
+				ChainID:          fmt.Sprintf("chain-%d", c),
+				ProposalID:       fmt.Sprintf("%d", p),
+				NotificationSent: true,
+			})
+		}
+	}
+	if err := db.CreateInBatches(proposals, 500).Error; err != nil {
+		b.Fatalf("Failed to seed proposals: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var proposal Proposal
+		db.Where("chain_id = ? AND proposal_id = ?", "chain-9", fmt.Sprintf("%d", i%perChain)).First(&proposal)
+
+		var pending []Proposal
+		db.Where("notification_sent = ?", false).Find(&pending)
+	}
+}
+
+func BenchmarkProposalLookupWithoutIndexes(b *testing.B) {
+	benchmarkProposalLookup(b, migrations[:2])
+}
+
+func BenchmarkProposalLookupWithIndexes(b *testing.B) {
+	benchmarkProposalLookup(b, migrations)
+}
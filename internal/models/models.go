@@ -1,12 +1,14 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
-// Proposal represents a governance proposal from any chain
+// Proposal represents a governance proposal from any chain; (ChainID, ProposalID) is unique
+// and, like NotificationSent, indexed by the 0003_proposal_indexes migration
 type Proposal struct {
 	ID          uint   `gorm:"primaryKey"`
 	ChainID     string `gorm:"index;not null"`
@@ -14,6 +16,17 @@ type Proposal struct {
 	Title       string
 	Description string
 	Status      string
+	Proposer    string // Address that submitted the proposal (blank if unknown)
+	Expedited   bool   `gorm:"default:false"` // Expedited proposals have a shorter voting period
+
+	// Type URL of the proposal's message or content, e.g. /cosmos.upgrade.v1beta1.MsgSoftwareUpgrade
+	ProposalType string
+	// Comma-separated type URLs of every message, set only for multi-message proposals
+	MessageTypes  string
+	UpgradeName   string // Software upgrade plan name (upgrade proposals only)
+	UpgradeHeight int64  // Software upgrade target height (upgrade proposals only)
+	UpgradeInfo   string // Software upgrade plan info, often JSON listing binaries per platform
+
 	VotingStart *time.Time
 	VotingEnd   *time.Time
 	CreatedAt   time.Time
@@ -21,11 +34,56 @@ type Proposal struct {
 
 	// Notification tracking
 	NotificationSent bool `gorm:"default:false"`
+	ReminderSent     bool `gorm:"default:false"` // End-of-voting reminder already sent
+	// Set once the notifier has announced the proposal; NotificationSent alone is also set
+	// for proposals the notification filter hid and for history stored on the first scan
+	Announced bool `gorm:"default:false"`
+
+	// Discord message the proposal was announced in; status changes, reminders and vote
+	// confirmations reply to it. Cleared when the message turns out to have been deleted
+	NotificationChannelID string
+	NotificationMessageID string
+
+	// Final tally in base units, stored once voting has ended; empty before
+	FinalTallyYes        string
+	FinalTallyNo         string
+	FinalTallyAbstain    string
+	FinalTallyNoWithVeto string
+
+	// Status changes of announced proposals, queued for a notification by the scanner
+	PreviousStatus      string // Status before the last change
+	StatusChangePending bool   `gorm:"default:false;index"`
+
+	// Archived proposals ended long ago and are hidden from listings; their votes are kept
+	Archived bool `gorm:"default:false;index"`
 
 	// Voting tracking
 	Vote *Vote `gorm:"foreignKey:ProposalID,ChainID;references:ProposalID,ChainID"`
 }
 
+// HasFinalTally reports whether the proposal's final tally has been stored
+func (p *Proposal) HasFinalTally() bool {
+	return p.FinalTallyYes != "" || p.FinalTallyNo != "" || p.FinalTallyAbstain != "" || p.FinalTallyNoWithVeto != ""
+}
+
+// Types returns the type URL of every message in the proposal
+func (p *Proposal) Types() []string {
+	if p.MessageTypes == "" {
+		return []string{p.ProposalType}
+	}
+	return strings.Split(p.MessageTypes, ",")
+}
+
+// NotArchived is a query scope excluding archived proposals
+func NotArchived(db *gorm.DB) *gorm.DB {
+	return db.Where("archived = ?", false)
+}
+
+// InVotingPeriod is a query scope selecting proposals whose voting period is open
+func InVotingPeriod(db *gorm.DB) *gorm.DB {
+	return db.Where("status = ?", "PROPOSAL_STATUS_VOTING_PERIOD")
+}
+
 // Vote represents a vote cast on a proposal
 type Vote struct {
 	ID         uint   `gorm:"primaryKey"`
@@ -34,6 +92,8 @@ type Vote struct {
 	Option     string // yes, no, abstain, no_with_veto
 	TxHash     string
 	VotedAt    time.Time
+	VotedBy    string // Discord user ID that triggered the vote (audit trail)
+	Rationale  string // Optional note on why the vote was cast; kept locally, never sent on-chain
 	CreatedAt  time.Time
 
 	// Authz fields for voting on behalf of another wallet
@@ -63,12 +123,47 @@ type NotificationLog struct {
 	SentAt     time.Time
 }
 
-// InitDB initializes the database and creates tables
+// AuthzGrantWarning records the last authz grant expiry warning sent for a chain's granter, so
+// each grant is warned about once while expiring and once more if it lapses
+type AuthzGrantWarning struct {
+	ID         uint      `gorm:"primaryKey"`
+	ChainID    string    `gorm:"uniqueIndex:idx_authz_grant_warnings_granter;not null"`
+	Granter    string    `gorm:"uniqueIndex:idx_authz_grant_warnings_granter"`
+	Expiration time.Time // Expiry of the grant warned about; zero when no grant was found
+	Expired    bool      // Whether the warning was for a grant that had already lapsed
+	WarnedAt   time.Time
+}
+
+// Auto-vote states
+const (
+	AutoVotePending = "pending" // Waiting for DueAt
+	AutoVoteVetoed  = "vetoed"  // An operator reacted 🛑 before it was cast
+	AutoVoteVoted   = "voted"
+	AutoVoteSkipped = "skipped" // No longer applicable, e.g. voted by hand or voting ended
+	AutoVoteFailed  = "failed"
+)
+
+// AutoVote is a vote scheduled by an auto-vote rule, cast at DueAt unless vetoed first.
+// There is at most one per proposal, so a vetoed or failed auto-vote is never rescheduled
+type AutoVote struct {
+	ID         uint   `gorm:"primaryKey"`
+	ChainID    string `gorm:"uniqueIndex:idx_auto_votes_proposal;not null"`
+	ProposalID string `gorm:"uniqueIndex:idx_auto_votes_proposal;not null"`
+	Option     string
+	Rule       string    // Description of the rule that matched
+	DueAt      time.Time `gorm:"index"`
+	Status     string    `gorm:"index"`
+	Detail     string    // Who vetoed it, or why it was skipped or failed
+
+	// Discord message announcing the auto-vote; reacting 🛑 to it vetoes the vote
+	ChannelID string
+	MessageID string `gorm:"index"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// InitDB initializes the database by applying any pending schema migrations
 func InitDB(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&Proposal{},
-		&Vote{},
-		&WalletInfo{},
-		&NotificationLog{},
-	)
+	return RunMigrations(db)
 }
@@ -0,0 +1,50 @@
+package redact
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Mask replaces every redacted value
+const Mask = "REDACTED"
+
+// minSecretLength keeps very short configured values from masking unrelated text
+const minSecretLength = 4
+
+var patterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// Query parameters and key=value pairs: ?api_key=..., &token=..., password=...
+	{regexp.MustCompile(`(?i)\b((?:api[_-]?key|access[_-]?token|auth[_-]?token|token|secret|password|passphrase)=)[^&\s"']+`), "${1}" + Mask},
+	// CLI flags: --api-key ..., --token=...
+	{regexp.MustCompile(`(?i)(--(?:api[_-]key|token|secret|password|passphrase)[= ])\S+`), "${1}" + Mask},
+	// Authorization header values
+	{regexp.MustCompile(`(?i)\b(bearer)\s+[A-Za-z0-9._~+/=-]+`), "${1} " + Mask},
+	// BIP39 mnemonics: 12 to 24 short lowercase words in a row
+	{regexp.MustCompile(`\b(?:[a-z]{3,8}\s+){11,23}[a-z]{3,8}\b`), Mask},
+}
+
+// String masks secrets in s before it is logged: the given values wherever they appear,
+// plus API keys, tokens and passwords in URLs and flags, and anything shaped like a mnemonic
+func String(s string, secrets ...string) string {
+	// Longest first, so a secret containing another is masked whole
+	sorted := append([]string(nil), secrets...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	for _, secret := range sorted {
+		if len(secret) >= minSecretLength {
+			s = strings.ReplaceAll(s, secret, Mask)
+		}
+	}
+
+	for _, p := range patterns {
+		s = p.re.ReplaceAllString(s, p.repl)
+	}
+	return s
+}
+
+// Command joins a command's arguments for logging with secrets masked
+func Command(args []string, secrets ...string) string {
+	return String(strings.Join(args, " "), secrets...)
+}
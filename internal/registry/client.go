@@ -5,10 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
+	"prop-voter/internal/version"
+
 	"go.uber.org/zap"
 )
 
@@ -25,6 +30,8 @@ type ChainInfo struct {
 	GitRepo      string `json:"-"` // Extracted from codebase
 	Version      string `json:"-"` // Extracted from codebase
 	BinaryURL    string `json:"-"` // Extracted from codebase binaries
+	ExplorerURL  string `json:"-"` // Proposal page template derived from explorers
+	Slip44       uint32 `json:"slip44"`
 }
 
 // ChainRegistryResponse represents the full Chain Registry response
@@ -34,6 +41,7 @@ type ChainRegistryResponse struct {
 	ChainID      string `json:"chain_id"`
 	Bech32Prefix string `json:"bech32_prefix"`
 	DaemonName   string `json:"daemon_name"`
+	Slip44       uint32 `json:"slip44"`
 	Staking      struct {
 		StakingTokens []struct {
 			Denom string `json:"denom"`
@@ -48,6 +56,14 @@ type ChainRegistryResponse struct {
 		PNG string `json:"png"`
 		SVG string `json:"svg"`
 	} `json:"logo_URIs"`
+	Explorers []RegistryExplorer `json:"explorers"`
+}
+
+// RegistryExplorer is a block explorer entry from the Chain Registry
+type RegistryExplorer struct {
+	Kind         string `json:"kind"`
+	URL          string `json:"url"`
+	ProposalPage string `json:"proposal_page"`
 }
 
 // Client handles Chain Registry API interactions
@@ -55,14 +71,40 @@ type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	logger     *zap.Logger
+	cacheMu    sync.Mutex // Guards cache; binary setup fetches chains concurrently
 	cache      map[string]*ChainInfo
 	cacheTTL   time.Duration
+	cacheDir   string // On-disk cache directory (disabled when empty)
+}
+
+// diskCacheEntry is the on-disk representation of cached chain info
+type diskCacheEntry struct {
+	FetchedAt    time.Time `json:"fetched_at"`
+	ChainName    string    `json:"chain_name"`
+	PrettyName   string    `json:"pretty_name"`
+	ChainID      string    `json:"chain_id"`
+	Bech32Prefix string    `json:"bech32_prefix"`
+	DaemonName   string    `json:"daemon_name"`
+	Denom        string    `json:"denom"`
+	Decimals     int       `json:"decimals"`
+	LogoURL      string    `json:"logo_url"`
+	GitRepo      string    `json:"git_repo"`
+	Version      string    `json:"version"`
+	BinaryURL    string    `json:"binary_url"`
+	ExplorerURL  string    `json:"explorer_url"`
+	Slip44       uint32    `json:"slip44"`
 }
 
-// NewClient creates a new Chain Registry client
-func NewClient(logger *zap.Logger) *Client {
+// DefaultBaseURL is the raw content root of the official Cosmos Chain Registry
+const DefaultBaseURL = "https://raw.githubusercontent.com/cosmos/chain-registry/master"
+
+// NewClient creates a new Chain Registry client reading from baseURL (DefaultBaseURL if empty)
+func NewClient(logger *zap.Logger, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
 	return &Client{
-		baseURL: "https://raw.githubusercontent.com/cosmos/chain-registry/master",
+		baseURL: strings.TrimSuffix(baseURL, "/"),
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -72,14 +114,46 @@ func NewClient(logger *zap.Logger) *Client {
 	}
 }
 
-// GetChainInfo fetches chain information from the Chain Registry
+// EnableDiskCache persists fetched chain info as JSON files in dir, reusing them until ttl expires
+func (c *Client) EnableDiskCache(dir string, ttl time.Duration) {
+	c.cacheDir = dir
+	if ttl > 0 {
+		c.cacheTTL = ttl
+	}
+}
+
+// GetChainInfo fetches chain information from the Chain Registry; chainName is the chain's
+// registry directory, e.g. "osmosis" or "testnets/osmosistestnet"
 func (c *Client) GetChainInfo(ctx context.Context, chainName string) (*ChainInfo, error) {
 	// Check cache first
-	if cachedInfo, exists := c.cache[chainName]; exists {
+	c.cacheMu.Lock()
+	cachedInfo, exists := c.cache[chainName]
+	c.cacheMu.Unlock()
+	if exists {
 		c.logger.Debug("Using cached chain info", zap.String("chain", chainName))
 		return cachedInfo, nil
 	}
 
+	// Then the on-disk cache, skipping the network entirely if it's still fresh
+	if cachedInfo, ok := c.readDiskCache(chainName); ok {
+		c.logger.Debug("Using disk cached chain info", zap.String("chain", chainName))
+		c.cacheMu.Lock()
+		c.cache[chainName] = cachedInfo
+		c.cacheMu.Unlock()
+		return cachedInfo, nil
+	}
+
+	return c.fetchChainInfo(ctx, chainName)
+}
+
+// RefreshChainInfo re-fetches chain information from the Chain Registry, bypassing both
+// caches, and stores the fresh result in them
+func (c *Client) RefreshChainInfo(ctx context.Context, chainName string) (*ChainInfo, error) {
+	return c.fetchChainInfo(ctx, chainName)
+}
+
+// fetchChainInfo fetches chain.json and assetlist.json for a chain and caches the result
+func (c *Client) fetchChainInfo(ctx context.Context, chainName string) (*ChainInfo, error) {
 	c.logger.Info("Fetching chain info from Chain Registry",
 		zap.String("chain", chainName))
 
@@ -91,6 +165,7 @@ func (c *Client) GetChainInfo(ctx context.Context, chainName string) (*ChainInfo
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	req.Header.Set("User-Agent", version.UserAgent())
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -115,6 +190,7 @@ func (c *Client) GetChainInfo(ctx context.Context, chainName string) (*ChainInfo
 		ChainID:      registryResp.ChainID,
 		Bech32Prefix: registryResp.Bech32Prefix,
 		DaemonName:   registryResp.DaemonName,
+		Slip44:       registryResp.Slip44,
 		GitRepo:      registryResp.Codebase.GitRepo,
 		Version:      registryResp.Codebase.RecommendedVersion,
 		Decimals:     6, // Default to 6 decimals, will be updated from assetlist
@@ -132,6 +208,9 @@ func (c *Client) GetChainInfo(ctx context.Context, chainName string) (*ChainInfo
 		chainInfo.LogoURL = registryResp.LogoURIs.SVG
 	}
 
+	// Derive a proposal explorer link template
+	chainInfo.ExplorerURL = proposalExplorerURL(registryResp.Explorers)
+
 	// Extract binary URL for current platform
 	platform := fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)
 	if binaryURL, exists := registryResp.Codebase.Binaries[platform]; exists {
@@ -147,7 +226,10 @@ func (c *Client) GetChainInfo(ctx context.Context, chainName string) (*ChainInfo
 	}
 
 	// Cache the result
+	c.cacheMu.Lock()
 	c.cache[chainName] = chainInfo
+	c.cacheMu.Unlock()
+	c.writeDiskCache(chainName, chainInfo)
 
 	c.logger.Info("Successfully fetched chain info",
 		zap.String("chain", chainName),
@@ -161,10 +243,99 @@ func (c *Client) GetChainInfo(ctx context.Context, chainName string) (*ChainInfo
 	return chainInfo, nil
 }
 
+// diskCachePath returns the cache file path for a chain
+func (c *Client) diskCachePath(chainName string) string {
+	return filepath.Join(c.cacheDir, chainName+".json")
+}
+
+// readDiskCache loads chain info from disk if present and not older than the cache TTL
+func (c *Client) readDiskCache(chainName string) (*ChainInfo, bool) {
+	if c.cacheDir == "" {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.diskCachePath(chainName))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry diskCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		c.logger.Warn("Ignoring corrupt chain registry cache file",
+			zap.String("chain", chainName),
+			zap.Error(err),
+		)
+		return nil, false
+	}
+
+	if time.Since(entry.FetchedAt) > c.cacheTTL {
+		c.logger.Debug("Disk cached chain info expired", zap.String("chain", chainName))
+		return nil, false
+	}
+
+	return &ChainInfo{
+		ChainName:    entry.ChainName,
+		PrettyName:   entry.PrettyName,
+		ChainID:      entry.ChainID,
+		Bech32Prefix: entry.Bech32Prefix,
+		DaemonName:   entry.DaemonName,
+		Denom:        entry.Denom,
+		Decimals:     entry.Decimals,
+		LogoURL:      entry.LogoURL,
+		GitRepo:      entry.GitRepo,
+		Version:      entry.Version,
+		BinaryURL:    entry.BinaryURL,
+		ExplorerURL:  entry.ExplorerURL,
+		Slip44:       entry.Slip44,
+	}, true
+}
+
+// writeDiskCache stores chain info on disk; failures are logged but not fatal
+func (c *Client) writeDiskCache(chainName string, chainInfo *ChainInfo) {
+	if c.cacheDir == "" {
+		return
+	}
+
+	entry := diskCacheEntry{
+		FetchedAt:    time.Now(),
+		ChainName:    chainInfo.ChainName,
+		PrettyName:   chainInfo.PrettyName,
+		ChainID:      chainInfo.ChainID,
+		Bech32Prefix: chainInfo.Bech32Prefix,
+		DaemonName:   chainInfo.DaemonName,
+		Denom:        chainInfo.Denom,
+		Decimals:     chainInfo.Decimals,
+		LogoURL:      chainInfo.LogoURL,
+		GitRepo:      chainInfo.GitRepo,
+		Version:      chainInfo.Version,
+		BinaryURL:    chainInfo.BinaryURL,
+		ExplorerURL:  chainInfo.ExplorerURL,
+		Slip44:       chainInfo.Slip44,
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		c.logger.Warn("Failed to encode chain registry cache", zap.String("chain", chainName), zap.Error(err))
+		return
+	}
+
+	// Testnet chains are cached under a testnets/ subdirectory, mirroring the registry
+	cacheDir := filepath.Dir(c.diskCachePath(chainName))
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		c.logger.Warn("Failed to create chain registry cache directory", zap.String("dir", cacheDir), zap.Error(err))
+		return
+	}
+
+	if err := os.WriteFile(c.diskCachePath(chainName), data, 0644); err != nil {
+		c.logger.Warn("Failed to write chain registry cache", zap.String("chain", chainName), zap.Error(err))
+	}
+}
+
 // AssetListResponse represents the assetlist.json response
 type AssetListResponse struct {
 	Assets []struct {
 		Base       string `json:"base"`
+		Display    string `json:"display"`
 		DenomUnits []struct {
 			Denom    string `json:"denom"`
 			Exponent int    `json:"exponent"`
@@ -180,6 +351,7 @@ func (c *Client) fetchAssetInfo(ctx context.Context, chainName string, chainInfo
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
+	req.Header.Set("User-Agent", version.UserAgent())
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -197,26 +369,33 @@ func (c *Client) fetchAssetInfo(ctx context.Context, chainName string, chainInfo
 	}
 
 	// Find the main staking token and extract its decimal precision
-	for _, asset := range assetResp.Assets {
-		// Look for the asset that matches our staking denom
-		if asset.Base == chainInfo.Denom {
-			// Find the display denomination with highest exponent
-			maxExponent := 0
-			for _, denomUnit := range asset.DenomUnits {
-				if denomUnit.Exponent > maxExponent {
-					maxExponent = denomUnit.Exponent
-				}
+	for i, asset := range assetResp.Assets {
+		// Look for the asset that matches our staking denom; without one, the native
+		// token is listed first by registry convention
+		if asset.Base != chainInfo.Denom && (chainInfo.Denom != "" || i > 0) {
+			continue
+		}
+
+		// Prefer the exponent of the display unit, falling back to the highest exponent
+		exponent := 0
+		for _, denomUnit := range asset.DenomUnits {
+			if denomUnit.Denom == asset.Display {
+				exponent = denomUnit.Exponent
+				break
 			}
-			if maxExponent > 0 {
-				chainInfo.Decimals = maxExponent
-				c.logger.Debug("Found decimal precision from assetlist",
-					zap.String("chain", chainName),
-					zap.String("denom", chainInfo.Denom),
-					zap.Int("decimals", maxExponent),
-				)
-				return nil
+			if denomUnit.Exponent > exponent {
+				exponent = denomUnit.Exponent
 			}
 		}
+		if exponent > 0 {
+			chainInfo.Decimals = exponent
+			c.logger.Debug("Found decimal precision from assetlist",
+				zap.String("chain", chainName),
+				zap.String("denom", asset.Base),
+				zap.Int("decimals", exponent),
+			)
+			return nil
+		}
 	}
 
 	c.logger.Debug("Asset not found in assetlist, using default decimals",
@@ -275,13 +454,28 @@ type BinaryInfo struct {
 
 // ClearCache removes all cached chain information
 func (c *Client) ClearCache() {
+	c.cacheMu.Lock()
 	c.cache = make(map[string]*ChainInfo)
+	c.cacheMu.Unlock()
 	c.logger.Debug("Chain registry cache cleared")
 }
 
-// ListSupportedChains returns a list of commonly supported chains
+// testnetChains are commonly used chains from the registry's testnets/ directory
+var testnetChains = []string{
+	"cosmoshubtestnet",
+	"osmosistestnet",
+	"junotestnet",
+	"neutrontestnet",
+	"stargazetestnet",
+	"injectivetestnet",
+	"celestiatestnet3",
+	"nobletestnet",
+}
+
+// ListSupportedChains returns a list of commonly supported chains; testnets are listed as
+// "testnets/<chain>" and are configured with that chain name plus testnet: true
 func (c *Client) ListSupportedChains() []string {
-	return []string{
+	chains := []string{
 		"cosmoshub",
 		"osmosis",
 		"juno",
@@ -345,4 +539,34 @@ func (c *Client) ListSupportedChains() []string {
 		"dymension",
 		"celestia",
 	}
+
+	for _, chain := range testnetChains {
+		chains = append(chains, "testnets/"+chain)
+	}
+	return chains
+}
+
+// proposalExplorerURL picks a proposal page template (with a {proposal_id} placeholder) from the
+// registry's explorers, preferring an explicit proposal_page, then Mintscan, then ping.pub
+func proposalExplorerURL(explorers []RegistryExplorer) string {
+	for _, explorer := range explorers {
+		if strings.Contains(explorer.ProposalPage, "${proposalId}") {
+			return strings.ReplaceAll(explorer.ProposalPage, "${proposalId}", "{proposal_id}")
+		}
+	}
+
+	for _, kind := range []string{"mintscan", "ping.pub"} {
+		for _, explorer := range explorers {
+			if !strings.EqualFold(explorer.Kind, kind) || explorer.URL == "" {
+				continue
+			}
+			base := strings.TrimSuffix(explorer.URL, "/")
+			if kind == "mintscan" {
+				return base + "/proposals/{proposal_id}"
+			}
+			return base + "/gov/{proposal_id}"
+		}
+	}
+
+	return ""
 }
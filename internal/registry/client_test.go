@@ -7,13 +7,14 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"go.uber.org/zap/zaptest"
 )
 
 func TestNewClient(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	client := NewClient(logger)
+	client := NewClient(logger, "")
 
 	if client.baseURL != "https://raw.githubusercontent.com/cosmos/chain-registry/master" {
 		t.Errorf("Expected default base URL, got %s", client.baseURL)
@@ -32,6 +33,35 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClientCustomBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mirror/testchain/chain.json" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(ChainRegistryResponse{
+			ChainName:  "testchain",
+			ChainID:    "testchain-1",
+			DaemonName: "testd",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(zaptest.NewLogger(t), server.URL+"/mirror/")
+
+	if client.baseURL != server.URL+"/mirror" {
+		t.Errorf("Expected trailing slash to be trimmed, got %s", client.baseURL)
+	}
+
+	chainInfo, err := client.GetChainInfo(context.Background(), "testchain")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if chainInfo.ChainID != "testchain-1" {
+		t.Errorf("Expected chain ID testchain-1 from the custom registry, got %s", chainInfo.ChainID)
+	}
+}
+
 func TestGetChainInfo_Success(t *testing.T) {
 	// Create a mock Chain Registry response
 	mockResponse := ChainRegistryResponse{
@@ -118,7 +148,7 @@ func TestGetChainInfo_Success(t *testing.T) {
 	defer server.Close()
 
 	logger := zaptest.NewLogger(t)
-	client := NewClient(logger)
+	client := NewClient(logger, "")
 	client.baseURL = server.URL // Override with test server URL
 
 	ctx := context.Background()
@@ -184,7 +214,7 @@ func TestGetChainInfo_NotFound(t *testing.T) {
 	defer server.Close()
 
 	logger := zaptest.NewLogger(t)
-	client := NewClient(logger)
+	client := NewClient(logger, "")
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -258,7 +288,7 @@ func TestGetChainInfo_Cache(t *testing.T) {
 	defer server.Close()
 
 	logger := zaptest.NewLogger(t)
-	client := NewClient(logger)
+	client := NewClient(logger, "")
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -284,6 +314,116 @@ func TestGetChainInfo_Cache(t *testing.T) {
 	}
 }
 
+func TestRefreshChainInfo(t *testing.T) {
+	requestCount := 0
+	recommendedVersion := "v25.0.0"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/osmosis/chain.json":
+			mockResponse := ChainRegistryResponse{
+				ChainName:  "osmosis",
+				ChainID:    "osmosis-1",
+				DaemonName: "osmosisd",
+			}
+			mockResponse.Codebase.RecommendedVersion = recommendedVersion
+			json.NewEncoder(w).Encode(mockResponse)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(zaptest.NewLogger(t), "")
+	client.baseURL = server.URL
+	client.EnableDiskCache(t.TempDir(), time.Hour)
+
+	ctx := context.Background()
+
+	if _, err := client.GetChainInfo(ctx, "osmosis"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	recommendedVersion = "v26.0.0"
+
+	chainInfo, err := client.RefreshChainInfo(ctx, "osmosis")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if chainInfo.Version != "v26.0.0" {
+		t.Errorf("Expected refreshed version v26.0.0, got %s", chainInfo.Version)
+	}
+	if requestCount != 4 {
+		t.Errorf("Expected refresh to bypass the cache (4 requests), got %d", requestCount)
+	}
+
+	// The refreshed data replaces both the memory and disk caches
+	cached, err := client.GetChainInfo(ctx, "osmosis")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cached.Version != "v26.0.0" {
+		t.Errorf("Expected cached version v26.0.0, got %s", cached.Version)
+	}
+
+	onDisk, ok := client.readDiskCache("osmosis")
+	if !ok || onDisk.Version != "v26.0.0" {
+		t.Errorf("Expected disk cache to hold the refreshed version, got %+v", onDisk)
+	}
+	if requestCount != 4 {
+		t.Errorf("Expected no further requests after refresh, got %d", requestCount)
+	}
+}
+
+func TestGetChainInfo_Decimals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/evmos/chain.json":
+			w.Write([]byte(`{"chain_name":"evmos","chain_id":"evmos_9001-2","staking":{"staking_tokens":[{"denom":"aevmos"}]}}`))
+		case "/evmos/assetlist.json":
+			w.Write([]byte(`{"assets":[{"base":"aevmos","display":"evmos","denom_units":[{"denom":"aevmos","exponent":0},{"denom":"evmos","exponent":18}]}]}`))
+		case "/osmosis/chain.json":
+			w.Write([]byte(`{"chain_name":"osmosis","chain_id":"osmosis-1","staking":{"staking_tokens":[{"denom":"uosmo"}]}}`))
+		case "/osmosis/assetlist.json":
+			w.Write([]byte(`{"assets":[{"base":"uion","display":"ion","denom_units":[{"denom":"uion","exponent":0},{"denom":"ion","exponent":6}]},{"base":"uosmo","display":"osmo","denom_units":[{"denom":"uosmo","exponent":0},{"denom":"osmo","exponent":6},{"denom":"megaosmo","exponent":12}]}]}`))
+		case "/nostaking/chain.json":
+			w.Write([]byte(`{"chain_name":"nostaking","chain_id":"nostaking-1"}`))
+		case "/nostaking/assetlist.json":
+			w.Write([]byte(`{"assets":[{"base":"afee","display":"fee","denom_units":[{"denom":"afee","exponent":0},{"denom":"fee","exponent":18}]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(zaptest.NewLogger(t), server.URL)
+
+	tests := []struct {
+		chain    string
+		decimals int
+	}{
+		{"evmos", 18},
+		{"osmosis", 6}, // Display unit wins over a higher exponent alias
+		{"nostaking", 18},
+	}
+
+	for _, tt := range tests {
+		chainInfo, err := client.GetChainInfo(context.Background(), tt.chain)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", tt.chain, err)
+		}
+		if chainInfo.Decimals != tt.decimals {
+			t.Errorf("Expected %s to have %d decimals, got %d", tt.chain, tt.decimals, chainInfo.Decimals)
+		}
+	}
+}
+
 func TestGetBinaryInfo_Success(t *testing.T) {
 	chainInfo := &ChainInfo{
 		ChainName:  "osmosis",
@@ -294,7 +434,7 @@ func TestGetBinaryInfo_Success(t *testing.T) {
 	}
 
 	logger := zaptest.NewLogger(t)
-	client := NewClient(logger)
+	client := NewClient(logger, "")
 
 	binaryInfo, err := client.GetBinaryInfo(chainInfo)
 
@@ -334,7 +474,7 @@ func TestGetBinaryInfo_NoBinaryURL(t *testing.T) {
 	}
 
 	logger := zaptest.NewLogger(t)
-	client := NewClient(logger)
+	client := NewClient(logger, "")
 
 	binaryInfo, err := client.GetBinaryInfo(chainInfo)
 
@@ -373,7 +513,7 @@ func TestGetBinaryInfo_InvalidGitRepo(t *testing.T) {
 	}
 
 	logger := zaptest.NewLogger(t)
-	client := NewClient(logger)
+	client := NewClient(logger, "")
 
 	_, err := client.GetBinaryInfo(chainInfo)
 
@@ -389,7 +529,7 @@ func TestGetBinaryInfo_InvalidGitRepo(t *testing.T) {
 
 func TestClientClearCache(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	client := NewClient(logger)
+	client := NewClient(logger, "")
 
 	// Add something to cache
 	client.cache["test"] = &ChainInfo{ChainName: "test"}
@@ -407,7 +547,7 @@ func TestClientClearCache(t *testing.T) {
 
 func TestClientListSupportedChains(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	client := NewClient(logger)
+	client := NewClient(logger, "")
 
 	chains := client.ListSupportedChains()
 
@@ -428,6 +568,10 @@ func TestClientListSupportedChains(t *testing.T) {
 			t.Errorf("Expected chain '%s' to be in supported list", expected)
 		}
 	}
+
+	if !chainMap["testnets/osmosistestnet"] {
+		t.Error("Expected testnets to be listed under testnets/")
+	}
 }
 
 // Test SVG logo fallback
@@ -454,7 +598,7 @@ func TestGetChainInfo_SVGLogoFallback(t *testing.T) {
 	defer server.Close()
 
 	logger := zaptest.NewLogger(t)
-	client := NewClient(logger)
+	client := NewClient(logger, "")
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -495,7 +639,7 @@ func TestGetChainInfo_NoStakingTokens(t *testing.T) {
 	defer server.Close()
 
 	logger := zaptest.NewLogger(t)
-	client := NewClient(logger)
+	client := NewClient(logger, "")
 	client.baseURL = server.URL
 
 	ctx := context.Background()
@@ -509,3 +653,50 @@ func TestGetChainInfo_NoStakingTokens(t *testing.T) {
 		t.Errorf("Expected empty denom, got '%s'", chainInfo.Denom)
 	}
 }
+
+func TestProposalExplorerURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		explorers []RegistryExplorer
+		expected  string
+	}{
+		{
+			name:     "no explorers",
+			expected: "",
+		},
+		{
+			name: "explicit proposal page wins",
+			explorers: []RegistryExplorer{
+				{Kind: "mintscan", URL: "https://www.mintscan.io/osmosis"},
+				{Kind: "other", URL: "https://explorer.example.com", ProposalPage: "https://explorer.example.com/gov/${proposalId}"},
+			},
+			expected: "https://explorer.example.com/gov/{proposal_id}",
+		},
+		{
+			name: "mintscan preferred over ping.pub",
+			explorers: []RegistryExplorer{
+				{Kind: "ping.pub", URL: "https://ping.pub/osmosis"},
+				{Kind: "mintscan", URL: "https://www.mintscan.io/osmosis/"},
+			},
+			expected: "https://www.mintscan.io/osmosis/proposals/{proposal_id}",
+		},
+		{
+			name:      "ping.pub fallback",
+			explorers: []RegistryExplorer{{Kind: "ping.pub", URL: "https://ping.pub/osmosis"}},
+			expected:  "https://ping.pub/osmosis/gov/{proposal_id}",
+		},
+		{
+			name:      "unknown explorer",
+			explorers: []RegistryExplorer{{Kind: "bigdipper", URL: "https://bigdipper.live/osmosis"}},
+			expected:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := proposalExplorerURL(tt.explorers); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
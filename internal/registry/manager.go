@@ -3,6 +3,7 @@ package registry
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"prop-voter/config"
 
@@ -15,14 +16,22 @@ type Manager struct {
 	logger *zap.Logger
 }
 
-// NewManager creates a new registry manager
-func NewManager(logger *zap.Logger) *Manager {
+// NewManager creates a new registry manager reading from baseURL (DefaultBaseURL if empty)
+func NewManager(logger *zap.Logger, baseURL string) *Manager {
 	return &Manager{
-		client: NewClient(logger),
+		client: NewClient(logger, baseURL),
 		logger: logger,
 	}
 }
 
+// EnableDiskCache persists Chain Registry responses in dir so restarts skip the network until ttl expires
+func (m *Manager) EnableDiskCache(dir string, ttl time.Duration) {
+	if dir == "" {
+		return
+	}
+	m.client.EnableDiskCache(dir, ttl)
+}
+
 // PopulateChainConfigs populates Chain Registry information for all chains
 func (m *Manager) PopulateChainConfigs(ctx context.Context, chains []config.ChainConfig) error {
 	for i := range chains {
@@ -36,13 +45,14 @@ func (m *Manager) PopulateChainConfigs(ctx context.Context, chains []config.Chai
 		}
 
 		m.logger.Info("Populating chain info from Chain Registry",
-			zap.String("chain_name", chain.ChainRegistryName))
+			zap.String("chain_name", chain.ChainRegistryName),
+			zap.Bool("testnet", chain.Testnet))
 
-		// Fetch chain info from registry
-		chainInfo, err := m.client.GetChainInfo(ctx, chain.ChainRegistryName)
+		// Fetch chain info from registry (testnets/<chain> for testnet chains)
+		chainInfo, err := m.client.GetChainInfo(ctx, chain.RegistryPath())
 		if err != nil {
 			return fmt.Errorf("failed to fetch chain info for %s: %w",
-				chain.ChainRegistryName, err)
+				chain.RegistryPath(), err)
 		}
 
 		// Convert to config format
@@ -57,6 +67,8 @@ func (m *Manager) PopulateChainConfigs(ctx context.Context, chains []config.Chai
 			GitRepo:      chainInfo.GitRepo,
 			Version:      chainInfo.Version,
 			BinaryURL:    chainInfo.BinaryURL,
+			ExplorerURL:  chainInfo.ExplorerURL,
+			Slip44:       chainInfo.Slip44,
 		}
 
 		// Populate the chain config
@@ -109,6 +121,7 @@ func (m *Manager) GetBinaryInfoForChain(ctx context.Context, chain *config.Chain
 		GitRepo:      chain.RegistryInfo.GitRepo,
 		Version:      chain.RegistryInfo.Version,
 		BinaryURL:    chain.RegistryInfo.BinaryURL,
+		ExplorerURL:  chain.RegistryInfo.ExplorerURL,
 	}
 
 	return m.client.GetBinaryInfo(chainInfo)
@@ -159,6 +172,11 @@ func (m *Manager) ListSupportedChains() []string {
 	return m.client.ListSupportedChains()
 }
 
+// RefreshChainInfo re-fetches a single chain's Chain Registry data, replacing any cached copy
+func (m *Manager) RefreshChainInfo(ctx context.Context, chainName string) (*ChainInfo, error) {
+	return m.client.RefreshChainInfo(ctx, chainName)
+}
+
 // ClearCache clears the Chain Registry cache
 func (m *Manager) ClearCache() {
 	m.client.ClearCache()
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"prop-voter/config"
 
@@ -15,7 +16,7 @@ import (
 
 func TestNewManager(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	manager := NewManager(logger)
+	manager := NewManager(logger, "")
 
 	if manager.client == nil {
 		t.Error("Expected client to be initialized")
@@ -111,7 +112,7 @@ func TestPopulateChainConfigs_Success(t *testing.T) {
 	defer server.Close()
 
 	logger := zaptest.NewLogger(t)
-	manager := NewManager(logger)
+	manager := NewManager(logger, "")
 	manager.client.baseURL = server.URL
 
 	// Create test chains configuration
@@ -196,7 +197,7 @@ func TestPopulateChainConfigs_ChainNotFound(t *testing.T) {
 	defer server.Close()
 
 	logger := zaptest.NewLogger(t)
-	manager := NewManager(logger)
+	manager := NewManager(logger, "")
 	manager.client.baseURL = server.URL
 
 	chains := []config.ChainConfig{
@@ -221,9 +222,57 @@ func TestPopulateChainConfigs_ChainNotFound(t *testing.T) {
 	}
 }
 
+func TestPopulateChainConfigs_Testnet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/testnets/osmosistestnet/chain.json":
+			json.NewEncoder(w).Encode(ChainRegistryResponse{
+				ChainName:  "osmosistestnet",
+				ChainID:    "osmo-test-5",
+				DaemonName: "osmosisd",
+			})
+		case "/osmosis/chain.json":
+			json.NewEncoder(w).Encode(ChainRegistryResponse{
+				ChainName:  "osmosis",
+				ChainID:    "osmosis-1",
+				DaemonName: "osmosisd",
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	manager := NewManager(zaptest.NewLogger(t), server.URL)
+	manager.EnableDiskCache(t.TempDir(), time.Hour)
+
+	chains := []config.ChainConfig{
+		{ChainRegistryName: "osmosistestnet", Testnet: true},
+		{ChainRegistryName: "osmosis"},
+	}
+	if err := manager.PopulateChainConfigs(context.Background(), chains); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if chains[0].GetChainID() != "osmo-test-5" {
+		t.Errorf("Expected testnet chain ID 'osmo-test-5', got '%s'", chains[0].GetChainID())
+	}
+	if chains[1].GetChainID() != "osmosis-1" {
+		t.Errorf("Expected mainnet chain ID 'osmosis-1', got '%s'", chains[1].GetChainID())
+	}
+
+	// A testnet flag on a mainnet-only name should not fall back to the mainnet path
+	chains = []config.ChainConfig{{ChainRegistryName: "osmosis", Testnet: true}}
+	if err := manager.PopulateChainConfigs(context.Background(), chains); err == nil {
+		t.Error("Expected error for a testnet that isn't in testnets/")
+	}
+}
+
 func TestGetBinaryInfoForChain_ChainRegistry(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	manager := NewManager(logger)
+	manager := NewManager(logger, "")
 
 	// Create chain config with registry info
 	chain := &config.ChainConfig{
@@ -269,7 +318,7 @@ func TestGetBinaryInfoForChain_ChainRegistry(t *testing.T) {
 
 func TestGetBinaryInfoForChain_Legacy(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	manager := NewManager(logger)
+	manager := NewManager(logger, "")
 
 	// Create legacy chain config
 	chain := &config.ChainConfig{
@@ -309,7 +358,7 @@ func TestGetBinaryInfoForChain_Legacy(t *testing.T) {
 
 func TestGetBinaryInfoForChain_LegacyDisabled(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	manager := NewManager(logger)
+	manager := NewManager(logger, "")
 
 	chain := &config.ChainConfig{
 		Name:      "Custom Chain",
@@ -336,7 +385,7 @@ func TestGetBinaryInfoForChain_LegacyDisabled(t *testing.T) {
 
 func TestGetBinaryInfoForChain_ChainRegistryNoInfo(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	manager := NewManager(logger)
+	manager := NewManager(logger, "")
 
 	chain := &config.ChainConfig{
 		ChainRegistryName: "osmosis",
@@ -358,7 +407,7 @@ func TestGetBinaryInfoForChain_ChainRegistryNoInfo(t *testing.T) {
 
 func TestValidateChainConfig_ChainRegistry(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	manager := NewManager(logger)
+	manager := NewManager(logger, "")
 
 	// Valid Chain Registry config
 	validConfig := &config.ChainConfig{
@@ -414,7 +463,7 @@ func TestValidateChainConfig_ChainRegistry(t *testing.T) {
 
 func TestValidateChainConfig_Legacy(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	manager := NewManager(logger)
+	manager := NewManager(logger, "")
 
 	// Valid legacy config
 	validConfig := &config.ChainConfig{
@@ -455,7 +504,7 @@ func TestValidateChainConfig_Legacy(t *testing.T) {
 
 func TestValidateChainConfig_CommonFields(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	manager := NewManager(logger)
+	manager := NewManager(logger, "")
 
 	testCases := []struct {
 		name        string
@@ -510,7 +559,7 @@ func TestValidateChainConfig_CommonFields(t *testing.T) {
 
 func TestManagerListSupportedChains(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	manager := NewManager(logger)
+	manager := NewManager(logger, "")
 
 	chains := manager.ListSupportedChains()
 
@@ -535,7 +584,7 @@ func TestManagerListSupportedChains(t *testing.T) {
 
 func TestManagerClearCache(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	manager := NewManager(logger)
+	manager := NewManager(logger, "")
 
 	// Add something to the client cache through manager
 	manager.client.cache["test"] = &ChainInfo{ChainName: "test"}
@@ -567,3 +616,76 @@ func contains(s, substr string) bool {
 						return false
 					}())))
 }
+
+func TestManagerDiskCache(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/osmosis/chain.json":
+			json.NewEncoder(w).Encode(ChainRegistryResponse{
+				ChainName:    "osmosis",
+				PrettyName:   "Osmosis",
+				ChainID:      "osmosis-1",
+				Bech32Prefix: "osmo",
+				DaemonName:   "osmosisd",
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	logger := zaptest.NewLogger(t)
+	ctx := context.Background()
+
+	// First manager fetches from the network and writes the disk cache
+	first := NewManager(logger, "")
+	first.client.baseURL = server.URL
+	first.EnableDiskCache(cacheDir, time.Hour)
+
+	chains := []config.ChainConfig{{ChainRegistryName: "osmosis"}}
+	if err := first.PopulateChainConfigs(ctx, chains); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	requestsAfterFirst := requestCount
+	if requestsAfterFirst == 0 {
+		t.Fatal("Expected first manager to hit the network")
+	}
+
+	// Second manager (simulating a restart) should read from disk only
+	second := NewManager(logger, "")
+	second.client.baseURL = server.URL
+	second.EnableDiskCache(cacheDir, time.Hour)
+
+	chains = []config.ChainConfig{{ChainRegistryName: "osmosis"}}
+	if err := second.PopulateChainConfigs(ctx, chains); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requestCount != requestsAfterFirst {
+		t.Errorf("Expected no additional requests from disk cache, got %d", requestCount-requestsAfterFirst)
+	}
+
+	if chains[0].GetChainID() != "osmosis-1" {
+		t.Errorf("Expected chain ID 'osmosis-1' from disk cache, got '%s'", chains[0].GetChainID())
+	}
+
+	// An expired entry should be refreshed from the network
+	expired := NewManager(logger, "")
+	expired.client.baseURL = server.URL
+	expired.EnableDiskCache(cacheDir, time.Nanosecond)
+
+	chains = []config.ChainConfig{{ChainRegistryName: "osmosis"}}
+	if err := expired.PopulateChainConfigs(ctx, chains); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requestCount == requestsAfterFirst {
+		t.Error("Expected expired disk cache to trigger a network refresh")
+	}
+}
@@ -0,0 +1,56 @@
+package scanner
+
+import (
+	"context"
+	"time"
+
+	"prop-voter/internal/models"
+
+	"go.uber.org/zap"
+)
+
+// defaultArchiveInterval is how often archival runs when not configured
+const defaultArchiveInterval = 24 * time.Hour
+
+// archiveLoop archives old proposals at startup and then on every archive interval
+func (s *Scanner) archiveLoop(ctx context.Context) {
+	interval := s.config.Database.ArchiveInterval
+	if interval <= 0 {
+		interval = defaultArchiveInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		cutoff := time.Now().Add(-s.config.Database.ArchiveAfter)
+		if archived, err := s.archiveProposals(cutoff); err != nil {
+			s.logger.Error("Failed to archive old proposals", zap.Error(err))
+		} else if archived > 0 {
+			s.logger.Info("Archived old proposals",
+				zap.Int64("count", archived),
+				zap.Time("voting_ended_before", cutoff),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// archiveProposals flags proposals whose voting ended before cutoff as archived; votes are
+// stored separately and are left untouched
+func (s *Scanner) archiveProposals(cutoff time.Time) (int64, error) {
+	s.dbMu.Lock()
+	defer s.dbMu.Unlock()
+
+	result := s.db.Model(&models.Proposal{}).
+		Scopes(models.NotArchived).
+		Where("voting_end IS NOT NULL AND voting_end < ?", cutoff).
+		Where("status NOT IN ?", []string{"PROPOSAL_STATUS_DEPOSIT_PERIOD", "PROPOSAL_STATUS_VOTING_PERIOD"}).
+		Update("archived", true)
+	return result.RowsAffected, result.Error
+}
@@ -6,11 +6,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"prop-voter/config"
+	"prop-voter/internal/health"
 	"prop-voter/internal/models"
+	"prop-voter/internal/version"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -22,8 +26,24 @@ type Scanner struct {
 	config *config.Config
 	logger *zap.Logger
 	client *http.Client
+
+	scanMu    sync.RWMutex
+	lastScans map[string]time.Time // chain ID -> last successful scan
+
+	dbMu sync.Mutex // Serializes database access from concurrent chain scans
+
+	onUpgradePassed func(proposal models.Proposal) // Called when a software upgrade proposal passes
+	health          *health.Status                 // Receives each chain's scan outcome, if set
 }
 
+const (
+	// defaultScanConcurrency is the number of chains scanned in parallel when not configured
+	defaultScanConcurrency = 5
+
+	// defaultChainScanTimeout bounds a single chain scan when not configured
+	defaultChainScanTimeout = 2 * time.Minute
+)
+
 // PaginationInfo represents pagination information from the API
 type PaginationInfo struct {
 	NextKey string `json:"next_key,omitempty"`
@@ -36,7 +56,12 @@ type ProposalData struct {
 	Title            string
 	Description      string
 	Status           string
-	FinalTallyResult interface{}
+	Proposer         string
+	Expedited        bool
+	Type             string       // Type URL of the first message (v1) or content (v1beta1)
+	MessageTypes     []string     // Type URLs of every message, set only for multi-message v1 proposals
+	Upgrade          *UpgradePlan // Set for software upgrade proposals
+	FinalTallyResult *TallyResult // Final counts, set by the chain once voting ends
 	SubmitTime       string
 	DepositEndTime   string
 	TotalDeposit     []interface{}
@@ -50,7 +75,10 @@ type ProposalDataV1 struct {
 	Title            string        `json:"title"`
 	Summary          string        `json:"summary"`
 	Status           string        `json:"status"`
-	FinalTallyResult interface{}   `json:"final_tally_result"`
+	Proposer         string        `json:"proposer"`
+	Expedited        bool          `json:"expedited"`
+	Messages         []Content     `json:"messages"`
+	FinalTallyResult *TallyResult  `json:"final_tally_result"`
 	SubmitTime       string        `json:"submit_time"`
 	DepositEndTime   string        `json:"deposit_end_time"`
 	TotalDeposit     []interface{} `json:"total_deposit"`
@@ -63,7 +91,7 @@ type ProposalDataV1Beta1 struct {
 	ProposalID       string        `json:"proposal_id"`
 	Content          Content       `json:"content"`
 	Status           string        `json:"status"`
-	FinalTallyResult interface{}   `json:"final_tally_result"`
+	FinalTallyResult *TallyResult  `json:"final_tally_result"`
 	SubmitTime       string        `json:"submit_time"`
 	DepositEndTime   string        `json:"deposit_end_time"`
 	TotalDeposit     []interface{} `json:"total_deposit"`
@@ -71,11 +99,112 @@ type ProposalDataV1Beta1 struct {
 	VotingEndTime    string        `json:"voting_end_time"`
 }
 
-// Content represents the content of a proposal (v1beta1)
+// TallyResult is a proposal's vote tally in base units; gov v1 names the fields *_count
+type TallyResult struct {
+	Yes        string
+	No         string
+	Abstain    string
+	NoWithVeto string
+}
+
+// UnmarshalJSON accepts both the v1 (yes_count) and v1beta1 (yes) field names
+func (t *TallyResult) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Yes             string `json:"yes"`
+		No              string `json:"no"`
+		Abstain         string `json:"abstain"`
+		NoWithVeto      string `json:"no_with_veto"`
+		YesCount        string `json:"yes_count"`
+		NoCount         string `json:"no_count"`
+		AbstainCount    string `json:"abstain_count"`
+		NoWithVetoCount string `json:"no_with_veto_count"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*t = TallyResult{
+		Yes:        firstNonEmpty(raw.YesCount, raw.Yes),
+		No:         firstNonEmpty(raw.NoCount, raw.No),
+		Abstain:    firstNonEmpty(raw.AbstainCount, raw.Abstain),
+		NoWithVeto: firstNonEmpty(raw.NoWithVetoCount, raw.NoWithVeto),
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Content represents the content of a proposal (v1beta1) or a v1 proposal message
 type Content struct {
-	Type        string `json:"@type"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
+	Type        string       `json:"@type"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Plan        *UpgradePlan `json:"plan,omitempty"`    // Software upgrade proposals
+	Content     *Content     `json:"content,omitempty"` // Legacy content wrapped by v1 MsgExecLegacyContent
+}
+
+// UpgradePlan is the plan carried by a software upgrade proposal
+type UpgradePlan struct {
+	Name   string `json:"name"`
+	Height string `json:"height"`
+	Info   string `json:"info"`
+}
+
+// textProposalType labels v1 proposals without messages, which are signaling (text) proposals
+const textProposalType = "/cosmos.gov.v1beta1.TextProposal"
+
+// legacyContentType wraps v1beta1 content in v1 proposals
+const legacyContentType = "/cosmos.gov.v1.MsgExecLegacyContent"
+
+// unwrap returns the legacy content inside MsgExecLegacyContent, or the message itself
+func (c Content) unwrap() Content {
+	if c.Type == legacyContentType && c.Content != nil {
+		return *c.Content
+	}
+	return c
+}
+
+// proposalType returns the type URL and upgrade plan (if any) of a v1 proposal's messages
+func proposalType(messages []Content) (string, *UpgradePlan) {
+	if len(messages) == 0 {
+		return textProposalType, nil
+	}
+
+	var plan *UpgradePlan
+	for _, msg := range messages {
+		if inner := msg.unwrap(); inner.Plan != nil {
+			plan = inner.Plan
+			break
+		}
+	}
+	return messages[0].unwrap().Type, plan
+}
+
+// messageTypes returns the type URLs of a multi-message v1 proposal, or nil for a single message
+func messageTypes(messages []Content) []string {
+	if len(messages) < 2 {
+		return nil
+	}
+
+	types := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		types = append(types, msg.unwrap().Type)
+	}
+	return types
+}
+
+// ProposerResponse represents the proposals/{id}/proposer API response
+type ProposerResponse struct {
+	ProposalID string `json:"proposal_id"`
+	Proposer   string `json:"proposer"`
 }
 
 // GovernanceResponseV1 represents the v1 API response
@@ -92,12 +221,53 @@ type GovernanceResponseV1Beta1 struct {
 
 // NewScanner creates a new proposal scanner
 func NewScanner(db *gorm.DB, config *config.Config, logger *zap.Logger) *Scanner {
+	timeout := config.Scanning.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
 	return &Scanner{
-		db:     db,
-		config: config,
-		logger: logger,
-		client: &http.Client{Timeout: 30 * time.Second},
+		db:        db,
+		config:    config,
+		logger:    logger,
+		client:    &http.Client{Timeout: timeout},
+		lastScans: make(map[string]time.Time),
+	}
+}
+
+// SetUpgradeHandler registers a callback run (in its own goroutine) when a software upgrade
+// proposal is seen passing
+func (s *Scanner) SetUpgradeHandler(handler func(proposal models.Proposal)) {
+	s.onUpgradePassed = handler
+}
+
+// SetHealthStatus publishes each chain scan's outcome to the health endpoint
+func (s *Scanner) SetHealthStatus(status *health.Status) {
+	s.health = status
+}
+
+// upgradePassed hands a newly passed software upgrade proposal to the upgrade handler
+func (s *Scanner) upgradePassed(proposal models.Proposal) {
+	if s.onUpgradePassed == nil || proposal.Status != "PROPOSAL_STATUS_PASSED" || proposal.UpgradeName == "" {
+		return
 	}
+
+	s.logger.Info("Software upgrade proposal passed",
+		zap.String("chain_id", proposal.ChainID),
+		zap.String("proposal_id", proposal.ProposalID),
+		zap.String("upgrade", proposal.UpgradeName),
+		zap.Int64("height", proposal.UpgradeHeight),
+	)
+	go s.onUpgradePassed(proposal)
+}
+
+// LastScanTime returns when the given chain was last scanned successfully
+func (s *Scanner) LastScanTime(chainID string) (time.Time, bool) {
+	s.scanMu.RLock()
+	defer s.scanMu.RUnlock()
+
+	t, ok := s.lastScans[chainID]
+	return t, ok
 }
 
 // Start begins the scanning process for all configured chains
@@ -107,6 +277,10 @@ func (s *Scanner) Start(ctx context.Context) error {
 	ticker := time.NewTicker(s.config.Scanning.Interval)
 	defer ticker.Stop()
 
+	if s.config.Database.ArchiveAfter > 0 {
+		go s.archiveLoop(ctx)
+	}
+
 	// Initial scan
 	s.scanAllChains(ctx)
 
@@ -121,66 +295,96 @@ func (s *Scanner) Start(ctx context.Context) error {
 	}
 }
 
-// scanAllChains scans all configured chains for new proposals
-func (s *Scanner) scanAllChains(ctx context.Context) {
+// ScanOnce runs a single scan of every configured chain, returning an error naming the
+// chains that failed
+func (s *Scanner) ScanOnce(ctx context.Context) error {
+	failed := s.scanAllChains(ctx)
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("failed to scan %d of %d chains: %s", len(failed), len(s.config.Chains), strings.Join(failed, ", "))
+}
+
+// scanAllChains scans all configured chains for new proposals using a bounded worker pool
+// so a slow or failing chain does not hold up the others; it returns the chains that failed
+func (s *Scanner) scanAllChains(ctx context.Context) []string {
+	workers := s.config.Scanning.Concurrency
+	if workers <= 0 {
+		workers = defaultScanConcurrency
+	}
+	if workers > len(s.config.Chains) {
+		workers = len(s.config.Chains)
+	}
+
+	chains := make(chan config.ChainConfig)
+	var (
+		wg       sync.WaitGroup
+		failedMu sync.Mutex
+		failed   []string
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chain := range chains {
+				if err := s.scanChainAndRecord(ctx, chain); err != nil {
+					failedMu.Lock()
+					failed = append(failed, chain.GetName())
+					failedMu.Unlock()
+				}
+			}
+		}()
+	}
+
+feed:
 	for _, chain := range s.config.Chains {
 		select {
 		case <-ctx.Done():
-			return
-		default:
-			if err := s.scanChain(ctx, chain); err != nil {
-				s.logger.Error("Failed to scan chain",
-					zap.String("chain", chain.GetName()),
-					zap.Error(err),
-				)
-			}
+			break feed
+		case chains <- chain:
 		}
 	}
-}
+	close(chains)
 
-// scanChain scans a single chain for proposals
-func (s *Scanner) scanChain(ctx context.Context, chain config.ChainConfig) error {
-	s.logger.Debug("Scanning chain for proposals", zap.String("chain", chain.GetName()))
-
-	// Try both API versions and use the one with better data
-	var proposals []ProposalData
+	wg.Wait()
+	return failed
+}
 
-	// Try v1 first
-	proposalsV1, errV1 := s.tryFetchProposalsV1(ctx, chain)
+// scanChainAndRecord scans one chain under its own deadline and records the scan time on success
+func (s *Scanner) scanChainAndRecord(ctx context.Context, chain config.ChainConfig) error {
+	timeout := s.config.Scanning.ChainTimeout
+	if timeout <= 0 {
+		timeout = defaultChainScanTimeout
+	}
 
-	// Try v1beta1 second
-	proposalsV1Beta1, errV1Beta1 := s.tryFetchProposalsV1Beta1(ctx, chain)
+	chainCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	// If both failed, return error
-	if errV1 != nil && errV1Beta1 != nil {
-		return fmt.Errorf("both v1 and v1beta1 endpoints failed - v1: %v, v1beta1: %v", errV1, errV1Beta1)
+	err := s.scanChain(chainCtx, chain)
+	if s.health != nil {
+		s.health.RecordScan(chain.GetChainID(), time.Now(), err)
+	}
+	if err != nil {
+		s.logger.Error("Failed to scan chain",
+			zap.String("chain", chain.GetName()),
+			zap.Error(err),
+		)
+		return err
 	}
 
-	// Choose the best API response based on data completeness
-	if errV1 == nil && errV1Beta1 == nil {
-		// Both succeeded - pick the one with better title data
-		v1HasTitles := len(proposalsV1) > 0 && proposalsV1[0].Title != ""
-		v1Beta1HasTitles := len(proposalsV1Beta1) > 0 && proposalsV1Beta1[0].Title != ""
+	s.scanMu.Lock()
+	s.lastScans[chain.GetChainID()] = time.Now()
+	s.scanMu.Unlock()
+	return nil
+}
 
-		if v1HasTitles && !v1Beta1HasTitles {
-			proposals = proposalsV1
-			s.logger.Debug("Using v1 API (better metadata)", zap.String("chain", chain.GetName()))
-		} else if v1Beta1HasTitles && !v1HasTitles {
-			proposals = proposalsV1Beta1
-			s.logger.Debug("Using v1beta1 API (better metadata)", zap.String("chain", chain.GetName()))
-		} else {
-			// Both have titles or both don't - prefer v1
-			proposals = proposalsV1
-			s.logger.Debug("Using v1 API (default choice)", zap.String("chain", chain.GetName()))
-		}
-	} else if errV1 == nil {
-		// Only v1 succeeded
-		proposals = proposalsV1
-		s.logger.Debug("Using v1 API (v1beta1 failed)", zap.String("chain", chain.GetName()), zap.Error(errV1Beta1))
-	} else {
-		// Only v1beta1 succeeded
-		proposals = proposalsV1Beta1
-		s.logger.Debug("Using v1beta1 API (v1 failed)", zap.String("chain", chain.GetName()), zap.Error(errV1))
+// scanChain scans a single chain for proposals
+func (s *Scanner) scanChain(ctx context.Context, chain config.ChainConfig) error {
+	s.logger.Debug("Scanning chain for proposals", zap.String("chain", chain.GetName()))
+
+	proposals, err := s.fetchProposals(ctx, chain)
+	if err != nil {
+		return err
 	}
 
 	s.logger.Debug("Fetched proposals",
@@ -188,38 +392,143 @@ func (s *Scanner) scanChain(ctx context.Context, chain config.ChainConfig) error
 		zap.Int("proposal_count", len(proposals)),
 	)
 
+	s.populateProposers(ctx, chain, proposals)
+
 	return s.processProposals(chain, proposals)
 }
 
-// tryFetchProposalsV1Beta1 attempts to fetch proposals using the v1beta1 API
-func (s *Scanner) tryFetchProposalsV1Beta1(ctx context.Context, chain config.ChainConfig) ([]ProposalData, error) {
-	url := fmt.Sprintf("%s/cosmos/gov/v1beta1/proposals?pagination.limit=5&pagination.reverse=true", chain.REST)
-	if s.config.AuthEndpoints.Enabled && s.config.AuthEndpoints.APIKey != "" {
-		if strings.Contains(url, "?") {
-			url = url + "&api_key=" + s.config.AuthEndpoints.APIKey
-		} else {
-			url = url + "?api_key=" + s.config.AuthEndpoints.APIKey
-		}
+// proposalFetch is the outcome of querying one governance API version
+type proposalFetch struct {
+	api       string
+	proposals []ProposalData
+	err       error
+}
+
+// fetchProposals queries the v1 and v1beta1 APIs concurrently but always waits for v1, whose
+// responses carry fields v1beta1 lacks (expedited, proposer, metadata). v1beta1 is used only
+// when v1 fails or returns no titles; when neither carries titles, v1 is preferred
+func (s *Scanner) fetchProposals(ctx context.Context, chain config.ChainConfig) ([]ProposalData, error) {
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	v1Result, v1beta1Result := make(chan proposalFetch, 1), make(chan proposalFetch, 1)
+	go func() {
+		proposals, err := s.tryFetchProposalsV1(fetchCtx, chain)
+		v1Result <- proposalFetch{api: "v1", proposals: proposals, err: err}
+	}()
+	go func() {
+		proposals, err := s.tryFetchProposalsV1Beta1(fetchCtx, chain)
+		v1beta1Result <- proposalFetch{api: "v1beta1", proposals: proposals, err: err}
+	}()
+
+	v1 := <-v1Result
+	if v1.err == nil && hasTitles(v1.proposals) {
+		s.logger.Debug("Using proposals API", zap.String("chain", chain.GetName()), zap.String("api", v1.api))
+		return v1.proposals, nil
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	v1beta1 := <-v1beta1Result
+	if v1beta1.err == nil && hasTitles(v1beta1.proposals) {
+		s.logger.Debug("Using proposals API", zap.String("chain", chain.GetName()), zap.String("api", v1beta1.api))
+		return v1beta1.proposals, nil
 	}
 
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch proposals: %w", err)
+	for _, result := range []proposalFetch{v1, v1beta1} {
+		if result.err == nil {
+			s.logger.Debug("Using proposals API (no titles available)",
+				zap.String("chain", chain.GetName()),
+				zap.String("api", result.api),
+			)
+			return result.proposals, nil
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	return nil, fmt.Errorf("both v1 and v1beta1 endpoints failed - v1: %v, v1beta1: %v", v1.err, v1beta1.err)
+}
+
+// hasTitles reports whether an API response carries proposal titles
+func hasTitles(proposals []ProposalData) bool {
+	return len(proposals) > 0 && proposals[0].Title != ""
+}
+
+// fetchREST GETs path from the chain's REST endpoints in order, moving to the next
+// endpoint on connection errors or 5xx responses
+func (s *Scanner) fetchREST(ctx context.Context, chain config.ChainConfig, path string) ([]byte, error) {
+	endpoints := chain.GetRESTEndpoints()
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no REST endpoint configured")
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	var lastErr error
+	for idx, endpoint := range endpoints {
+		url := s.config.AuthEndpoints.AppendQuery(strings.TrimRight(endpoint, "/") + path)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("User-Agent", version.UserAgent())
+		s.config.AuthEndpoints.SetHeader(req.Header)
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			// A cancelled or expired scan applies to every endpoint, so stop failing over
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("failed to fetch %s: %w", path, ctx.Err())
+			}
+			lastErr = fmt.Errorf("failed to fetch %s: %w", path, err)
+			s.logger.Warn("REST endpoint unreachable, trying next",
+				zap.String("chain", chain.GetName()),
+				zap.String("endpoint", endpoint),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+			s.logger.Warn("REST endpoint returned server error, trying next",
+				zap.String("chain", chain.GetName()),
+				zap.String("endpoint", endpoint),
+				zap.Int("status", resp.StatusCode),
+			)
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		if idx > 0 {
+			s.logger.Info("Using fallback REST endpoint",
+				zap.String("chain", chain.GetName()),
+				zap.String("endpoint", endpoint),
+			)
+		} else {
+			s.logger.Debug("Using REST endpoint",
+				zap.String("chain", chain.GetName()),
+				zap.String("endpoint", endpoint),
+			)
+		}
+
+		return body, nil
+	}
+
+	return nil, lastErr
+}
+
+// tryFetchProposalsV1Beta1 attempts to fetch proposals using the v1beta1 API
+func (s *Scanner) tryFetchProposalsV1Beta1(ctx context.Context, chain config.ChainConfig) ([]ProposalData, error) {
+	body, err := s.fetchREST(ctx, chain, "/cosmos/gov/v1beta1/proposals?pagination.limit=5&pagination.reverse=true")
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
 	var govResp GovernanceResponseV1Beta1
@@ -235,6 +544,8 @@ func (s *Scanner) tryFetchProposalsV1Beta1(ctx context.Context, chain config.Cha
 			Title:            p.Content.Title,
 			Description:      p.Content.Description,
 			Status:           p.Status,
+			Type:             p.Content.Type,
+			Upgrade:          p.Content.Plan,
 			FinalTallyResult: p.FinalTallyResult,
 			SubmitTime:       p.SubmitTime,
 			DepositEndTime:   p.DepositEndTime,
@@ -249,33 +560,9 @@ func (s *Scanner) tryFetchProposalsV1Beta1(ctx context.Context, chain config.Cha
 
 // tryFetchProposalsV1 attempts to fetch proposals using the v1 API
 func (s *Scanner) tryFetchProposalsV1(ctx context.Context, chain config.ChainConfig) ([]ProposalData, error) {
-	url := fmt.Sprintf("%s/cosmos/gov/v1/proposals?pagination.limit=5&pagination.reverse=true", chain.REST)
-	if s.config.AuthEndpoints.Enabled && s.config.AuthEndpoints.APIKey != "" {
-		if strings.Contains(url, "?") {
-			url = url + "&api_key=" + s.config.AuthEndpoints.APIKey
-		} else {
-			url = url + "?api_key=" + s.config.AuthEndpoints.APIKey
-		}
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := s.client.Do(req)
+	body, err := s.fetchREST(ctx, chain, "/cosmos/gov/v1/proposals?pagination.limit=5&pagination.reverse=true")
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch proposals: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
 	var govResp GovernanceResponseV1
@@ -286,11 +573,17 @@ func (s *Scanner) tryFetchProposalsV1(ctx context.Context, chain config.ChainCon
 	// Convert v1 proposals to unified format
 	var proposals []ProposalData
 	for _, p := range govResp.Proposals {
+		typeURL, plan := proposalType(p.Messages)
 		proposals = append(proposals, ProposalData{
 			ProposalID:       p.ID,
 			Title:            p.Title,
 			Description:      p.Summary,
 			Status:           p.Status,
+			Proposer:         p.Proposer,
+			Expedited:        p.Expedited,
+			Type:             typeURL,
+			MessageTypes:     messageTypes(p.Messages),
+			Upgrade:          plan,
 			FinalTallyResult: p.FinalTallyResult,
 			SubmitTime:       p.SubmitTime,
 			DepositEndTime:   p.DepositEndTime,
@@ -303,8 +596,55 @@ func (s *Scanner) tryFetchProposalsV1(ctx context.Context, chain config.ChainCon
 	return proposals, nil
 }
 
+// populateProposers fills in missing proposer addresses for proposals not yet stored
+// Chains that don't expose the proposer endpoint simply leave the proposer blank
+func (s *Scanner) populateProposers(ctx context.Context, chain config.ChainConfig, proposals []ProposalData) {
+	for idx := range proposals {
+		if proposals[idx].Proposer != "" {
+			continue
+		}
+
+		var count int64
+		s.dbMu.Lock()
+		s.db.Model(&models.Proposal{}).Where("chain_id = ? AND proposal_id = ?", chain.GetChainID(), proposals[idx].ProposalID).Count(&count)
+		s.dbMu.Unlock()
+		if count > 0 {
+			continue
+		}
+
+		proposer, err := s.fetchProposer(ctx, chain, proposals[idx].ProposalID)
+		if err != nil {
+			s.logger.Debug("Proposer not available",
+				zap.String("chain", chain.GetName()),
+				zap.String("proposal_id", proposals[idx].ProposalID),
+				zap.Error(err),
+			)
+			continue
+		}
+		proposals[idx].Proposer = proposer
+	}
+}
+
+// fetchProposer queries the address that submitted a proposal
+func (s *Scanner) fetchProposer(ctx context.Context, chain config.ChainConfig, proposalID string) (string, error) {
+	body, err := s.fetchREST(ctx, chain, fmt.Sprintf("/cosmos/gov/v1/proposals/%s/proposer", proposalID))
+	if err != nil {
+		return "", err
+	}
+
+	var proposerResp ProposerResponse
+	if err := json.Unmarshal(body, &proposerResp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal proposer response: %w", err)
+	}
+
+	return proposerResp.Proposer, nil
+}
+
 // processProposals processes the proposals and stores new ones in the database
 func (s *Scanner) processProposals(chain config.ChainConfig, proposals []ProposalData) error {
+	s.dbMu.Lock()
+	defer s.dbMu.Unlock()
+
 	// Check if this is the first scan for this chain (no proposals exist yet)
 	var existingCount int64
 	s.db.Model(&models.Proposal{}).Where("chain_id = ?", chain.GetChainID()).Count(&existingCount)
@@ -331,7 +671,10 @@ func (s *Scanner) processProposals(chain config.ChainConfig, proposals []Proposa
 			// Check if proposal is actively voting to determine notification behavior
 			isActivelyVoting := strings.Contains(strings.ToUpper(proposal.Status), "VOTING")
 
-			if isFirstScan && !isActivelyVoting {
+			// Voting ended shortly before the first scan: still announce it as a catch-up
+			catchUp := isFirstScan && !isActivelyVoting && s.endedWithinLookback(newProposal)
+
+			if isFirstScan && !isActivelyVoting && !catchUp {
 				// Historical non-voting proposals: mark as already notified
 				newProposal.NotificationSent = true
 				s.logger.Debug("Historical proposal stored without notification",
@@ -340,17 +683,34 @@ func (s *Scanner) processProposals(chain config.ChainConfig, proposals []Proposa
 					zap.String("title", proposal.Title),
 					zap.String("status", proposal.Status),
 				)
+			} else if !chain.NotificationFilter.Allows(proposal.Title, proposal.Type) {
+				// Stored for voting and listing, but the chain's filter keeps it out of Discord
+				newProposal.NotificationSent = true
+				s.logger.Info("New proposal filtered from notifications",
+					zap.String("chain", chain.GetName()),
+					zap.String("proposal_id", proposal.ProposalID),
+					zap.String("title", proposal.Title),
+					zap.String("type", proposal.Type),
+				)
 			} else {
 				// New proposals OR actively voting proposals: queue notification
 				newProposal.NotificationSent = false
-				if isActivelyVoting {
+				switch {
+				case catchUp:
+					s.logger.Info("Recently ended proposal found on first scan - catch-up notification queued",
+						zap.String("chain", chain.GetName()),
+						zap.String("proposal_id", proposal.ProposalID),
+						zap.String("title", proposal.Title),
+						zap.String("status", proposal.Status),
+					)
+				case isActivelyVoting:
 					s.logger.Info("Active voting proposal found - notification queued",
 						zap.String("chain", chain.GetName()),
 						zap.String("proposal_id", proposal.ProposalID),
 						zap.String("title", proposal.Title),
 						zap.String("status", proposal.Status),
 					)
-				} else {
+				default:
 					s.logger.Info("New proposal found - notification queued",
 						zap.String("chain", chain.GetName()),
 						zap.String("proposal_id", proposal.ProposalID),
@@ -368,16 +728,59 @@ func (s *Scanner) processProposals(chain config.ChainConfig, proposals []Proposa
 				)
 				continue
 			}
+
+			// Upgrades that passed between scans (historical ones are ignored on the first scan)
+			if !isFirstScan {
+				s.upgradePassed(newProposal)
+			}
 		} else if result.Error == nil {
-			// Existing proposal, update if status changed
-			if existing.Status != proposal.Status {
+			// Existing proposal, update if status changed or proposer/type/final tally became known
+			updated := s.convertToModel(chain, proposal)
+			if existing.Status != proposal.Status ||
+				(existing.Proposer == "" && proposal.Proposer != "") ||
+				(existing.ProposalType == "" && proposal.Type != "") ||
+				(existing.MessageTypes == "" && updated.MessageTypes != "") ||
+				(!existing.HasFinalTally() && updated.HasFinalTally()) {
+				statusChanged := existing.Status != proposal.Status
+				// Announced proposals the filter still allows get a follow-up showing the transition
+				announced := existing.Announced && chain.NotificationFilter.Allows(proposal.Title, proposal.Type)
+				if statusChanged && announced && s.config.StatusChanges.Enabled {
+					existing.PreviousStatus = existing.Status
+					existing.StatusChangePending = true
+					s.logger.Info("Proposal status changed - notification queued",
+						zap.String("chain", chain.GetName()),
+						zap.String("proposal_id", proposal.ProposalID),
+						zap.String("from", existing.Status),
+						zap.String("to", proposal.Status),
+					)
+				}
 				existing.Status = proposal.Status
+				if proposal.Proposer != "" {
+					existing.Proposer = proposal.Proposer
+				}
+				if updated.HasFinalTally() {
+					existing.FinalTallyYes = updated.FinalTallyYes
+					existing.FinalTallyNo = updated.FinalTallyNo
+					existing.FinalTallyAbstain = updated.FinalTallyAbstain
+					existing.FinalTallyNoWithVeto = updated.FinalTallyNoWithVeto
+				}
+				if existing.ProposalType == "" {
+					existing.ProposalType = updated.ProposalType
+					existing.UpgradeName = updated.UpgradeName
+					existing.UpgradeHeight = updated.UpgradeHeight
+					existing.UpgradeInfo = updated.UpgradeInfo
+				}
+				if existing.MessageTypes == "" {
+					existing.MessageTypes = updated.MessageTypes
+				}
 				if err := s.db.Save(&existing).Error; err != nil {
 					s.logger.Error("Failed to update proposal",
 						zap.String("chain", chain.GetName()),
 						zap.String("proposal_id", proposal.ProposalID),
 						zap.Error(err),
 					)
+				} else if statusChanged {
+					s.upgradePassed(existing)
 				}
 			}
 		} else {
@@ -392,6 +795,24 @@ func (s *Scanner) processProposals(chain config.ChainConfig, proposals []Proposa
 	return nil
 }
 
+// endedWithinLookback reports whether the proposal's voting ended within scanning.first_scan_lookback
+func (s *Scanner) endedWithinLookback(proposal models.Proposal) bool {
+	lookback := s.config.Scanning.FirstScanLookback
+	if lookback <= 0 || proposal.VotingEnd == nil {
+		return false
+	}
+	return time.Since(*proposal.VotingEnd) <= lookback
+}
+
+// votingEnded reports whether a proposal status is final
+func votingEnded(status string) bool {
+	switch status {
+	case "PROPOSAL_STATUS_PASSED", "PROPOSAL_STATUS_REJECTED", "PROPOSAL_STATUS_FAILED":
+		return true
+	}
+	return false
+}
+
 // filterRelevantProposals filters proposals to focus on active and recent ones
 func (s *Scanner) filterRelevantProposals(proposals []ProposalData) []ProposalData {
 	var relevant []ProposalData
@@ -418,11 +839,29 @@ func (s *Scanner) filterRelevantProposals(proposals []ProposalData) []ProposalDa
 // convertToModel converts API proposal data to database model
 func (s *Scanner) convertToModel(chain config.ChainConfig, proposal ProposalData) models.Proposal {
 	model := models.Proposal{
-		ChainID:     chain.GetChainID(),
-		ProposalID:  proposal.ProposalID,
-		Title:       proposal.Title,
-		Description: proposal.Description,
-		Status:      proposal.Status,
+		ChainID:      chain.GetChainID(),
+		ProposalID:   proposal.ProposalID,
+		Title:        proposal.Title,
+		Description:  proposal.Description,
+		Status:       proposal.Status,
+		Proposer:     proposal.Proposer,
+		Expedited:    proposal.Expedited,
+		ProposalType: proposal.Type,
+		MessageTypes: strings.Join(proposal.MessageTypes, ","),
+	}
+
+	if proposal.Upgrade != nil {
+		model.UpgradeName = proposal.Upgrade.Name
+		model.UpgradeHeight, _ = strconv.ParseInt(proposal.Upgrade.Height, 10, 64)
+		model.UpgradeInfo = proposal.Upgrade.Info
+	}
+
+	// The final tally is only meaningful once voting has ended (v1 reports zeros until then)
+	if tally := proposal.FinalTallyResult; tally != nil && votingEnded(proposal.Status) {
+		model.FinalTallyYes = tally.Yes
+		model.FinalTallyNo = tally.No
+		model.FinalTallyAbstain = tally.Abstain
+		model.FinalTallyNoWithVeto = tally.NoWithVeto
 	}
 
 	// Parse voting times if available
@@ -3,13 +3,16 @@ package scanner
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"prop-voter/config"
+	"prop-voter/internal/health"
 	"prop-voter/internal/models"
 
 	"go.uber.org/zap/zaptest"
@@ -78,6 +81,59 @@ func TestNewScanner(t *testing.T) {
 	}
 }
 
+func TestNewScannerTimeout(t *testing.T) {
+	scanner := NewScanner(nil, &config.Config{}, zaptest.NewLogger(t))
+	if scanner.client.Timeout != 30*time.Second {
+		t.Errorf("Expected default timeout 30s, got %v", scanner.client.Timeout)
+	}
+
+	cfg := &config.Config{Scanning: config.ScanConfig{Timeout: 5 * time.Second}}
+	scanner = NewScanner(nil, cfg, zaptest.NewLogger(t))
+	if scanner.client.Timeout != 5*time.Second {
+		t.Errorf("Expected configured timeout 5s, got %v", scanner.client.Timeout)
+	}
+}
+
+func TestFetchRESTUserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	scanner, _ := setupTestScanner(t)
+	if _, err := scanner.fetchREST(context.Background(), config.ChainConfig{REST: server.URL}, "/cosmos/gov/v1/proposals"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(userAgent, "prop-voter/") {
+		t.Errorf("Expected prop-voter User-Agent, got '%s'", userAgent)
+	}
+}
+
+func TestFetchRESTAuthHeader(t *testing.T) {
+	var apiKey, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey, query = r.Header.Get("X-API-Key"), r.URL.RawQuery
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	scanner, _ := setupTestScanner(t)
+	scanner.config.AuthEndpoints = config.AuthEndpointsConfig{Enabled: true, APIKey: "secret-key", Style: config.AuthStyleHeader}
+	if _, err := scanner.fetchREST(context.Background(), config.ChainConfig{REST: server.URL}, "/cosmos/gov/v1/proposals"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if apiKey != "secret-key" {
+		t.Errorf("Expected the API key in the X-API-Key header, got '%s'", apiKey)
+	}
+	if strings.Contains(query, "secret-key") {
+		t.Errorf("Expected the API key to stay out of the query string, got '%s'", query)
+	}
+}
+
 func TestConvertToModel(t *testing.T) {
 	scanner, _ := setupTestScanner(t)
 
@@ -198,6 +254,202 @@ func TestProcessProposalsNewProposal(t *testing.T) {
 	}
 }
 
+func TestProcessProposalsNotificationFilter(t *testing.T) {
+	scanner, db := setupTestScanner(t)
+
+	chain := config.ChainConfig{
+		Name:    "Test Chain",
+		ChainID: "test-1",
+		NotificationFilter: config.NotificationFilter{
+			Exclude: []string{"test"},
+		},
+	}
+
+	proposals := []ProposalData{
+		{ProposalID: "1", Title: "Testnet parameter tweak", Status: "PROPOSAL_STATUS_VOTING_PERIOD"},
+		{ProposalID: "2", Title: "v12 Upgrade", Type: "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade", Status: "PROPOSAL_STATUS_VOTING_PERIOD"},
+	}
+
+	if err := scanner.processProposals(chain, proposals); err != nil {
+		t.Fatalf("Failed to process proposals: %v", err)
+	}
+
+	var stored []models.Proposal
+	db.Order("proposal_id").Find(&stored)
+	if len(stored) != 2 {
+		t.Fatalf("Expected filtered proposals to still be stored, got %d", len(stored))
+	}
+	if !stored[0].NotificationSent {
+		t.Error("Expected the excluded proposal not to be queued for notification")
+	}
+	if stored[1].NotificationSent {
+		t.Error("Expected the other proposal to be queued for notification")
+	}
+}
+
+func TestProcessProposalsFirstScanLookback(t *testing.T) {
+	scanner, db := setupTestScanner(t)
+	scanner.config.Scanning.FirstScanLookback = 48 * time.Hour
+
+	chain := config.ChainConfig{
+		Name:    "Test Chain",
+		ChainID: "test-1",
+	}
+
+	recent := time.Now().Add(-6 * time.Hour).UTC().Format(time.RFC3339)
+	old := time.Now().Add(-30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	proposals := []ProposalData{
+		{ProposalID: "1", Title: "Old spend", Status: "PROPOSAL_STATUS_PASSED", VotingEndTime: old},
+		{ProposalID: "2", Title: "Recent upgrade", Status: "PROPOSAL_STATUS_PASSED", VotingEndTime: recent},
+		{ProposalID: "3", Title: "Undated", Status: "PROPOSAL_STATUS_REJECTED"},
+	}
+
+	if err := scanner.processProposals(chain, proposals); err != nil {
+		t.Fatalf("Failed to process proposals: %v", err)
+	}
+
+	var stored []models.Proposal
+	db.Order("proposal_id").Find(&stored)
+	if len(stored) != 3 {
+		t.Fatalf("Expected 3 stored proposals, got %d", len(stored))
+	}
+	if !stored[0].NotificationSent {
+		t.Error("Expected a proposal that ended before the lookback to stay silent")
+	}
+	if stored[1].NotificationSent {
+		t.Error("Expected a proposal that ended within the lookback to get a catch-up notification")
+	}
+	if !stored[2].NotificationSent {
+		t.Error("Expected a proposal without a voting end to stay silent")
+	}
+
+	// The lookback only applies to the first scan
+	later := []ProposalData{{ProposalID: "4", Title: "Late arrival", Status: "PROPOSAL_STATUS_PASSED", VotingEndTime: old}}
+	if err := scanner.processProposals(chain, later); err != nil {
+		t.Fatalf("Failed to process proposals: %v", err)
+	}
+	var proposal models.Proposal
+	db.Where("proposal_id = ?", "4").First(&proposal)
+	if proposal.NotificationSent {
+		t.Error("Expected proposals found after the first scan to be queued as before")
+	}
+}
+
+func TestProcessProposalsStatusChange(t *testing.T) {
+	scanner, db := setupTestScanner(t)
+	scanner.config.StatusChanges.Enabled = true
+
+	chain := config.ChainConfig{
+		Name:               "Test Chain",
+		ChainID:            "test-1",
+		NotificationFilter: config.NotificationFilter{Exclude: []string{"Spam"}},
+	}
+
+	for _, p := range []models.Proposal{
+		{ChainID: "test-1", ProposalID: "1", Status: "PROPOSAL_STATUS_VOTING_PERIOD", NotificationSent: true, Announced: true},
+		{ChainID: "test-1", ProposalID: "2", Status: "PROPOSAL_STATUS_VOTING_PERIOD", NotificationSent: false},
+		{ChainID: "test-1", ProposalID: "3", Status: "PROPOSAL_STATUS_VOTING_PERIOD", NotificationSent: true, Announced: true},
+		{ChainID: "test-1", ProposalID: "4", Title: "Spam", Status: "PROPOSAL_STATUS_VOTING_PERIOD", NotificationSent: true, Announced: true},
+		{ChainID: "test-1", ProposalID: "5", Status: "PROPOSAL_STATUS_DEPOSIT_PERIOD", NotificationSent: true},
+	} {
+		db.Create(&p)
+	}
+
+	proposals := []ProposalData{
+		{ProposalID: "1", Status: "PROPOSAL_STATUS_PASSED"},
+		{ProposalID: "2", Status: "PROPOSAL_STATUS_REJECTED"},
+		{ProposalID: "3", Status: "PROPOSAL_STATUS_VOTING_PERIOD"},
+		{ProposalID: "4", Title: "Spam", Status: "PROPOSAL_STATUS_PASSED"},
+		{ProposalID: "5", Status: "PROPOSAL_STATUS_REJECTED"},
+	}
+	if err := scanner.processProposals(chain, proposals); err != nil {
+		t.Fatalf("Failed to process proposals: %v", err)
+	}
+
+	var stored []models.Proposal
+	db.Order("proposal_id").Find(&stored)
+	if !stored[0].StatusChangePending || stored[0].PreviousStatus != "PROPOSAL_STATUS_VOTING_PERIOD" {
+		t.Errorf("Expected the announced proposal's change to be queued, got pending=%v previous=%q",
+			stored[0].StatusChangePending, stored[0].PreviousStatus)
+	}
+	if stored[1].StatusChangePending {
+		t.Error("Expected no status change notification for a proposal never announced")
+	}
+	if stored[2].StatusChangePending {
+		t.Error("Expected no status change notification without a change")
+	}
+	if stored[3].StatusChangePending {
+		t.Error("Expected no status change notification for a proposal the filter hid")
+	}
+	if stored[4].StatusChangePending || stored[4].Status != "PROPOSAL_STATUS_REJECTED" {
+		t.Errorf("Expected a silent update for a proposal stored as history, got status %s pending=%v",
+			stored[4].Status, stored[4].StatusChangePending)
+	}
+
+	// Disabled: statuses still update silently
+	scanner.config.StatusChanges.Enabled = false
+	if err := scanner.processProposals(chain, []ProposalData{{ProposalID: "3", Status: "PROPOSAL_STATUS_REJECTED"}}); err != nil {
+		t.Fatalf("Failed to process proposals: %v", err)
+	}
+	var third models.Proposal
+	db.Where("proposal_id = ?", "3").First(&third)
+	if third.Status != "PROPOSAL_STATUS_REJECTED" || third.StatusChangePending {
+		t.Errorf("Expected a silent update when disabled, got status %s pending=%v", third.Status, third.StatusChangePending)
+	}
+}
+
+func TestTallyResultUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"v1", `{"yes_count":"100","no_count":"20","abstain_count":"3","no_with_veto_count":"4"}`},
+		{"v1beta1", `{"yes":"100","no":"20","abstain":"3","no_with_veto":"4"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tally TallyResult
+			if err := json.Unmarshal([]byte(tt.json), &tally); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tally != (TallyResult{Yes: "100", No: "20", Abstain: "3", NoWithVeto: "4"}) {
+				t.Errorf("Unexpected tally: %+v", tally)
+			}
+		})
+	}
+}
+
+func TestProcessProposalsFinalTally(t *testing.T) {
+	scanner, db := setupTestScanner(t)
+
+	chain := config.ChainConfig{
+		Name:    "Test Chain",
+		ChainID: "test-1",
+	}
+	zero := &TallyResult{Yes: "0", No: "0", Abstain: "0", NoWithVeto: "0"}
+	final := &TallyResult{Yes: "900", No: "50", Abstain: "25", NoWithVeto: "5"}
+
+	// v1 reports zeros while voting, which aren't stored as a final tally
+	voting := []ProposalData{{ProposalID: "1", Status: "PROPOSAL_STATUS_VOTING_PERIOD", FinalTallyResult: zero}}
+	if err := scanner.processProposals(chain, voting); err != nil {
+		t.Fatalf("Failed to process proposals: %v", err)
+	}
+	var stored models.Proposal
+	db.Where("proposal_id = ?", "1").First(&stored)
+	if stored.HasFinalTally() {
+		t.Errorf("Expected no final tally while voting, got %+v", stored)
+	}
+
+	ended := []ProposalData{{ProposalID: "1", Status: "PROPOSAL_STATUS_PASSED", FinalTallyResult: final}}
+	if err := scanner.processProposals(chain, ended); err != nil {
+		t.Fatalf("Failed to process proposals: %v", err)
+	}
+	db.Where("proposal_id = ?", "1").First(&stored)
+	if stored.FinalTallyYes != "900" || stored.FinalTallyNo != "50" || stored.FinalTallyAbstain != "25" || stored.FinalTallyNoWithVeto != "5" {
+		t.Errorf("Expected the final tally to be stored, got %+v", stored)
+	}
+}
+
 func TestProcessProposalsUpdateExisting(t *testing.T) {
 	scanner, db := setupTestScanner(t)
 
@@ -350,6 +602,248 @@ func TestScanChainHTTPServer(t *testing.T) {
 	}
 }
 
+func TestScanChainFetchesProposer(t *testing.T) {
+	testResponse := GovernanceResponseV1Beta1{
+		Proposals: []ProposalDataV1Beta1{
+			{
+				ProposalID: "789",
+				Content:    Content{Title: "Proposer Test Proposal"},
+				Status:     "PROPOSAL_STATUS_VOTING_PERIOD",
+			},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/gov/v1beta1/proposals":
+			json.NewEncoder(w).Encode(testResponse)
+		case "/cosmos/gov/v1/proposals/789/proposer":
+			json.NewEncoder(w).Encode(ProposerResponse{ProposalID: "789", Proposer: "test1proposeraddr"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	scanner, db := setupTestScanner(t)
+
+	chain := config.ChainConfig{
+		Name:    "Test Chain",
+		ChainID: "test-1",
+		REST:    server.URL,
+	}
+
+	if err := scanner.scanChain(context.Background(), chain); err != nil {
+		t.Fatalf("Failed to scan chain: %v", err)
+	}
+
+	var stored models.Proposal
+	if err := db.Where("chain_id = ? AND proposal_id = ?", "test-1", "789").First(&stored).Error; err != nil {
+		t.Fatalf("Failed to retrieve stored proposal: %v", err)
+	}
+
+	if stored.Proposer != "test1proposeraddr" {
+		t.Errorf("Expected proposer 'test1proposeraddr', got '%s'", stored.Proposer)
+	}
+}
+
+func TestScanChainRESTFailover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/gov/v1beta1/proposals" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(GovernanceResponseV1Beta1{
+			Proposals: []ProposalDataV1Beta1{
+				{
+					ProposalID: "321",
+					Content:    Content{Title: "Failover Proposal"},
+					Status:     "PROPOSAL_STATUS_VOTING_PERIOD",
+				},
+			},
+		})
+	}))
+	defer up.Close()
+
+	scanner, db := setupTestScanner(t)
+
+	chain := config.ChainConfig{
+		Name:          "Test Chain",
+		ChainID:       "test-1",
+		REST:          down.URL,
+		RESTFallbacks: []string{up.URL},
+	}
+
+	if err := scanner.scanChain(context.Background(), chain); err != nil {
+		t.Fatalf("Expected scan to fail over to the backup endpoint, got: %v", err)
+	}
+
+	var stored models.Proposal
+	if err := db.Where("chain_id = ? AND proposal_id = ?", "test-1", "321").First(&stored).Error; err != nil {
+		t.Fatalf("Failed to retrieve stored proposal: %v", err)
+	}
+}
+
+func TestScanChainV1Expedited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/gov/v1/proposals" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"proposals":[{"id":"55","title":"Fast Upgrade","status":"PROPOSAL_STATUS_VOTING_PERIOD","expedited":true}]}`))
+	}))
+	defer server.Close()
+
+	scanner, db := setupTestScanner(t)
+
+	chain := config.ChainConfig{
+		Name:    "Test Chain",
+		ChainID: "test-1",
+		REST:    server.URL,
+	}
+
+	if err := scanner.scanChain(context.Background(), chain); err != nil {
+		t.Fatalf("Failed to scan chain: %v", err)
+	}
+
+	var stored models.Proposal
+	if err := db.Where("chain_id = ? AND proposal_id = ?", "test-1", "55").First(&stored).Error; err != nil {
+		t.Fatalf("Failed to retrieve stored proposal: %v", err)
+	}
+
+	if !stored.Expedited {
+		t.Error("Expected proposal to be marked as expedited")
+	}
+}
+
+func TestScanChainProposalTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/gov/v1/proposals" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"proposals":[
+			{"id":"60","title":"v19 Upgrade","status":"PROPOSAL_STATUS_VOTING_PERIOD","messages":[{"@type":"/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade","authority":"cosmos10d07y265gmmuvt4z0w9aw880jnsr700j6zn9kn","plan":{"name":"v19","height":"20739800","info":""}}]},
+			{"id":"61","title":"Legacy Upgrade","status":"PROPOSAL_STATUS_VOTING_PERIOD","messages":[{"@type":"/cosmos.gov.v1.MsgExecLegacyContent","content":{"@type":"/cosmos.upgrade.v1beta1.SoftwareUpgradeProposal","title":"Legacy Upgrade","plan":{"name":"v18","height":"19000000"}}}]},
+			{"id":"62","title":"Signal","status":"PROPOSAL_STATUS_VOTING_PERIOD","messages":[]},
+			{"id":"63","title":"Params and Spend","status":"PROPOSAL_STATUS_VOTING_PERIOD","messages":[{"@type":"/cosmos.gov.v1.MsgUpdateParams"},{"@type":"/cosmos.distribution.v1beta1.MsgCommunityPoolSpend"}]}
+		]}`))
+	}))
+	defer server.Close()
+
+	scanner, db := setupTestScanner(t)
+
+	chain := config.ChainConfig{
+		Name:    "Test Chain",
+		ChainID: "test-1",
+		REST:    server.URL,
+	}
+
+	if err := scanner.scanChain(context.Background(), chain); err != nil {
+		t.Fatalf("Failed to scan chain: %v", err)
+	}
+
+	tests := []struct {
+		proposalID    string
+		proposalType  string
+		messageTypes  string
+		upgradeName   string
+		upgradeHeight int64
+	}{
+		{"60", "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade", "", "v19", 20739800},
+		{"61", "/cosmos.upgrade.v1beta1.SoftwareUpgradeProposal", "", "v18", 19000000},
+		{"62", textProposalType, "", "", 0},
+		{"63", "/cosmos.gov.v1.MsgUpdateParams", "/cosmos.gov.v1.MsgUpdateParams,/cosmos.distribution.v1beta1.MsgCommunityPoolSpend", "", 0},
+	}
+
+	for _, tt := range tests {
+		var stored models.Proposal
+		if err := db.Where("chain_id = ? AND proposal_id = ?", "test-1", tt.proposalID).First(&stored).Error; err != nil {
+			t.Fatalf("Failed to retrieve proposal %s: %v", tt.proposalID, err)
+		}
+		if stored.ProposalType != tt.proposalType {
+			t.Errorf("Proposal %s: expected type %q, got %q", tt.proposalID, tt.proposalType, stored.ProposalType)
+		}
+		if stored.MessageTypes != tt.messageTypes {
+			t.Errorf("Proposal %s: expected message types %q, got %q", tt.proposalID, tt.messageTypes, stored.MessageTypes)
+		}
+		if stored.UpgradeName != tt.upgradeName || stored.UpgradeHeight != tt.upgradeHeight {
+			t.Errorf("Proposal %s: expected upgrade %q at %d, got %q at %d",
+				tt.proposalID, tt.upgradeName, tt.upgradeHeight, stored.UpgradeName, stored.UpgradeHeight)
+		}
+	}
+}
+
+func TestProcessProposalsUpgradePassed(t *testing.T) {
+	scanner, db := setupTestScanner(t)
+
+	passed := make(chan models.Proposal, 1)
+	scanner.SetUpgradeHandler(func(proposal models.Proposal) {
+		passed <- proposal
+	})
+
+	chain := config.ChainConfig{
+		Name:    "Test Chain",
+		ChainID: "test-1",
+	}
+
+	db.Create(&models.Proposal{
+		ChainID:      "test-1",
+		ProposalID:   "60",
+		Title:        "v19 Upgrade",
+		Status:       "PROPOSAL_STATUS_VOTING_PERIOD",
+		ProposalType: "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade",
+		UpgradeName:  "v19",
+	})
+	db.Create(&models.Proposal{
+		ChainID:      "test-1",
+		ProposalID:   "61",
+		Title:        "Signal",
+		Status:       "PROPOSAL_STATUS_VOTING_PERIOD",
+		ProposalType: textProposalType,
+	})
+
+	proposals := []ProposalData{
+		{
+			ProposalID: "60",
+			Title:      "v19 Upgrade",
+			Status:     "PROPOSAL_STATUS_PASSED",
+			Type:       "/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade",
+			Upgrade:    &UpgradePlan{Name: "v19", Height: "20739800"},
+		},
+		{
+			ProposalID: "61",
+			Title:      "Signal",
+			Status:     "PROPOSAL_STATUS_PASSED",
+			Type:       textProposalType,
+		},
+	}
+
+	if err := scanner.processProposals(chain, proposals); err != nil {
+		t.Fatalf("Failed to process proposals: %v", err)
+	}
+
+	select {
+	case proposal := <-passed:
+		if proposal.ProposalID != "60" || proposal.UpgradeName != "v19" {
+			t.Errorf("Expected upgrade handler for proposal 60 (v19), got %s (%s)", proposal.ProposalID, proposal.UpgradeName)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected upgrade handler to be called for the passed upgrade proposal")
+	}
+
+	select {
+	case proposal := <-passed:
+		t.Errorf("Expected a single upgrade handler call, also got proposal %s", proposal.ProposalID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 func TestScanChainHTTPError(t *testing.T) {
 	scanner, _ := setupTestScanner(t)
 
@@ -366,6 +860,25 @@ func TestScanChainHTTPError(t *testing.T) {
 	}
 }
 
+func TestScanChainPublishesHealthStatus(t *testing.T) {
+	scanner, _ := setupTestScanner(t)
+	status := health.NewStatus()
+	scanner.SetHealthStatus(status)
+
+	chain := config.ChainConfig{Name: "Test Chain", ChainID: "test-1", REST: "http://non-existent-server:99999"}
+	if err := scanner.scanChainAndRecord(context.Background(), chain); err == nil {
+		t.Fatal("Expected error when scanning non-existent server")
+	}
+
+	recorded := status.Chains()["test-1"]
+	if recorded.LastError == "" || !recorded.Failing() || recorded.LastScan != nil {
+		t.Errorf("Expected the failed scan to be published, got %+v", recorded)
+	}
+	if status.ScanErrors() != 1 {
+		t.Errorf("Expected 1 scan error, got %d", status.ScanErrors())
+	}
+}
+
 func TestScanChainHTTP404(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
@@ -528,6 +1041,265 @@ func TestScanAllChains(t *testing.T) {
 	if chainProposals["chain-2"] != "Chain 2 Proposal" {
 		t.Errorf("Expected 'Chain 2 Proposal', got '%s'", chainProposals["chain-2"])
 	}
+
+	// Verify successful scans were recorded
+	for _, chainID := range []string{"chain-1", "chain-2"} {
+		if _, ok := scanner.LastScanTime(chainID); !ok {
+			t.Errorf("Expected last scan time to be recorded for %s", chainID)
+		}
+	}
+}
+
+func TestScanOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"proposals":[]}`))
+	}))
+	defer server.Close()
+
+	scanner, _ := setupTestScanner(t)
+	scanner.config.Chains = []config.ChainConfig{
+		{Name: "Healthy", ChainID: "healthy-1", REST: server.URL},
+	}
+
+	if err := scanner.ScanOnce(context.Background()); err != nil {
+		t.Fatalf("Expected all chains to scan, got: %v", err)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	scanner.config.Chains = append(scanner.config.Chains, config.ChainConfig{Name: "Broken", ChainID: "broken-1", REST: failing.URL})
+
+	err := scanner.ScanOnce(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 chains: Broken") {
+		t.Errorf("Expected the failing chain to be reported, got: %v", err)
+	}
+}
+
+func TestScanAllChainsConcurrent(t *testing.T) {
+	const numChains = 8
+	const concurrency = 3
+
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Paths look like /chain-N/cosmos/gov/...
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+		chainID := parts[0]
+
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		// One broken chain must not stop the others from being scanned
+		if chainID == "chain-0" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		if !strings.HasSuffix(r.URL.Path, "/cosmos/gov/v1/proposals") {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(GovernanceResponseV1{
+			Proposals: []ProposalDataV1{
+				{ID: "1", Title: chainID + " proposal", Status: "PROPOSAL_STATUS_VOTING_PERIOD"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Scanning: config.ScanConfig{
+			Interval:    1 * time.Minute,
+			Concurrency: concurrency,
+		},
+	}
+	for i := 0; i < numChains; i++ {
+		chainID := fmt.Sprintf("chain-%d", i)
+		cfg.Chains = append(cfg.Chains, config.ChainConfig{
+			Name:    chainID,
+			ChainID: chainID,
+			REST:    server.URL + "/" + chainID,
+		})
+	}
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	models.InitDB(db)
+
+	scanner := NewScanner(db, cfg, zaptest.NewLogger(t))
+	scanner.scanAllChains(context.Background())
+
+	var count int64
+	db.Model(&models.Proposal{}).Count(&count)
+	if count != numChains-1 {
+		t.Errorf("Expected %d proposals, got %d", numChains-1, count)
+	}
+
+	for i := 1; i < numChains; i++ {
+		chainID := fmt.Sprintf("chain-%d", i)
+		if _, ok := scanner.LastScanTime(chainID); !ok {
+			t.Errorf("Expected %s to be scanned", chainID)
+		}
+	}
+	if _, ok := scanner.LastScanTime("chain-0"); ok {
+		t.Error("Expected failing chain not to record a successful scan")
+	}
+
+	// Each chain queries v1 and v1beta1 in parallel
+	if max := atomic.LoadInt32(&maxInFlight); max > 2*concurrency {
+		t.Errorf("Expected at most %d concurrent requests, saw %d", 2*concurrency, max)
+	}
+}
+
+func TestScanChainPrefersV1(t *testing.T) {
+	// v1beta1 answers immediately; v1 answers later with the expedited flag
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/gov/v1/proposals":
+			time.Sleep(100 * time.Millisecond)
+			w.Write([]byte(`{"proposals":[{"id":"7","title":"Fast Proposal","status":"PROPOSAL_STATUS_VOTING_PERIOD","expedited":true}]}`))
+		case "/cosmos/gov/v1beta1/proposals":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(GovernanceResponseV1Beta1{
+				Proposals: []ProposalDataV1Beta1{
+					{ProposalID: "7", Content: Content{Title: "Fast Proposal"}, Status: "PROPOSAL_STATUS_VOTING_PERIOD"},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	scanner, db := setupTestScanner(t)
+	chain := config.ChainConfig{Name: "Test Chain", ChainID: "test-1", REST: server.URL}
+
+	if err := scanner.scanChain(context.Background(), chain); err != nil {
+		t.Fatalf("Failed to scan chain: %v", err)
+	}
+
+	var stored models.Proposal
+	if err := db.Where("chain_id = ? AND proposal_id = ?", "test-1", "7").First(&stored).Error; err != nil {
+		t.Fatalf("Failed to retrieve stored proposal: %v", err)
+	}
+	if stored.Title != "Fast Proposal" || !stored.Expedited {
+		t.Errorf("Expected the v1 answer with its expedited flag to win, got %+v", stored)
+	}
+}
+
+func TestScanChainV1Beta1Fallback(t *testing.T) {
+	// v1 fails, so the v1beta1 answer is used
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/gov/v1beta1/proposals" {
+			http.Error(w, "not implemented", http.StatusNotImplemented)
+			return
+		}
+		json.NewEncoder(w).Encode(GovernanceResponseV1Beta1{
+			Proposals: []ProposalDataV1Beta1{
+				{ProposalID: "8", Content: Content{Title: "Legacy Proposal"}, Status: "PROPOSAL_STATUS_VOTING_PERIOD"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	scanner, db := setupTestScanner(t)
+	chain := config.ChainConfig{Name: "Test Chain", ChainID: "test-1", REST: server.URL}
+
+	if err := scanner.scanChain(context.Background(), chain); err != nil {
+		t.Fatalf("Failed to scan chain: %v", err)
+	}
+
+	var stored models.Proposal
+	if err := db.Where("chain_id = ? AND proposal_id = ?", "test-1", "8").First(&stored).Error; err != nil {
+		t.Fatalf("Failed to retrieve stored proposal: %v", err)
+	}
+	if stored.Title != "Legacy Proposal" {
+		t.Errorf("Expected title 'Legacy Proposal', got '%s'", stored.Title)
+	}
+}
+
+func TestScanChainTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	scanner, _ := setupTestScanner(t)
+	scanner.config.Scanning.ChainTimeout = 100 * time.Millisecond
+	chain := config.ChainConfig{
+		Name:          "Hung Chain",
+		ChainID:       "hung-1",
+		REST:          server.URL,
+		RESTFallbacks: []string{server.URL + "/fallback"},
+	}
+
+	start := time.Now()
+	scanner.scanChainAndRecord(context.Background(), chain)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected scan to be cut off by the chain timeout, took %v", elapsed)
+	}
+
+	if _, ok := scanner.LastScanTime("hung-1"); ok {
+		t.Error("Expected timed out scan not to be recorded as successful")
+	}
+}
+
+func TestArchiveProposals(t *testing.T) {
+	scanner, db := setupTestScanner(t)
+
+	now := time.Now()
+	longAgo := now.Add(-200 * 24 * time.Hour)
+	recent := now.Add(-24 * time.Hour)
+	future := now.Add(24 * time.Hour)
+
+	db.Create(&models.Proposal{ChainID: "test-1", ProposalID: "1", Status: "PROPOSAL_STATUS_PASSED", VotingEnd: &longAgo})
+	db.Create(&models.Proposal{ChainID: "test-1", ProposalID: "2", Status: "PROPOSAL_STATUS_REJECTED", VotingEnd: &recent})
+	db.Create(&models.Proposal{ChainID: "test-1", ProposalID: "3", Status: "PROPOSAL_STATUS_VOTING_PERIOD", VotingEnd: &future})
+	db.Create(&models.Proposal{ChainID: "test-1", ProposalID: "4", Status: "PROPOSAL_STATUS_DEPOSIT_PERIOD"})
+	db.Create(&models.Vote{ChainID: "test-1", ProposalID: "1", Option: "yes", TxHash: "AAA"})
+
+	archived, err := scanner.archiveProposals(now.Add(-90 * 24 * time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to archive proposals: %v", err)
+	}
+	if archived != 1 {
+		t.Errorf("Expected 1 archived proposal, got %d", archived)
+	}
+
+	var active []models.Proposal
+	db.Scopes(models.NotArchived).Order("proposal_id").Find(&active)
+	if len(active) != 3 || active[0].ProposalID != "2" {
+		t.Errorf("Expected proposals 2-4 to stay active, got %d", len(active))
+	}
+
+	var voteCount int64
+	db.Model(&models.Vote{}).Where("chain_id = ? AND proposal_id = ?", "test-1", "1").Count(&voteCount)
+	if voteCount != 1 {
+		t.Error("Expected the archived proposal's vote to be preserved")
+	}
+
+	// Rescanning an archived proposal updates it in place instead of re-creating it
+	chain := config.ChainConfig{Name: "Test Chain", ChainID: "test-1"}
+	if err := scanner.processProposals(chain, []ProposalData{{ProposalID: "1", Status: "PROPOSAL_STATUS_PASSED"}}); err != nil {
+		t.Fatalf("Failed to process proposals: %v", err)
+	}
+	var count int64
+	db.Model(&models.Proposal{}).Where("chain_id = ? AND proposal_id = ?", "test-1", "1").Count(&count)
+	if count != 1 {
+		t.Errorf("Expected archived proposal not to be duplicated, got %d rows", count)
+	}
 }
 
 func TestStartAndStop(t *testing.T) {
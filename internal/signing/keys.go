@@ -0,0 +1,201 @@
+// Package signing builds, signs and encodes Cosmos SDK transactions natively, without
+// shelling out to a chain's CLI binary
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // Cosmos addresses are defined with RIPEMD-160
+)
+
+// EthereumCoinType is the coin type of chains using Ethereum-style eth_secp256k1 keys, whose
+// addresses and signatures native signing does not support
+const EthereumCoinType = 60
+
+// hardened marks a hardened BIP-32 child index
+const hardened = 0x80000000
+
+// PrivateKey is a secp256k1 account key derived from a mnemonic
+type PrivateKey struct {
+	key *secp256k1.PrivateKey
+}
+
+// DeriveKey derives the account key at m/44'/coinType'/0'/0/0 from a BIP-39 mnemonic (no
+// passphrase), matching `keys add --recover` with the default account and index. The words
+// and checksum are validated, so a mistyped mnemonic is rejected rather than deriving some
+// other account
+func DeriveKey(mnemonic string, coinType uint32) (*PrivateKey, error) {
+	words := strings.Fields(mnemonic)
+	if n := len(words); n < 12 || n > 24 || n%3 != 0 {
+		return nil, fmt.Errorf("mnemonic must have 12 to 24 words, got %d", n)
+	}
+
+	seed, err := bip39.NewSeedWithErrorChecking(strings.Join(words, " "), "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+
+	master := hmacSHA512([]byte("Bitcoin seed"), seed)
+	var key secp256k1.ModNScalar
+	if overflow := key.SetByteSlice(master[:32]); overflow || key.IsZero() {
+		return nil, fmt.Errorf("invalid master key")
+	}
+	chainCode := master[32:]
+
+	for _, index := range []uint32{44 | hardened, coinType | hardened, 0 | hardened, 0, 0} {
+		var err error
+		if chainCode, err = deriveChild(&key, chainCode, index); err != nil {
+			return nil, err
+		}
+	}
+
+	return &PrivateKey{key: secp256k1.NewPrivateKey(&key)}, nil
+}
+
+// deriveChild replaces key with its BIP-32 child at index and returns the child chain code
+func deriveChild(key *secp256k1.ModNScalar, chainCode []byte, index uint32) ([]byte, error) {
+	data := make([]byte, 0, 37)
+	if index >= hardened {
+		keyBytes := key.Bytes()
+		data = append(data, 0)
+		data = append(data, keyBytes[:]...)
+	} else {
+		data = append(data, secp256k1.NewPrivateKey(key).PubKey().SerializeCompressed()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	sum := hmacSHA512(chainCode, data)
+	var tweak secp256k1.ModNScalar
+	if overflow := tweak.SetByteSlice(sum[:32]); overflow {
+		return nil, fmt.Errorf("invalid child key at index %d", index)
+	}
+	key.Add(&tweak)
+	if key.IsZero() {
+		return nil, fmt.Errorf("invalid child key at index %d", index)
+	}
+	return sum[32:], nil
+}
+
+// hmacSHA512 returns HMAC-SHA512 of data under key
+func hmacSHA512(key, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// PubKeyBytes returns the 33-byte compressed public key
+func (k *PrivateKey) PubKeyBytes() []byte {
+	return k.key.PubKey().SerializeCompressed()
+}
+
+// Address returns the key's bech32 account address for a chain prefix
+func (k *PrivateKey) Address(prefix string) (string, error) {
+	sha := sha256.Sum256(k.PubKeyBytes())
+	hasher := ripemd160.New()
+	hasher.Write(sha[:])
+	return bech32Encode(prefix, hasher.Sum(nil))
+}
+
+// Sign returns the 64-byte r||s signature over the SHA-256 hash of msg, with a low S value
+// as the SDK requires
+func (k *PrivateKey) Sign(msg []byte) []byte {
+	hash := sha256.Sum256(msg)
+	// The compact form is a recovery byte followed by r and s
+	return ecdsa.SignCompact(k.key, hash[:], true)[1:]
+}
+
+// VerifySignature reports whether sig is a valid r||s signature of msg by the compressed
+// public key pubKey
+func VerifySignature(pubKey, msg, sig []byte) bool {
+	if len(sig) != 64 {
+		return false
+	}
+	key, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return false
+	}
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(sig[:32]) || s.SetByteSlice(sig[32:]) {
+		return false
+	}
+	hash := sha256.Sum256(msg)
+	return ecdsa.NewSignature(&r, &s).Verify(hash[:], key)
+}
+
+// bech32Charset is the bech32 data character set
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bech32Encode encodes data (8-bit bytes) as a bech32 string with the given prefix
+func bech32Encode(prefix string, data []byte) (string, error) {
+	if prefix == "" {
+		return "", fmt.Errorf("bech32 prefix is required")
+	}
+	prefix = strings.ToLower(prefix)
+
+	// Regroup 8-bit bytes into 5-bit words
+	var words []byte
+	var acc, bits uint32
+	for _, b := range data {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			words = append(words, byte(acc>>bits&31))
+		}
+	}
+	if bits > 0 {
+		words = append(words, byte(acc<<(5-bits)&31))
+	}
+
+	values := append(bech32PrefixExpand(prefix), words...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	polymod := bech32Polymod(values) ^ 1
+
+	var out strings.Builder
+	out.WriteString(prefix)
+	out.WriteByte('1')
+	for _, w := range words {
+		out.WriteByte(bech32Charset[w])
+	}
+	for i := 0; i < 6; i++ {
+		out.WriteByte(bech32Charset[(polymod>>(5*(5-i)))&31])
+	}
+	return out.String(), nil
+}
+
+// bech32PrefixExpand expands the human-readable prefix for checksum computation
+func bech32PrefixExpand(prefix string) []byte {
+	out := make([]byte, 0, len(prefix)*2+1)
+	for i := 0; i < len(prefix); i++ {
+		out = append(out, prefix[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(prefix); i++ {
+		out = append(out, prefix[i]&31)
+	}
+	return out
+}
+
+// bech32Polymod computes the bech32 checksum polynomial
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
@@ -0,0 +1,214 @@
+package signing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"prop-voter/config"
+)
+
+// testMnemonic is the well-known all-"abandon" BIP-39 test mnemonic
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestDeriveKeyAddress(t *testing.T) {
+	key, err := DeriveKey(testMnemonic, config.DefaultCoinType)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	address, err := key.Address("cosmos")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4"; address != want {
+		t.Errorf("Expected %s, got %s", want, address)
+	}
+
+	// Extra whitespace and line breaks in a stored mnemonic derive the same key
+	again, err := DeriveKey("  "+testMnemonic[:7]+"\n"+testMnemonic[8:]+"\n", config.DefaultCoinType)
+	if err != nil || !bytes.Equal(again.PubKeyBytes(), key.PubKeyBytes()) {
+		t.Errorf("Expected whitespace to be normalized, got err %v", err)
+	}
+
+	other, err := DeriveKey(testMnemonic, 330)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if bytes.Equal(other.PubKeyBytes(), key.PubKeyBytes()) {
+		t.Error("Expected a different coin type to derive a different key")
+	}
+}
+
+func TestDeriveKeyInvalidMnemonic(t *testing.T) {
+	for _, mnemonic := range []string{
+		"", "abandon abandon about", testMnemonic + " abandon",
+		// A word outside the BIP-39 wordlist
+		strings.Replace(testMnemonic, "about", "abuot", 1),
+		// Valid words with a bad checksum
+		strings.Replace(testMnemonic, "about", "abandon", 1),
+	} {
+		if _, err := DeriveKey(mnemonic, config.DefaultCoinType); err == nil {
+			t.Errorf("Expected %q to be rejected", mnemonic)
+		}
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	key, err := DeriveKey(testMnemonic, config.DefaultCoinType)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sig := key.Sign([]byte("sign doc"))
+	if len(sig) != 64 {
+		t.Fatalf("Expected a 64-byte signature, got %d bytes", len(sig))
+	}
+	if !VerifySignature(key.PubKeyBytes(), []byte("sign doc"), sig) {
+		t.Error("Expected the signature to verify")
+	}
+	if VerifySignature(key.PubKeyBytes(), []byte("other doc"), sig) {
+		t.Error("Expected the signature not to verify for another message")
+	}
+}
+
+func TestMsgVoteEncoding(t *testing.T) {
+	msg, err := MsgVote(MsgVoteV1Beta1TypeURL, 5, "cosmos1x", "VOTE_OPTION_NO_WITH_VETO")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []byte{0x08, 0x05, 0x12, 0x08, 'c', 'o', 's', 'm', 'o', 's', '1', 'x', 0x18, 0x04}
+	if !bytes.Equal(msg.Value, want) {
+		t.Errorf("Expected %x, got %x", want, msg.Value)
+	}
+
+	if _, err := MsgVote(MsgVoteV1TypeURL, 5, "cosmos1x", "VOTE_OPTION_UNSPECIFIED"); err == nil {
+		t.Error("Expected an unspecified option to be rejected")
+	}
+	if _, err := MsgVote("/cosmos.gov.v2.MsgVote", 5, "cosmos1x", "VOTE_OPTION_YES"); err == nil {
+		t.Error("Expected an unknown message type to be rejected")
+	}
+
+	exec := MsgExec("cosmos1grantee", msg)
+	fields := decodeFields(t, exec.Value)
+	if string(fields[1][0]) != "cosmos1grantee" || len(fields[2]) != 1 {
+		t.Fatalf("Unexpected MsgExec fields: %v", fields)
+	}
+	inner := decodeFields(t, fields[2][0])
+	if string(inner[1][0]) != MsgVoteV1Beta1TypeURL || !bytes.Equal(inner[2][0], want) {
+		t.Errorf("Expected the vote wrapped as an Any, got %v", inner)
+	}
+}
+
+func TestMsgDepositEncoding(t *testing.T) {
+	msg, err := MsgDeposit(MsgDepositV1TypeURL, 5, "cosmos1x", []Coin{{Denom: "uatom", Amount: "10"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	fields := decodeFields(t, msg.Value)
+	if binaryVarint(fields[1][0]) != 5 || string(fields[2][0]) != "cosmos1x" || len(fields[3]) != 1 {
+		t.Fatalf("Unexpected MsgDeposit fields: %v", fields)
+	}
+	coin := decodeFields(t, fields[3][0])
+	if string(coin[1][0]) != "uatom" || string(coin[2][0]) != "10" {
+		t.Errorf("Expected 10uatom, got %v", coin)
+	}
+
+	if _, err := MsgDeposit(MsgVoteV1TypeURL, 5, "cosmos1x", nil); err == nil {
+		t.Error("Expected a vote type URL to be rejected")
+	}
+}
+
+func TestMsgCancelProposalEncoding(t *testing.T) {
+	msg := MsgCancelProposal(5, "cosmos1x")
+	want := []byte{0x08, 0x05, 0x12, 0x08, 'c', 'o', 's', 'm', 'o', 's', '1', 'x'}
+	if msg.TypeURL != MsgCancelProposalTypeURL || !bytes.Equal(msg.Value, want) {
+		t.Errorf("Expected %x, got %s %x", want, msg.TypeURL, msg.Value)
+	}
+}
+
+func TestTxSign(t *testing.T) {
+	key, err := DeriveKey(testMnemonic, config.DefaultCoinType)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	msg, err := MsgVote(MsgVoteV1TypeURL, 12, "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4", "VOTE_OPTION_YES")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tx := Tx{
+		Messages:      []Any{msg},
+		Memo:          "via prop-voter",
+		Fee:           []Coin{{Denom: "uatom", Amount: "5000"}},
+		GasLimit:      200000,
+		ChainID:       "cosmoshub-4",
+		AccountNumber: 42,
+		Sequence:      7,
+	}
+	raw := decodeFields(t, tx.Sign(key))
+	if len(raw[1]) != 1 || len(raw[2]) != 1 || len(raw[3]) != 1 {
+		t.Fatalf("Expected body, auth info and one signature, got %v", raw)
+	}
+
+	body := decodeFields(t, raw[1][0])
+	if string(body[2][0]) != "via prop-voter" || len(body[1]) != 1 {
+		t.Errorf("Unexpected tx body: %v", body)
+	}
+
+	authInfo := decodeFields(t, raw[2][0])
+	signerInfo := decodeFields(t, authInfo[1][0])
+	if signerInfo[3] == nil || binaryVarint(signerInfo[3][0]) != 7 {
+		t.Errorf("Expected sequence 7 in the signer info, got %v", signerInfo[3])
+	}
+	fee := decodeFields(t, authInfo[2][0])
+	if binaryVarint(fee[2][0]) != 200000 {
+		t.Errorf("Expected gas limit 200000, got %v", fee[2])
+	}
+
+	// The signature covers the SignDoc rebuilt from the raw body and auth info bytes
+	var signDoc []byte
+	signDoc = appendBytesField(signDoc, 1, raw[1][0])
+	signDoc = appendBytesField(signDoc, 2, raw[2][0])
+	signDoc = appendStringField(signDoc, 3, "cosmoshub-4")
+	signDoc = appendVarintField(signDoc, 4, 42)
+	if !VerifySignature(key.PubKeyBytes(), signDoc, raw[3][0]) {
+		t.Error("Expected the signature to verify against the SignDoc")
+	}
+}
+
+// decodeFields splits a protobuf message into its fields; varint values are returned as
+// their encoded bytes
+func decodeFields(t *testing.T, b []byte) map[int][][]byte {
+	t.Helper()
+	fields := make(map[int][][]byte)
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			t.Fatalf("Invalid tag in %x", b)
+		}
+		b = b[n:]
+		field := int(tag >> 3)
+		switch tag & 7 {
+		case wireVarint:
+			_, n := binary.Uvarint(b)
+			fields[field] = append(fields[field], b[:n])
+			b = b[n:]
+		case wireBytes:
+			length, n := binary.Uvarint(b)
+			b = b[n:]
+			fields[field] = append(fields[field], b[:length])
+			b = b[length:]
+		default:
+			t.Fatalf("Unexpected wire type %d", tag&7)
+		}
+	}
+	return fields
+}
+
+// binaryVarint decodes an encoded varint
+func binaryVarint(b []byte) uint64 {
+	value, _ := binary.Uvarint(b)
+	return value
+}
@@ -0,0 +1,198 @@
+package signing
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Message type URLs built natively
+const (
+	MsgVoteV1TypeURL         = "/cosmos.gov.v1.MsgVote"
+	MsgVoteV1Beta1TypeURL    = "/cosmos.gov.v1beta1.MsgVote"
+	MsgDepositV1TypeURL      = "/cosmos.gov.v1.MsgDeposit"
+	MsgDepositV1Beta1TypeURL = "/cosmos.gov.v1beta1.MsgDeposit"
+	MsgCancelProposalTypeURL = "/cosmos.gov.v1.MsgCancelProposal"
+	MsgExecTypeURL           = "/cosmos.authz.v1beta1.MsgExec"
+	pubKeyTypeURL            = "/cosmos.crypto.secp256k1.PubKey"
+)
+
+// signModeDirect is SIGN_MODE_DIRECT: the signature covers the protobuf SignDoc
+const signModeDirect = 1
+
+// voteOptions maps gov VoteOption names to their enum values, shared by gov v1 and v1beta1
+var voteOptions = map[string]uint64{
+	"VOTE_OPTION_YES":          1,
+	"VOTE_OPTION_ABSTAIN":      2,
+	"VOTE_OPTION_NO":           3,
+	"VOTE_OPTION_NO_WITH_VETO": 4,
+}
+
+// Any is a protobuf Any: an encoded message and its type URL
+type Any struct {
+	TypeURL string
+	Value   []byte
+}
+
+// MsgVote encodes a gov MsgVote of the given type URL (v1 or v1beta1); option is a
+// VOTE_OPTION_* name
+func MsgVote(typeURL string, proposalID uint64, voter, option string) (Any, error) {
+	if typeURL != MsgVoteV1TypeURL && typeURL != MsgVoteV1Beta1TypeURL {
+		return Any{}, fmt.Errorf("unsupported vote message type %q", typeURL)
+	}
+	value, ok := voteOptions[option]
+	if !ok {
+		return Any{}, fmt.Errorf("unsupported vote option %q", option)
+	}
+
+	var msg []byte
+	msg = appendVarintField(msg, 1, proposalID)
+	msg = appendStringField(msg, 2, voter)
+	msg = appendVarintField(msg, 3, value)
+	// gov v1 adds an optional metadata string (field 4), left empty
+	return Any{TypeURL: typeURL, Value: msg}, nil
+}
+
+// MsgDeposit encodes a gov MsgDeposit of the given type URL (v1 or v1beta1), which share
+// one layout
+func MsgDeposit(typeURL string, proposalID uint64, depositor string, amount []Coin) (Any, error) {
+	if typeURL != MsgDepositV1TypeURL && typeURL != MsgDepositV1Beta1TypeURL {
+		return Any{}, fmt.Errorf("unsupported deposit message type %q", typeURL)
+	}
+
+	var msg []byte
+	msg = appendVarintField(msg, 1, proposalID)
+	msg = appendStringField(msg, 2, depositor)
+	for _, coin := range amount {
+		msg = appendBytesField(msg, 3, coin.encode())
+	}
+	return Any{TypeURL: typeURL, Value: msg}, nil
+}
+
+// MsgCancelProposal encodes a gov v1 MsgCancelProposal (Cosmos SDK v0.50+) sent by the
+// proposal's proposer
+func MsgCancelProposal(proposalID uint64, proposer string) Any {
+	var msg []byte
+	msg = appendVarintField(msg, 1, proposalID)
+	msg = appendStringField(msg, 2, proposer)
+	return Any{TypeURL: MsgCancelProposalTypeURL, Value: msg}
+}
+
+// MsgExec wraps msgs in an authz MsgExec sent by grantee
+func MsgExec(grantee string, msgs ...Any) Any {
+	var msg []byte
+	msg = appendStringField(msg, 1, grantee)
+	for _, inner := range msgs {
+		msg = appendBytesField(msg, 2, inner.encode())
+	}
+	return Any{TypeURL: MsgExecTypeURL, Value: msg}
+}
+
+// Coin is an amount of one denom
+type Coin struct {
+	Denom  string
+	Amount string
+}
+
+// encode serializes the coin
+func (c Coin) encode() []byte {
+	var out []byte
+	out = appendStringField(out, 1, c.Denom)
+	return appendStringField(out, 2, c.Amount)
+}
+
+// Tx is an unsigned single-signer transaction
+type Tx struct {
+	Messages      []Any
+	Memo          string
+	Fee           []Coin
+	GasLimit      uint64
+	ChainID       string
+	AccountNumber uint64
+	Sequence      uint64
+}
+
+// Sign signs the tx with SIGN_MODE_DIRECT and returns the encoded TxRaw bytes ready to broadcast
+func (t *Tx) Sign(key *PrivateKey) []byte {
+	body := t.bodyBytes()
+	authInfo := t.authInfoBytes(key.PubKeyBytes())
+
+	var signDoc []byte
+	signDoc = appendBytesField(signDoc, 1, body)
+	signDoc = appendBytesField(signDoc, 2, authInfo)
+	signDoc = appendStringField(signDoc, 3, t.ChainID)
+	signDoc = appendVarintField(signDoc, 4, t.AccountNumber)
+
+	var raw []byte
+	raw = appendBytesField(raw, 1, body)
+	raw = appendBytesField(raw, 2, authInfo)
+	raw = appendBytesField(raw, 3, key.Sign(signDoc))
+	return raw
+}
+
+// bodyBytes encodes the TxBody
+func (t *Tx) bodyBytes() []byte {
+	var body []byte
+	for _, msg := range t.Messages {
+		body = appendBytesField(body, 1, msg.encode())
+	}
+	return appendStringField(body, 2, t.Memo)
+}
+
+// authInfoBytes encodes the AuthInfo for a single secp256k1 signer
+func (t *Tx) authInfoBytes(pubKey []byte) []byte {
+	pubKeyAny := Any{TypeURL: pubKeyTypeURL, Value: appendBytesField(nil, 1, pubKey)}
+
+	var single, modeInfo, signerInfo []byte
+	single = appendVarintField(single, 1, signModeDirect)
+	modeInfo = appendBytesField(modeInfo, 1, single)
+	signerInfo = appendBytesField(signerInfo, 1, pubKeyAny.encode())
+	signerInfo = appendBytesField(signerInfo, 2, modeInfo)
+	signerInfo = appendVarintField(signerInfo, 3, t.Sequence)
+
+	var fee []byte
+	for _, coin := range t.Fee {
+		fee = appendBytesField(fee, 1, coin.encode())
+	}
+	fee = appendVarintField(fee, 2, t.GasLimit)
+
+	var authInfo []byte
+	authInfo = appendBytesField(authInfo, 1, signerInfo)
+	return appendBytesField(authInfo, 2, fee)
+}
+
+// encode serializes the Any itself
+func (a Any) encode() []byte {
+	var out []byte
+	out = appendStringField(out, 1, a.TypeURL)
+	return appendBytesField(out, 2, a.Value)
+}
+
+// Protobuf wire types used by the SDK messages above
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+// appendVarintField appends a varint field, omitting zero values as proto3 does
+func appendVarintField(b []byte, field int, value uint64) []byte {
+	if value == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(field)<<3|wireVarint)
+	return binary.AppendUvarint(b, value)
+}
+
+// appendStringField appends a string field, omitting empty strings as proto3 does
+func appendStringField(b []byte, field int, value string) []byte {
+	return appendBytesField(b, field, []byte(value))
+}
+
+// appendBytesField appends a length-delimited field, omitting empty values as proto3 does
+func appendBytesField(b []byte, field int, value []byte) []byte {
+	if len(value) == 0 {
+		return b
+	}
+	b = binary.AppendUvarint(b, uint64(field)<<3|wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
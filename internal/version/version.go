@@ -0,0 +1,19 @@
+package version
+
+// Build details, set at build time via -ldflags, e.g.
+// -ldflags "-X prop-voter/internal/version.Version=<version> -X prop-voter/internal/version.Commit=<sha>"
+var (
+	Version   = "dev"     // Release version
+	Commit    = "unknown" // Git commit the binary was built from
+	BuildDate = "unknown" // Build time, RFC 3339 in UTC
+)
+
+// UserAgent returns the User-Agent header sent on outgoing HTTP requests
+func UserAgent() string {
+	return "prop-voter/" + Version
+}
+
+// String summarises the build details on one line
+func String() string {
+	return Version + " (commit " + Commit + ", built " + BuildDate + ")"
+}
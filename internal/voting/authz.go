@@ -0,0 +1,247 @@
+package voting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"prop-voter/config"
+
+	"go.uber.org/zap"
+)
+
+// Gov vote message types an authz grant can cover
+const (
+	MsgVoteV1TypeURL      = "/cosmos.gov.v1.MsgVote"
+	MsgVoteV1Beta1TypeURL = "/cosmos.gov.v1beta1.MsgVote"
+)
+
+// genericAuthorizationType is the only authorization type that can cover MsgVote
+const genericAuthorizationType = "/cosmos.authz.v1beta1.GenericAuthorization"
+
+// AuthzGrant is a gov vote grant from the configured granter to the bot's wallet
+type AuthzGrant struct {
+	MsgTypeURL string
+	Expiration *time.Time // Nil when the grant never expires
+}
+
+// Expired reports whether the grant had lapsed at now
+func (g AuthzGrant) Expired(now time.Time) bool {
+	return g.Expiration != nil && !g.Expiration.After(now)
+}
+
+// AuthzGrantStatus describes the gov vote grants the bot's wallet holds on a chain
+type AuthzGrantStatus struct {
+	Granter string
+	Grantee string
+	Grants  []AuthzGrant // Only grants covering a gov MsgVote
+
+	// MsgTypeURL is the MsgVote type authz votes are sent as: authz.msg_version when set,
+	// otherwise the type of the unexpired grants. Empty when both types (or neither) are
+	// granted, in which case each vote probes the proposal's gov API version
+	MsgTypeURL string
+}
+
+// ActiveGrant returns the unexpired vote grant that lasts longest, or nil when there is none;
+// a grant without an expiration always wins. When MsgTypeURL is set only grants covering it
+// count, since a grant for the other message type would not authorize the vote
+func (s *AuthzGrantStatus) ActiveGrant(now time.Time) *AuthzGrant {
+	var best *AuthzGrant
+	for i := range s.Grants {
+		grant := &s.Grants[i]
+		if grant.Expired(now) || (s.MsgTypeURL != "" && grant.MsgTypeURL != s.MsgTypeURL) {
+			continue
+		}
+		if best == nil || grant.Expiration == nil ||
+			(best.Expiration != nil && grant.Expiration.After(*best.Expiration)) {
+			best = grant
+		}
+		if best.Expiration == nil {
+			break
+		}
+	}
+	return best
+}
+
+// QueryAuthzGrants looks up the gov vote grants from one of the chain's configured granters
+// (an address or name; empty for the default granter) to the bot's voting wallet
+func (v *Voter) QueryAuthzGrants(chainID, granter string) (*AuthzGrantStatus, error) {
+	chainConfig := v.chains[chainID]
+
+	if chainConfig == nil {
+		return nil, fmt.Errorf("chain %s not found in configuration", chainID)
+	}
+
+	if !chainConfig.IsAuthzEnabled() {
+		return nil, fmt.Errorf("authz is not enabled for chain %s", chainID)
+	}
+
+	granterAddr, err := resolveGranter(chainConfig, granter)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	grantee, err := v.getAddressForKey(ctx, chainConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, err := v.fetchVoteGrants(ctx, chainConfig, granterAddr, grantee)
+	if err != nil {
+		return nil, err
+	}
+
+	msgType := configuredVoteMsgType(chainConfig)
+	if msgType == "" {
+		msgType = grantedVoteMsgType(grants, time.Now())
+	}
+
+	return &AuthzGrantStatus{
+		Granter:    granterAddr,
+		Grantee:    grantee,
+		Grants:     grants,
+		MsgTypeURL: msgType,
+	}, nil
+}
+
+// grantedVoteMsgType returns the MsgVote type the unexpired grants cover, or "" when they
+// cover both types or there are none
+func grantedVoteMsgType(grants []AuthzGrant, now time.Time) string {
+	var msgType string
+	for _, grant := range grants {
+		if grant.Expired(now) {
+			continue
+		}
+		if msgType != "" && grant.MsgTypeURL != msgType {
+			return ""
+		}
+		msgType = grant.MsgTypeURL
+	}
+	return msgType
+}
+
+// fetchVoteGrants queries every grant between granter and grantee and keeps those that cover
+// a gov MsgVote. The msg_type_url filter is not used because some chains answer it with an
+// error rather than an empty list when nothing matches
+func (v *Voter) fetchVoteGrants(ctx context.Context, chain *config.ChainConfig, granter, grantee string) ([]AuthzGrant, error) {
+	type grantsResponse struct {
+		Grants []struct {
+			Authorization struct {
+				Type string `json:"@type"`
+				Msg  string `json:"msg"`
+			} `json:"authorization"`
+			Expiration *time.Time `json:"expiration"`
+		} `json:"grants"`
+	}
+
+	query := url.Values{}
+	query.Set("granter", granter)
+	query.Set("grantee", grantee)
+
+	statusCode, body, err := v.getREST(ctx, chain, "/cosmos/authz/v1beta1/grants?"+query.Encode(), "Querying authz grants")
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("authz grants query failed: status %d - body: %s", statusCode, string(body))
+	}
+
+	var gr grantsResponse
+	if err := json.Unmarshal(body, &gr); err != nil {
+		return nil, fmt.Errorf("failed to parse authz grants response: %w - body: %s", err, string(body))
+	}
+
+	var grants []AuthzGrant
+	for _, grant := range gr.Grants {
+		if grant.Authorization.Type != genericAuthorizationType {
+			continue
+		}
+		if grant.Authorization.Msg != MsgVoteV1TypeURL && grant.Authorization.Msg != MsgVoteV1Beta1TypeURL {
+			continue
+		}
+		grants = append(grants, AuthzGrant{
+			MsgTypeURL: grant.Authorization.Msg,
+			Expiration: grant.Expiration,
+		})
+	}
+	return grants, nil
+}
+
+// authzVoteMsgType returns the MsgVote type URL to wrap in authz exec when voting as granter
+// from grantee: the configured authz.msg_version, otherwise the type granter's unexpired
+// grants cover (a v1 chain still only accepts the v1beta1 type from a legacy grant). When both
+// or neither are granted, or the grants can't be read, it is v1 if the chain serves the gov v1
+// API for the proposal (like the tally query, v1 is tried first) and v1beta1 if it does not.
+// An empty grantee skips the grant lookup
+func (v *Voter) authzVoteMsgType(ctx context.Context, chain *config.ChainConfig, proposalID, granter, grantee string) string {
+	if msgType := configuredVoteMsgType(chain); msgType != "" {
+		return msgType
+	}
+
+	if grantee != "" {
+		grants, err := v.fetchVoteGrants(ctx, chain, granter, grantee)
+		if err != nil {
+			v.logger.Debug("Could not read authz grants, probing gov API version",
+				zap.String("chain", chain.GetName()),
+				zap.String("granter", granter),
+				zap.Error(err),
+			)
+		} else if msgType := grantedVoteMsgType(grants, time.Now()); msgType != "" {
+			return msgType
+		}
+	}
+	return v.probeVoteMsgType(ctx, chain, proposalID)
+}
+
+// configuredVoteMsgType returns the MsgVote type pinned by authz.msg_version, or ""
+func configuredVoteMsgType(chain *config.ChainConfig) string {
+	switch chain.Authz.MsgVersion {
+	case config.GovVersionV1:
+		return MsgVoteV1TypeURL
+	case config.GovVersionV1Beta1:
+		return MsgVoteV1Beta1TypeURL
+	}
+	return ""
+}
+
+// probeVoteMsgType returns the v1 MsgVote type URL when the chain serves the gov v1 API for
+// the proposal, and v1beta1 when it does not
+func (v *Voter) probeVoteMsgType(ctx context.Context, chain *config.ChainConfig, proposalID string) string {
+	statusCode, _, err := v.getREST(ctx, chain, "/cosmos/gov/v1/proposals/"+proposalID, "Probing gov v1 API")
+	if err != nil || statusCode != http.StatusOK {
+		v.logger.Debug("Gov v1 API unavailable, using v1beta1 MsgVote",
+			zap.String("chain", chain.GetName()),
+			zap.Int("status", statusCode),
+			zap.Error(err),
+		)
+		return MsgVoteV1Beta1TypeURL
+	}
+	return MsgVoteV1TypeURL
+}
+
+// authzVoteMsg builds the authz exec message file voting option on proposalID for voter;
+// gov v1 adds a metadata field, the option enum is shared by both versions
+func (v *Voter) authzVoteMsg(msgType, proposalID, voter, option string) ([]byte, error) {
+	msg := map[string]string{
+		"@type":       msgType,
+		"proposal_id": proposalID,
+		"voter":       voter,
+		"option":      v.mapVoteOption(option),
+	}
+	if msgType == MsgVoteV1TypeURL {
+		msg["metadata"] = ""
+	}
+
+	body := map[string]interface{}{
+		"body": map[string]interface{}{
+			"messages": []map[string]string{msg},
+		},
+	}
+	return json.MarshalIndent(body, "", "  ")
+}
@@ -0,0 +1,209 @@
+package voting
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"prop-voter/config"
+	"prop-voter/internal/models"
+	"prop-voter/internal/signing"
+	"prop-voter/internal/wallet"
+
+	"go.uber.org/zap"
+)
+
+// walletStore holds the encrypted mnemonics read by native signing
+type walletStore interface {
+	GetWallet(chainID string) (*models.WalletInfo, string, error)
+}
+
+// SetWalletManager sets the wallet store native signing reads each chain's mnemonic from
+func (v *Voter) SetWalletManager(manager *wallet.Manager) {
+	v.wallets = manager
+}
+
+// nativeKey derives the chain's account key from its stored mnemonic and returns it with its
+// address, refusing a key whose address differs from the one recorded at import
+func (v *Voter) nativeKey(chain *config.ChainConfig) (*signing.PrivateKey, string, error) {
+	if chain.GetCoinType() == signing.EthereumCoinType {
+		return nil, "", fmt.Errorf("native signing does not support Ethereum-style keys (coin type %d) on %s; use signing_mode cli", signing.EthereumCoinType, chain.GetName())
+	}
+	if v.wallets == nil {
+		return nil, "", fmt.Errorf("native signing is enabled for %s but no wallet store is configured", chain.GetName())
+	}
+
+	info, mnemonic, err := v.wallets.GetWallet(chain.GetChainID())
+	if err != nil {
+		return nil, "", fmt.Errorf("native signing needs the key's mnemonic in the wallet store (import it with `-key import`): %w", err)
+	}
+
+	key, err := signing.DeriveKey(mnemonic, chain.GetCoinType())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to derive key for %s: %w", chain.GetName(), err)
+	}
+	address, err := key.Address(chain.GetPrefix())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to derive address for %s: %w", chain.GetName(), err)
+	}
+	if info.Address != "" && info.Address != address {
+		return nil, "", fmt.Errorf("derived address %s does not match the stored wallet address %s; check coin_type for %s", address, info.Address, chain.GetName())
+	}
+	return key, address, nil
+}
+
+// nativeSignGovVote builds and signs a gov vote tx in-process and returns the base64 tx bytes
+func (v *Voter) nativeSignGovVote(ctx context.Context, chain *config.ChainConfig, proposalID, option string, opts TxOptions) (string, error) {
+	key, address, err := v.nativeKey(chain)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := strconv.ParseUint(proposalID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid proposal ID %q", proposalID)
+	}
+	msg, err := signing.MsgVote(v.probeVoteMsgType(ctx, chain, proposalID), id, address, v.mapVoteOption(option))
+	if err != nil {
+		return "", err
+	}
+
+	return v.nativeSignTx(ctx, chain, key, address, msg, voteMemo(chain, proposalID, option, opts), opts)
+}
+
+// nativeSignAuthzVote builds and signs an authz exec vote tx in-process and returns the base64 tx bytes
+func (v *Voter) nativeSignAuthzVote(ctx context.Context, chain *config.ChainConfig, proposalID, option, granter string, opts TxOptions) (string, error) {
+	key, address, err := v.nativeKey(chain)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := strconv.ParseUint(proposalID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid proposal ID %q", proposalID)
+	}
+	vote, err := signing.MsgVote(v.authzVoteMsgType(ctx, chain, proposalID, granter, address), id, granter, v.mapVoteOption(option))
+	if err != nil {
+		return "", err
+	}
+
+	return v.nativeSignTx(ctx, chain, key, address, signing.MsgExec(address, vote), voteMemo(chain, proposalID, option, opts), opts)
+}
+
+// nativeSignDeposit builds and signs a gov deposit tx in-process and returns the base64 tx bytes
+func (v *Voter) nativeSignDeposit(ctx context.Context, chain *config.ChainConfig, proposalID, amount string, opts TxOptions) (string, error) {
+	key, address, err := v.nativeKey(chain)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := strconv.ParseUint(proposalID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid proposal ID %q", proposalID)
+	}
+	value, denom, err := parseCoin(amount)
+	if err != nil {
+		return "", err
+	}
+
+	// gov v1 and v1beta1 deposits share one layout; follow the version the chain votes with
+	msgType := signing.MsgDepositV1Beta1TypeURL
+	if v.probeVoteMsgType(ctx, chain, proposalID) == MsgVoteV1TypeURL {
+		msgType = signing.MsgDepositV1TypeURL
+	}
+	msg, err := signing.MsgDeposit(msgType, id, address, []signing.Coin{{Denom: denom, Amount: value.String()}})
+	if err != nil {
+		return "", err
+	}
+
+	return v.nativeSignTx(ctx, chain, key, address, msg, "", opts)
+}
+
+// nativeSignCancelProposal builds and signs a gov v1 cancel-proposal tx in-process and returns
+// the base64 tx bytes
+func (v *Voter) nativeSignCancelProposal(ctx context.Context, chain *config.ChainConfig, proposalID string, opts TxOptions) (string, error) {
+	key, address, err := v.nativeKey(chain)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := strconv.ParseUint(proposalID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid proposal ID %q", proposalID)
+	}
+
+	return v.nativeSignTx(ctx, chain, key, address, signing.MsgCancelProposal(id, address), "", opts)
+}
+
+// nativeSignTx signs msg from address with the account's number and sequence (or the
+// sequence override), simulating for the gas limit when the chain and vote set none
+func (v *Voter) nativeSignTx(ctx context.Context, chain *config.ChainConfig, key *signing.PrivateKey, address string, msg signing.Any, memo string, opts TxOptions) (string, error) {
+	if err := v.checkFeeBalance(ctx, chain, address); err != nil {
+		return "", err
+	}
+
+	accountNumber, sequence, err := v.queryAccount(ctx, chain, address)
+	if err != nil {
+		return "", fmt.Errorf("failed to query account: %w", err)
+	}
+	if opts.sequence != "" {
+		if sequence, err = strconv.ParseUint(opts.sequence, 10, 64); err != nil {
+			return "", fmt.Errorf("invalid sequence %q", opts.sequence)
+		}
+	}
+
+	feeAmount, feeDenom, err := parseCoin(v.calculateFees(chain))
+	if err != nil {
+		return "", fmt.Errorf("invalid fee: %w", err)
+	}
+
+	tx := signing.Tx{
+		Messages:      []signing.Any{msg},
+		Memo:          memo,
+		Fee:           []signing.Coin{{Denom: feeDenom, Amount: feeAmount.String()}},
+		ChainID:       chain.GetChainID(),
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
+	}
+
+	gasLimit, simulate, err := v.nativeGasLimit(chain, opts)
+	if err != nil {
+		return "", err
+	}
+	tx.GasLimit = gasLimit
+	if simulate {
+		// Simulate with the fallback limit, then sign again with the adjusted estimate
+		result, err := v.simulateTxBytesREST(ctx, chain, base64.StdEncoding.EncodeToString(tx.Sign(key)))
+		if err != nil {
+			return "", fmt.Errorf("failed to estimate gas: %w", err)
+		}
+		tx.GasLimit = result.EstimatedGas
+	}
+
+	v.logger.Debug("Signed tx natively",
+		zap.String("chain", chain.GetName()),
+		zap.String("signer", address),
+		zap.Uint64("account_number", accountNumber),
+		zap.Uint64("sequence", sequence),
+		zap.Uint64("gas_limit", tx.GasLimit),
+	)
+
+	return base64.StdEncoding.EncodeToString(tx.Sign(key)), nil
+}
+
+// nativeGasLimit mirrors gasArgs: a per-vote override, then the chain's gas_limit, else
+// simulation (reported as true, with the default limit to simulate under)
+func (v *Voter) nativeGasLimit(chain *config.ChainConfig, opts TxOptions) (uint64, bool, error) {
+	switch {
+	case opts.Gas != "" && opts.Gas != GasFixed:
+		limit, err := strconv.ParseUint(opts.Gas, 10, 64)
+		return limit, false, err
+	case opts.Gas == GasFixed || chain.GasLimit > 0:
+		limit, err := strconv.ParseUint(v.defaultGasLimit(chain), 10, 64)
+		return limit, false, err
+	default:
+		limit, err := strconv.ParseUint(v.defaultGasLimit(chain), 10, 64)
+		return limit, true, err
+	}
+}
@@ -4,16 +4,23 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"prop-voter/config"
+	"prop-voter/internal/redact"
+	"prop-voter/internal/version"
 
 	"go.uber.org/zap"
 )
@@ -22,29 +29,85 @@ import (
 type Voter struct {
 	config *config.Config
 	logger *zap.Logger
+	chains map[string]*config.ChainConfig // Chains keyed by chain ID
+
+	// httpClient is shared by every REST request so connections are reused; it has no
+	// timeout of its own, as each caller's context carries the deadline
+	httpClient *http.Client
+
+	wallets walletStore // Mnemonic source for chains with signing_mode native
 }
 
 // NewVoter creates a new voter instance
 func NewVoter(config *config.Config, logger *zap.Logger) *Voter {
 	return &Voter{
-		config: config,
-		logger: logger,
+		config:     config,
+		logger:     logger,
+		chains:     config.ChainsByID(),
+		httpClient: &http.Client{},
+	}
+}
+
+// TxOptions holds optional per-vote transaction settings
+type TxOptions struct {
+	// Gas overrides the chain's gas setting: a positive gas limit, or GasFixed to use the
+	// chain's gas_limit (or the conservative default) without simulating. Empty keeps the
+	// chain setting.
+	Gas string
+
+	// Memo is attached to the tx with --note; empty uses the chain's default_memo template
+	Memo string
+
+	// sequence overrides the account sequence when re-signing after a sequence mismatch;
+	// empty lets the CLI query it
+	sequence string
+}
+
+// MaxMemoLength is the Cosmos SDK default limit on tx memo length
+const MaxMemoLength = 256
+
+// Validate checks the gas override and memo length
+func (o TxOptions) Validate() error {
+	if err := ValidateGas(o.Gas); err != nil {
+		return err
+	}
+	if len(o.Memo) > MaxMemoLength {
+		return fmt.Errorf("memo is too long (%d characters, max %d)", len(o.Memo), MaxMemoLength)
+	}
+	return nil
+}
+
+// GasFixed requests a fixed gas limit from the chain config instead of --gas auto
+const GasFixed = "fixed"
+
+// ValidateGas checks that a per-vote gas override is empty, GasFixed or a positive integer
+func ValidateGas(gas string) error {
+	if gas == "" || gas == GasFixed {
+		return nil
 	}
+	if limit, err := strconv.ParseUint(gas, 10, 64); err != nil || limit == 0 {
+		return fmt.Errorf("invalid gas override %q: use a positive integer or %q", gas, GasFixed)
+	}
+	return nil
+}
+
+// VoteResult describes a submitted vote (or deposit) transaction
+type VoteResult struct {
+	TxHash string
+	Height int64 // Block height, known once inclusion is confirmed (or in block mode)
 }
 
 // Vote submits a vote for a proposal on the specified chain
-func (v *Voter) Vote(chainID, proposalID, option string) (string, error) {
+func (v *Voter) Vote(chainID, proposalID, option string, opts TxOptions) (*VoteResult, error) {
 	// Find the chain configuration
-	var chainConfig *config.ChainConfig
-	for _, chain := range v.config.Chains {
-		if chain.GetChainID() == chainID {
-			chainConfig = &chain
-			break
-		}
-	}
+	chainConfig := v.chains[chainID]
 
 	if chainConfig == nil {
-		return "", fmt.Errorf("chain %s not found in configuration", chainID)
+		return nil, fmt.Errorf("chain %s not found in configuration", chainID)
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, err
 	}
 
 	v.logger.Info("Submitting vote",
@@ -55,41 +118,90 @@ func (v *Voter) Vote(chainID, proposalID, option string) (string, error) {
 	)
 
 	// Build, sign, encode, and broadcast via REST
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), voteTimeout(chainConfig)+v.inclusionTimeout())
 	defer cancel()
 
-	txHash, err := v.buildSignAndBroadcastGovVoteREST(ctx, chainConfig, proposalID, option)
+	txResp, err := v.buildSignAndBroadcastGovVoteREST(ctx, chainConfig, proposalID, option, opts)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	v.logger.Info("Vote submitted successfully",
 		zap.String("chain", chainConfig.GetName()),
 		zap.String("proposal_id", proposalID),
-		zap.String("tx_hash", txHash),
+		zap.String("tx_hash", txResp.TxHash),
+		zap.Int64("height", txResp.BlockHeight()),
 	)
 
-	return txHash, nil
+	return &VoteResult{TxHash: txResp.TxHash, Height: txResp.BlockHeight()}, nil
 }
 
-// VoteAuthz submits an authz vote for a proposal on the specified chain on behalf of a granter
-func (v *Voter) VoteAuthz(chainID, proposalID, option string) (string, error) {
+// SimulateVote builds and signs a vote for a proposal and simulates it against the chain
+// without broadcasting, returning the estimated gas and any validation error
+func (v *Voter) SimulateVote(chainID, proposalID, option string, opts TxOptions) (*SimulationResult, error) {
 	// Find the chain configuration
-	var chainConfig *config.ChainConfig
-	for _, chain := range v.config.Chains {
-		if chain.GetChainID() == chainID {
-			chainConfig = &chain
-			break
-		}
+	chainConfig := v.chains[chainID]
+
+	if chainConfig == nil {
+		return nil, fmt.Errorf("chain %s not found in configuration", chainID)
 	}
 
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	v.logger.Info("Simulating vote",
+		zap.String("chain", chainConfig.GetName()),
+		zap.String("chain_id", chainID),
+		zap.String("proposal_id", proposalID),
+		zap.String("option", option),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), voteTimeout(chainConfig))
+	defer cancel()
+
+	txBytes, err := v.buildSignAndEncodeGovVote(ctx, chainConfig, proposalID, option, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := v.simulateTxBytesREST(ctx, chainConfig, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	result.Fees = v.calculateFees(chainConfig)
+
+	v.logger.Info("Vote simulation succeeded",
+		zap.String("chain", chainConfig.GetName()),
+		zap.String("proposal_id", proposalID),
+		zap.Uint64("gas_used", result.GasUsed),
+	)
+
+	return result, nil
+}
+
+// VoteAuthz submits an authz vote for a proposal on the specified chain on behalf of a granter,
+// given by address or name; an empty granter means the chain's default granter
+func (v *Voter) VoteAuthz(chainID, proposalID, option, granter string, opts TxOptions) (*VoteResult, error) {
+	// Find the chain configuration
+	chainConfig := v.chains[chainID]
+
 	if chainConfig == nil {
-		return "", fmt.Errorf("chain %s not found in configuration", chainID)
+		return nil, fmt.Errorf("chain %s not found in configuration", chainID)
 	}
 
 	// Check if authz is enabled for this chain
 	if !chainConfig.IsAuthzEnabled() {
-		return "", fmt.Errorf("authz voting is not enabled for chain %s", chainConfig.GetName())
+		return nil, fmt.Errorf("authz voting is not enabled for chain %s", chainConfig.GetName())
+	}
+
+	granterAddr, err := resolveGranter(chainConfig, granter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, err
 	}
 
 	v.logger.Info("Submitting authz vote",
@@ -97,26 +209,277 @@ func (v *Voter) VoteAuthz(chainID, proposalID, option string) (string, error) {
 		zap.String("chain_id", chainID),
 		zap.String("proposal_id", proposalID),
 		zap.String("option", option),
-		zap.String("granter", chainConfig.GetGranterAddr()),
+		zap.String("granter", granterAddr),
 	)
 
 	// Build, sign, encode, and broadcast via REST
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), voteTimeout(chainConfig)+v.inclusionTimeout())
 	defer cancel()
 
-	txHash, err := v.buildSignAndBroadcastAuthzVoteREST(ctx, chainConfig, proposalID, option)
+	txResp, err := v.buildSignAndBroadcastAuthzVoteREST(ctx, chainConfig, proposalID, option, granterAddr, opts)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	v.logger.Info("Authz vote submitted successfully",
 		zap.String("chain", chainConfig.GetName()),
 		zap.String("proposal_id", proposalID),
-		zap.String("tx_hash", txHash),
-		zap.String("granter", chainConfig.GetGranterAddr()),
+		zap.String("tx_hash", txResp.TxHash),
+		zap.Int64("height", txResp.BlockHeight()),
+		zap.String("granter", granterAddr),
+	)
+
+	return &VoteResult{TxHash: txResp.TxHash, Height: txResp.BlockHeight()}, nil
+}
+
+// resolveGranter returns the address of the chain's granter matching ref (an address or
+// name), or of the default granter when ref is empty
+func resolveGranter(chain *config.ChainConfig, ref string) (string, error) {
+	if ref == "" {
+		return chain.GetGranterAddr(), nil
+	}
+	granter := chain.FindGranter(ref)
+	if granter == nil {
+		return "", fmt.Errorf("granter %s is not configured for chain %s", ref, chain.GetName())
+	}
+	return granter.Address, nil
+}
+
+// ValidateDeposit checks that amount is a positive coin in chainID's denom, reporting whether it
+// exceeds the chain's max_deposit cap
+func (v *Voter) ValidateDeposit(chainID, amount string) (exceedsCap bool, err error) {
+	chainConfig := v.chains[chainID]
+
+	if chainConfig == nil {
+		return false, fmt.Errorf("chain %s not found in configuration", chainID)
+	}
+
+	value, denom, err := parseCoin(amount)
+	if err != nil {
+		return false, fmt.Errorf("invalid deposit amount %q: use base units with the denom, e.g. 1000000%s", amount, chainConfig.GetDenom())
+	}
+	if denom != chainConfig.GetDenom() {
+		return false, fmt.Errorf("deposit denom %s does not match %s's denom %s", denom, chainConfig.GetName(), chainConfig.GetDenom())
+	}
+	if value.Sign() == 0 {
+		return false, fmt.Errorf("deposit amount must be positive")
+	}
+
+	if chainConfig.MaxDeposit == "" {
+		return false, nil
+	}
+	limit, ok := new(big.Int).SetString(chainConfig.MaxDeposit, 10)
+	return ok && value.Cmp(limit) > 0, nil
+}
+
+// Deposit submits a deposit of amount (e.g. 1000000uatom) to a proposal in its deposit period
+func (v *Voter) Deposit(chainID, proposalID, amount string) (*VoteResult, error) {
+	// Find the chain configuration
+	chainConfig := v.chains[chainID]
+
+	if chainConfig == nil {
+		return nil, fmt.Errorf("chain %s not found in configuration", chainID)
+	}
+
+	exceedsCap, err := v.ValidateDeposit(chainID, amount)
+	if err != nil {
+		return nil, err
+	}
+	if exceedsCap {
+		v.logger.Warn("Deposit exceeds the chain's max_deposit",
+			zap.String("chain", chainConfig.GetName()),
+			zap.String("amount", amount),
+			zap.String("max_deposit", chainConfig.MaxDeposit),
+		)
+	}
+
+	v.logger.Info("Submitting deposit",
+		zap.String("chain", chainConfig.GetName()),
+		zap.String("chain_id", chainID),
+		zap.String("proposal_id", proposalID),
+		zap.String("amount", amount),
+	)
+
+	// Build, sign, encode, and broadcast via REST
+	ctx, cancel := context.WithTimeout(context.Background(), voteTimeout(chainConfig)+v.inclusionTimeout())
+	defer cancel()
+
+	txResp, err := v.buildSignAndBroadcastDepositREST(ctx, chainConfig, proposalID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	v.logger.Info("Deposit submitted successfully",
+		zap.String("chain", chainConfig.GetName()),
+		zap.String("proposal_id", proposalID),
+		zap.String("amount", amount),
+		zap.String("tx_hash", txResp.TxHash),
+		zap.Int64("height", txResp.BlockHeight()),
+	)
+
+	return &VoteResult{TxHash: txResp.TxHash, Height: txResp.BlockHeight()}, nil
+}
+
+// CancelProposal cancels a proposal submitted by the configured wallet with a gov v1
+// MsgCancelProposal (Cosmos SDK v0.50+). The wallet must be the proposal's proposer; the
+// chain may burn part of the deposit as set by its proposal_cancel_ratio param
+func (v *Voter) CancelProposal(chainID, proposalID string) (*VoteResult, error) {
+	// Find the chain configuration
+	chainConfig := v.chains[chainID]
+
+	if chainConfig == nil {
+		return nil, fmt.Errorf("chain %s not found in configuration", chainID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), voteTimeout(chainConfig)+v.inclusionTimeout())
+	defer cancel()
+
+	if err := v.checkProposer(ctx, chainConfig, proposalID); err != nil {
+		return nil, err
+	}
+
+	v.logger.Info("Cancelling proposal",
+		zap.String("chain", chainConfig.GetName()),
+		zap.String("chain_id", chainID),
+		zap.String("proposal_id", proposalID),
+	)
+
+	txResp, err := v.broadcastWithSequenceRetry(ctx, chainConfig, TxOptions{}, func(opts TxOptions) (string, error) {
+		return v.buildSignAndEncodeCancelProposal(ctx, chainConfig, proposalID, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if txResp.Code != 0 {
+		return nil, fmt.Errorf("cancel transaction failed with code %d: %s - %s", txResp.Code, txResp.Codespace, txResp.RawLog)
+	}
+
+	txResp, err = v.confirmInclusion(ctx, chainConfig, txResp)
+	if err != nil {
+		return nil, err
+	}
+	if txResp.Code != 0 {
+		return nil, fmt.Errorf("cancel transaction failed on-chain with code %d: %s - %s", txResp.Code, txResp.Codespace, txResp.RawLog)
+	}
+
+	v.logger.Info("Proposal cancelled successfully",
+		zap.String("chain", chainConfig.GetName()),
+		zap.String("proposal_id", proposalID),
+		zap.String("tx_hash", txResp.TxHash),
+		zap.Int64("height", txResp.BlockHeight()),
+	)
+
+	return &VoteResult{TxHash: txResp.TxHash, Height: txResp.BlockHeight()}, nil
+}
+
+// checkProposer refuses to cancel a proposal the configured wallet did not submit. The
+// proposer is read from the gov v1 API, which chains without MsgCancelProposal don't serve
+// (or serve without a proposer)
+func (v *Voter) checkProposer(ctx context.Context, chain *config.ChainConfig, proposalID string) error {
+	statusCode, body, err := v.getREST(ctx, chain, "/cosmos/gov/v1/proposals/"+proposalID, "Querying proposal proposer")
+	if err != nil {
+		return fmt.Errorf("failed to query proposal %s: %w", proposalID, err)
+	}
+	if statusCode == http.StatusNotFound {
+		return fmt.Errorf("proposal %s not found on %s, or the chain does not serve the gov v1 API (cancelling needs Cosmos SDK v0.50+)", proposalID, chain.GetName())
+	}
+	if statusCode != http.StatusOK {
+		return fmt.Errorf("failed to query proposal %s: HTTP %d", proposalID, statusCode)
+	}
+
+	var resp struct {
+		Proposal struct {
+			Proposer string `json:"proposer"`
+		} `json:"proposal"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("failed to parse proposal %s: %w", proposalID, err)
+	}
+	if resp.Proposal.Proposer == "" {
+		return fmt.Errorf("%s does not report who submitted proposal %s; cancelling needs Cosmos SDK v0.50+", chain.GetName(), proposalID)
+	}
+
+	address, err := v.getAddressForKey(ctx, chain)
+	if err != nil {
+		return fmt.Errorf("failed to resolve wallet address: %w", err)
+	}
+	if address != resp.Proposal.Proposer {
+		return fmt.Errorf("proposal %s was submitted by %s, not the configured wallet %s; only the proposer can cancel it", proposalID, resp.Proposal.Proposer, address)
+	}
+	return nil
+}
+
+// buildSignAndEncodeCancelProposal builds an unsigned gov cancel-proposal tx, signs it and
+// returns the base64 tx bytes
+func (v *Voter) buildSignAndEncodeCancelProposal(ctx context.Context, chain *config.ChainConfig, proposalID string, opts TxOptions) (string, error) {
+	if chain.UsesNativeSigning() {
+		return v.nativeSignCancelProposal(ctx, chain, proposalID, opts)
+	}
+
+	// Resolve the bech32 address for generate-only mode
+	fromAddress, err := v.getAddressForKey(ctx, chain)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve from address: %w", err)
+	}
+
+	if err := v.checkFeeBalance(ctx, chain, fromAddress); err != nil {
+		return "", err
+	}
+
+	workDir, cleanup, err := v.newTxWorkDir()
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	rpc := v.selectRPC(ctx, chain)
+
+	// 1) Build unsigned tx to temp file
+	unsignedFile := filepath.Join(workDir, "unsigned_cancel.json")
+	buildArgs := []string{
+		"tx", "gov", "cancel-proposal",
+		proposalID,
+		"--from", fromAddress,
+		"--chain-id", chain.GetChainID(),
+		"--node", v.appendAPIKeyForRPC(rpc),
+	}
+	buildArgs = append(buildArgs, v.gasArgs(chain, opts)...)
+	buildArgs = append(buildArgs,
+		"--fees", v.calculateFees(chain),
 	)
+	buildArgs = append(buildArgs, v.keyringArgs(chain)...)
+	buildArgs = append(buildArgs,
+		"--generate-only",
+		"--output", "json",
+	)
+
+	if err := v.execToFileWithContext(ctx, chain, buildArgs, unsignedFile); err != nil {
+		return "", fmt.Errorf("failed to build unsigned cancel-proposal tx: %w", err)
+	}
+
+	// 2) Sign the tx (online, queries via RPC are OK)
+	signedFile := filepath.Join(workDir, "signed_cancel.json")
+	signArgs := []string{
+		"tx", "sign", unsignedFile,
+		"--from", chain.WalletKey,
+		"--chain-id", chain.GetChainID(),
+		"--node", v.appendAPIKeyForRPC(rpc),
+		"--output", "json",
+	}
+	signArgs = append(signArgs, v.signerArgs(chain)...)
+	signArgs = append(signArgs, sequenceArgs(opts)...)
+	v.logLedgerWait(chain)
+	if err := v.execToFileWithContext(ctx, chain, signArgs, signedFile); err != nil {
+		return "", fmt.Errorf("failed to sign cancel-proposal tx: %w", v.ledgerSignError(ctx, chain, err))
+	}
+
+	// 3) Encode to base64 (tx_bytes)
+	txBytes, err := v.encodeTxFileToBase64WithContext(ctx, chain, signedFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cancel-proposal tx to base64: %w", err)
+	}
 
-	return txHash, nil
+	return txBytes, nil
 }
 
 // buildVoteCommandWithContext builds the CLI command for voting with timeout context
@@ -128,13 +491,16 @@ func (v *Voter) buildVoteCommandWithContext(ctx context.Context, chain *config.C
 		"--from", chain.WalletKey,
 		"--chain-id", chain.GetChainID(),
 		"--node", v.appendAPIKeyIfEnabled(chain.RPC),
-		"--gas", "auto",
-		"--gas-adjustment", "1.3",
+	}
+	args = append(args, v.gasArgs(chain, TxOptions{})...)
+	args = append(args,
 		"--fees", v.calculateFees(chain),
-		"--keyring-backend", "test",
+	)
+	args = append(args, v.signerArgs(chain)...)
+	args = append(args,
 		"--yes",
 		"--output", "json",
-	}
+	)
 
 	// Use managed binary path if available
 	cliPath := v.getBinaryPath(chain.GetCLIName())
@@ -150,41 +516,42 @@ func (v *Voter) buildVoteCommand(chain *config.ChainConfig, proposalID, option s
 		"--from", chain.WalletKey,
 		"--chain-id", chain.GetChainID(),
 		"--node", v.appendAPIKeyIfEnabled(chain.RPC),
-		"--gas", "auto",
-		"--gas-adjustment", "1.3",
+	}
+	args = append(args, v.gasArgs(chain, TxOptions{})...)
+	args = append(args,
 		"--fees", v.calculateFees(chain),
-		"--keyring-backend", "test",
+	)
+	args = append(args, v.signerArgs(chain)...)
+	args = append(args,
 		"--yes",
 		"--output", "json",
-	}
+	)
 
 	// Use managed binary path if available
 	cliPath := v.getBinaryPath(chain.GetCLIName())
 	return exec.Command(cliPath, args...)
 }
 
-// buildAuthzVoteCommandWithContext builds the CLI command for authz voting with timeout context
-func (v *Voter) buildAuthzVoteCommandWithContext(ctx context.Context, chain *config.ChainConfig, proposalID, option string) *exec.Cmd {
-	// Create temporary message file for authz exec
-	msgFile := fmt.Sprintf("/tmp/vote_msg_%s_%s.json", chain.GetChainID(), proposalID)
+// buildAuthzVoteCommandWithContext builds the CLI command for authz voting with timeout context.
+// The message file lives in a private temp directory; call cleanup once the command has run
+func (v *Voter) buildAuthzVoteCommandWithContext(ctx context.Context, chain *config.ChainConfig, proposalID, option string) (cmd *exec.Cmd, cleanup func(), err error) {
+	workDir, cleanup, err := v.newTxWorkDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	msgFile := filepath.Join(workDir, "vote_msg.json")
 
 	// Create the governance vote message JSON
-	govVoteMsg := fmt.Sprintf(`{
-  "body": {
-    "messages": [
-      {
-        "@type": "/cosmos.gov.v1beta1.MsgVote",
-        "proposal_id": "%s",
-        "voter": "%s",
-        "option": "%s"
-      }
-    ]
-  }
-}`, proposalID, chain.GetGranterAddr(), v.mapVoteOption(option))
+	govVoteMsg, err := v.authzVoteMsg(v.authzVoteMsgType(ctx, chain, proposalID, chain.GetGranterAddr(), ""), proposalID, chain.GetGranterAddr(), option)
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to encode authz msg: %w", err)
+	}
 
 	// Write message to temporary file
-	if err := os.WriteFile(msgFile, []byte(govVoteMsg), 0644); err != nil {
-		v.logger.Error("Failed to write authz message file", zap.Error(err))
+	if err := os.WriteFile(msgFile, govVoteMsg, 0600); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to write authz msg file: %w", err)
 	}
 
 	args := []string{
@@ -193,188 +560,480 @@ func (v *Voter) buildAuthzVoteCommandWithContext(ctx context.Context, chain *con
 		"--from", chain.WalletKey,
 		"--chain-id", chain.GetChainID(),
 		"--node", v.appendAPIKeyIfEnabled(chain.RPC),
-		"--gas", "auto",
-		"--gas-adjustment", "1.3",
+	}
+	args = append(args, v.gasArgs(chain, TxOptions{})...)
+	args = append(args,
 		"--fees", v.calculateFees(chain),
-		"--keyring-backend", "test",
+	)
+	args = append(args, v.signerArgs(chain)...)
+	args = append(args,
 		"--yes",
 		"--output", "json",
-	}
+	)
 
 	// Use managed binary path if available
 	cliPath := v.getBinaryPath(chain.GetCLIName())
-	cmd := exec.CommandContext(ctx, cliPath, args...)
+	return exec.CommandContext(ctx, cliPath, args...), cleanup, nil
+}
 
-	// Set up cleanup of the temporary file after command execution
-	go func() {
-		<-ctx.Done()
-		if err := os.Remove(msgFile); err != nil {
-			v.logger.Debug("Failed to remove temporary message file", zap.Error(err))
-		}
-	}()
+// buildSignAndBroadcastGovVoteREST constructs, signs, encodes and broadcasts a gov vote via REST
+func (v *Voter) buildSignAndBroadcastGovVoteREST(ctx context.Context, chain *config.ChainConfig, proposalID, option string, opts TxOptions) (*TxResponse, error) {
+	// 1-4) Build, sign, encode and broadcast the vote tx
+	txResp, err := v.broadcastWithSequenceRetry(ctx, chain, opts, func(opts TxOptions) (string, error) {
+		return v.buildSignAndEncodeGovVote(ctx, chain, proposalID, option, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if txResp.Code != 0 {
+		return nil, fmt.Errorf("transaction failed with code %d: %s", txResp.Code, txResp.Codespace)
+	}
 
-	return cmd
+	// 5) Optionally wait for the tx to be committed
+	txResp, err = v.confirmInclusion(ctx, chain, txResp)
+	if err != nil {
+		return nil, err
+	}
+	if txResp.Code != 0 {
+		return nil, fmt.Errorf("transaction failed on-chain with code %d: %s - %s", txResp.Code, txResp.Codespace, txResp.RawLog)
+	}
+	return txResp, nil
 }
 
-// buildSignAndBroadcastGovVoteREST constructs, signs, encodes and broadcasts a gov vote via REST
-func (v *Voter) buildSignAndBroadcastGovVoteREST(ctx context.Context, chain *config.ChainConfig, proposalID, option string) (string, error) {
+// buildSignAndEncodeGovVote builds an unsigned gov vote tx, signs it and returns the base64 tx bytes
+func (v *Voter) buildSignAndEncodeGovVote(ctx context.Context, chain *config.ChainConfig, proposalID, option string, opts TxOptions) (string, error) {
+	if chain.UsesNativeSigning() {
+		return v.nativeSignGovVote(ctx, chain, proposalID, option, opts)
+	}
+
 	// Resolve the bech32 address for generate-only mode
 	fromAddress, err := v.getAddressForKey(ctx, chain)
 	if err != nil {
 		return "", fmt.Errorf("failed to resolve from address: %w", err)
 	}
 
+	if err := v.checkFeeBalance(ctx, chain, fromAddress); err != nil {
+		return "", err
+	}
+
+	workDir, cleanup, err := v.newTxWorkDir()
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	rpc := v.selectRPC(ctx, chain)
+
 	// 1) Build unsigned tx to temp file
-	unsignedFile := fmt.Sprintf("/tmp/unsigned_vote_%s_%s.json", chain.GetChainID(), proposalID)
+	unsignedFile := filepath.Join(workDir, "unsigned_vote.json")
 	buildArgs := []string{
 		"tx", "gov", "vote",
 		proposalID,
 		option,
 		"--from", fromAddress,
 		"--chain-id", chain.GetChainID(),
-		"--node", v.appendAPIKeyForRPC(chain.RPC),
-		"--gas", "auto",
-		"--gas-adjustment", "1.3",
+		"--node", v.appendAPIKeyForRPC(rpc),
+	}
+	buildArgs = append(buildArgs, v.gasArgs(chain, opts)...)
+	buildArgs = append(buildArgs, v.memoArgs(chain, proposalID, option, opts)...)
+	buildArgs = append(buildArgs,
 		"--fees", v.calculateFees(chain),
-		"--keyring-backend", "test",
+	)
+	buildArgs = append(buildArgs, v.keyringArgs(chain)...)
+	buildArgs = append(buildArgs,
 		"--generate-only",
 		"--output", "json",
-	}
+	)
 
-	if err := v.execToFileWithContext(ctx, chain.GetCLIName(), buildArgs, unsignedFile); err != nil {
+	if err := v.execToFileWithContext(ctx, chain, buildArgs, unsignedFile); err != nil {
 		return "", fmt.Errorf("failed to build unsigned tx: %w", err)
 	}
 
 	// 2) Sign the tx (online, queries via RPC are OK)
-	signedFile := fmt.Sprintf("/tmp/signed_vote_%s_%s.json", chain.GetChainID(), proposalID)
+	signedFile := filepath.Join(workDir, "signed_vote.json")
 	signArgs := []string{
 		"tx", "sign", unsignedFile,
 		"--from", chain.WalletKey,
 		"--chain-id", chain.GetChainID(),
-		"--node", v.appendAPIKeyForRPC(chain.RPC),
-		"--keyring-backend", "test",
+		"--node", v.appendAPIKeyForRPC(rpc),
 		"--output", "json",
 	}
-	if err := v.execToFileWithContext(ctx, chain.GetCLIName(), signArgs, signedFile); err != nil {
-		return "", fmt.Errorf("failed to sign tx: %w", err)
+	signArgs = append(signArgs, v.signerArgs(chain)...)
+	signArgs = append(signArgs, sequenceArgs(opts)...)
+	v.logLedgerWait(chain)
+	if err := v.execToFileWithContext(ctx, chain, signArgs, signedFile); err != nil {
+		return "", fmt.Errorf("failed to sign tx: %w", v.ledgerSignError(ctx, chain, err))
 	}
 
 	// 3) Encode to base64 (tx_bytes)
-	txBytes, err := v.encodeTxFileToBase64WithContext(ctx, chain.GetCLIName(), signedFile)
+	txBytes, err := v.encodeTxFileToBase64WithContext(ctx, chain, signedFile)
 	if err != nil {
 		return "", fmt.Errorf("failed to encode tx to base64: %w", err)
 	}
 
-	// 4) Broadcast via REST
-	txResp, err := v.broadcastTxBytesREST(ctx, chain, txBytes)
+	return txBytes, nil
+}
+
+// buildSignAndBroadcastDepositREST constructs, signs, encodes and broadcasts a gov deposit via REST
+func (v *Voter) buildSignAndBroadcastDepositREST(ctx context.Context, chain *config.ChainConfig, proposalID, amount string) (*TxResponse, error) {
+	// 1-4) Build, sign, encode and broadcast the deposit tx
+	txResp, err := v.broadcastWithSequenceRetry(ctx, chain, TxOptions{}, func(opts TxOptions) (string, error) {
+		return v.buildSignAndEncodeDeposit(ctx, chain, proposalID, amount, opts)
+	})
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	if txResp.Code != 0 {
+		return nil, fmt.Errorf("deposit transaction failed with code %d: %s - %s", txResp.Code, txResp.Codespace, txResp.RawLog)
+	}
+
+	// 5) Optionally wait for the tx to be committed
+	txResp, err = v.confirmInclusion(ctx, chain, txResp)
+	if err != nil {
+		return nil, err
 	}
 	if txResp.Code != 0 {
-		return "", fmt.Errorf("transaction failed with code %d: %s", txResp.Code, txResp.Codespace)
+		return nil, fmt.Errorf("deposit transaction failed on-chain with code %d: %s - %s", txResp.Code, txResp.Codespace, txResp.RawLog)
 	}
-	return txResp.TxHash, nil
+	return txResp, nil
 }
 
-// buildSignAndBroadcastAuthzVoteREST constructs, signs, encodes and broadcasts an authz vote via REST
-func (v *Voter) buildSignAndBroadcastAuthzVoteREST(ctx context.Context, chain *config.ChainConfig, proposalID, option string) (string, error) {
+// buildSignAndEncodeDeposit builds an unsigned gov deposit tx, signs it and returns the base64 tx bytes
+func (v *Voter) buildSignAndEncodeDeposit(ctx context.Context, chain *config.ChainConfig, proposalID, amount string, opts TxOptions) (string, error) {
+	if chain.UsesNativeSigning() {
+		return v.nativeSignDeposit(ctx, chain, proposalID, amount, opts)
+	}
+
 	// Resolve the bech32 address for generate-only mode
 	fromAddress, err := v.getAddressForKey(ctx, chain)
 	if err != nil {
 		return "", fmt.Errorf("failed to resolve from address: %w", err)
 	}
 
-	// Prepare the authz exec message file
-	msgFile := fmt.Sprintf("/tmp/vote_msg_%s_%s.json", chain.GetChainID(), proposalID)
-	govVoteMsg := fmt.Sprintf(`{
-  "body": {
-    "messages": [
-      {
-        "@type": "/cosmos.gov.v1beta1.MsgVote",
-        "proposal_id": "%s",
-        "voter": "%s",
-        "option": "%s"
-      }
-    ]
-  }
-}`, proposalID, chain.GetGranterAddr(), v.mapVoteOption(option))
-	if err := os.WriteFile(msgFile, []byte(govVoteMsg), 0644); err != nil {
-		return "", fmt.Errorf("failed to write authz msg file: %w", err)
+	if err := v.checkFeeBalance(ctx, chain, fromAddress); err != nil {
+		return "", err
+	}
+
+	workDir, cleanup, err := v.newTxWorkDir()
+	if err != nil {
+		return "", err
 	}
-	defer func() { _ = os.Remove(msgFile) }()
+	defer cleanup()
+
+	rpc := v.selectRPC(ctx, chain)
 
 	// 1) Build unsigned tx to temp file
-	unsignedFile := fmt.Sprintf("/tmp/unsigned_authz_vote_%s_%s.json", chain.GetChainID(), proposalID)
+	unsignedFile := filepath.Join(workDir, "unsigned_deposit.json")
+	buildArgs := []string{
+		"tx", "gov", "deposit",
+		proposalID,
+		amount,
+		"--from", fromAddress,
+		"--chain-id", chain.GetChainID(),
+		"--node", v.appendAPIKeyForRPC(rpc),
+	}
+	buildArgs = append(buildArgs, v.gasArgs(chain, opts)...)
+	buildArgs = append(buildArgs,
+		"--fees", v.calculateFees(chain),
+	)
+	buildArgs = append(buildArgs, v.keyringArgs(chain)...)
+	buildArgs = append(buildArgs,
+		"--generate-only",
+		"--output", "json",
+	)
+
+	if err := v.execToFileWithContext(ctx, chain, buildArgs, unsignedFile); err != nil {
+		return "", fmt.Errorf("failed to build unsigned deposit tx: %w", err)
+	}
+
+	// 2) Sign the tx (online, queries via RPC are OK)
+	signedFile := filepath.Join(workDir, "signed_deposit.json")
+	signArgs := []string{
+		"tx", "sign", unsignedFile,
+		"--from", chain.WalletKey,
+		"--chain-id", chain.GetChainID(),
+		"--node", v.appendAPIKeyForRPC(rpc),
+		"--output", "json",
+	}
+	signArgs = append(signArgs, v.signerArgs(chain)...)
+	signArgs = append(signArgs, sequenceArgs(opts)...)
+	v.logLedgerWait(chain)
+	if err := v.execToFileWithContext(ctx, chain, signArgs, signedFile); err != nil {
+		return "", fmt.Errorf("failed to sign deposit tx: %w", v.ledgerSignError(ctx, chain, err))
+	}
+
+	// 3) Encode to base64 (tx_bytes)
+	txBytes, err := v.encodeTxFileToBase64WithContext(ctx, chain, signedFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode deposit tx to base64: %w", err)
+	}
+
+	return txBytes, nil
+}
+
+// buildSignAndBroadcastAuthzVoteREST constructs, signs, encodes and broadcasts an authz vote via
+// REST, voting as granter
+func (v *Voter) buildSignAndBroadcastAuthzVoteREST(ctx context.Context, chain *config.ChainConfig, proposalID, option, granter string, opts TxOptions) (*TxResponse, error) {
+	// 1-4) Build, sign, encode and broadcast the authz exec tx
+	txResp, err := v.broadcastWithSequenceRetry(ctx, chain, opts, func(opts TxOptions) (string, error) {
+		return v.buildSignAndEncodeAuthzVote(ctx, chain, proposalID, option, granter, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if txResp.Code != 0 {
+		return nil, fmt.Errorf("authz transaction failed with code %d: %s", txResp.Code, txResp.Codespace)
+	}
+
+	// 5) Optionally wait for the tx to be committed
+	txResp, err = v.confirmInclusion(ctx, chain, txResp)
+	if err != nil {
+		return nil, err
+	}
+	if txResp.Code != 0 {
+		return nil, fmt.Errorf("authz transaction failed on-chain with code %d: %s - %s", txResp.Code, txResp.Codespace, txResp.RawLog)
+	}
+	return txResp, nil
+}
+
+// buildSignAndEncodeAuthzVote builds an unsigned authz exec vote tx, signs it and returns the base64 tx bytes
+func (v *Voter) buildSignAndEncodeAuthzVote(ctx context.Context, chain *config.ChainConfig, proposalID, option, granter string, opts TxOptions) (string, error) {
+	if chain.UsesNativeSigning() {
+		return v.nativeSignAuthzVote(ctx, chain, proposalID, option, granter, opts)
+	}
+
+	// Resolve the bech32 address for generate-only mode
+	fromAddress, err := v.getAddressForKey(ctx, chain)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve from address: %w", err)
+	}
+
+	if err := v.checkFeeBalance(ctx, chain, fromAddress); err != nil {
+		return "", err
+	}
+
+	workDir, cleanup, err := v.newTxWorkDir()
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	// Prepare the authz exec message file
+	msgFile := filepath.Join(workDir, "vote_msg.json")
+	govVoteMsg, err := v.authzVoteMsg(v.authzVoteMsgType(ctx, chain, proposalID, granter, fromAddress), proposalID, granter, option)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode authz msg: %w", err)
+	}
+	if err := os.WriteFile(msgFile, govVoteMsg, 0600); err != nil {
+		return "", fmt.Errorf("failed to write authz msg file: %w", err)
+	}
+
+	rpc := v.selectRPC(ctx, chain)
+
+	// 1) Build unsigned tx to temp file
+	unsignedFile := filepath.Join(workDir, "unsigned_authz_vote.json")
 	buildArgs := []string{
 		"tx", "authz", "exec",
 		msgFile,
 		"--from", fromAddress,
 		"--chain-id", chain.GetChainID(),
-		"--node", v.appendAPIKeyForRPC(chain.RPC),
-		"--gas", "auto",
-		"--gas-adjustment", "1.3",
+		"--node", v.appendAPIKeyForRPC(rpc),
+	}
+	buildArgs = append(buildArgs, v.gasArgs(chain, opts)...)
+	buildArgs = append(buildArgs, v.memoArgs(chain, proposalID, option, opts)...)
+	buildArgs = append(buildArgs,
 		"--fees", v.calculateFees(chain),
-		"--keyring-backend", "test",
+	)
+	buildArgs = append(buildArgs, v.keyringArgs(chain)...)
+	buildArgs = append(buildArgs,
 		"--generate-only",
 		"--output", "json",
-	}
-	if err := v.execToFileWithContext(ctx, chain.GetCLIName(), buildArgs, unsignedFile); err != nil {
+	)
+	if err := v.execToFileWithContext(ctx, chain, buildArgs, unsignedFile); err != nil {
 		return "", fmt.Errorf("failed to build unsigned authz tx: %w", err)
 	}
 
 	// 2) Sign the tx (online)
-	signedFile := fmt.Sprintf("/tmp/signed_authz_vote_%s_%s.json", chain.GetChainID(), proposalID)
+	signedFile := filepath.Join(workDir, "signed_authz_vote.json")
 	signArgs := []string{
 		"tx", "sign", unsignedFile,
 		"--from", chain.WalletKey,
 		"--chain-id", chain.GetChainID(),
-		"--node", v.appendAPIKeyForRPC(chain.RPC),
-		"--keyring-backend", "test",
+		"--node", v.appendAPIKeyForRPC(rpc),
 		"--output", "json",
 	}
-	if err := v.execToFileWithContext(ctx, chain.GetCLIName(), signArgs, signedFile); err != nil {
-		return "", fmt.Errorf("failed to sign authz tx: %w", err)
+	signArgs = append(signArgs, v.signerArgs(chain)...)
+	signArgs = append(signArgs, sequenceArgs(opts)...)
+	v.logLedgerWait(chain)
+	if err := v.execToFileWithContext(ctx, chain, signArgs, signedFile); err != nil {
+		return "", fmt.Errorf("failed to sign authz tx: %w", v.ledgerSignError(ctx, chain, err))
 	}
 
 	// 3) Encode to base64 (tx_bytes)
-	txBytes, err := v.encodeTxFileToBase64WithContext(ctx, chain.GetCLIName(), signedFile)
+	txBytes, err := v.encodeTxFileToBase64WithContext(ctx, chain, signedFile)
 	if err != nil {
 		return "", fmt.Errorf("failed to encode authz tx to base64: %w", err)
 	}
 
-	// 4) Broadcast via REST
+	return txBytes, nil
+}
+
+// broadcastWithSequenceRetry broadcasts the tx produced by build. If the chain rejects it for an
+// account sequence mismatch (e.g. overlapping votes), the tx is rebuilt and re-signed once with
+// the correct --sequence.
+func (v *Voter) broadcastWithSequenceRetry(ctx context.Context, chain *config.ChainConfig, opts TxOptions, build func(TxOptions) (string, error)) (*TxResponse, error) {
+	txBytes, err := build(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	txResp, err := v.broadcastTxBytesREST(ctx, chain, txBytes)
+	if err != nil || !txResp.IsSequenceMismatch() {
+		return txResp, err
+	}
+
+	sequence, err := v.expectedSequence(ctx, chain, txResp)
 	if err != nil {
-		return "", err
+		v.logger.Warn("Account sequence mismatch, but the expected sequence could not be resolved",
+			zap.String("chain", chain.GetName()),
+			zap.String("raw_log", txResp.RawLog),
+			zap.Error(err),
+		)
+		return txResp, nil
 	}
-	if txResp.Code != 0 {
-		return "", fmt.Errorf("authz transaction failed with code %d: %s", txResp.Code, txResp.Codespace)
+
+	v.logger.Warn("Account sequence mismatch, retrying with the expected sequence",
+		zap.String("chain", chain.GetName()),
+		zap.Uint64("sequence", sequence),
+	)
+
+	opts.sequence = strconv.FormatUint(sequence, 10)
+	txBytes, err = build(opts)
+	if err != nil {
+		return nil, err
+	}
+	return v.broadcastTxBytesREST(ctx, chain, txBytes)
+}
+
+// sequenceMismatchPattern extracts the expected sequence from an SDK sequence mismatch log
+var sequenceMismatchPattern = regexp.MustCompile(`account sequence mismatch, expected (\d+)`)
+
+// expectedSequence returns the sequence the chain expects, taken from the mismatch log when
+// present, otherwise re-queried from the auth module
+func (v *Voter) expectedSequence(ctx context.Context, chain *config.ChainConfig, txResp *TxResponse) (uint64, error) {
+	if match := sequenceMismatchPattern.FindStringSubmatch(txResp.RawLog); match != nil {
+		return strconv.ParseUint(match[1], 10, 64)
 	}
-	return txResp.TxHash, nil
+
+	address, err := v.getAddressForKey(ctx, chain)
+	if err != nil {
+		return 0, err
+	}
+	return v.queryAccountSequence(ctx, chain, address)
 }
 
-// execToFileWithContext runs a CLI command and writes stdout to a file
-func (v *Voter) execToFileWithContext(ctx context.Context, cli string, args []string, outPath string) error {
-	cliPath := v.getBinaryPath(cli)
+// queryAccountSequence returns the account's current sequence from the auth module
+func (v *Voter) queryAccountSequence(ctx context.Context, chain *config.ChainConfig, address string) (uint64, error) {
+	_, sequence, err := v.queryAccount(ctx, chain, address)
+	return sequence, err
+}
+
+// queryAccount returns the account's number and current sequence from the auth module
+func (v *Voter) queryAccount(ctx context.Context, chain *config.ChainConfig, address string) (accountNumber, sequence uint64, err error) {
+	type baseAccount struct {
+		AccountNumber string `json:"account_number"`
+		Sequence      string `json:"sequence"`
+	}
+	type accountResponse struct {
+		Account struct {
+			baseAccount
+			// Vesting and module accounts wrap the base account
+			BaseAccount        *baseAccount `json:"base_account"`
+			BaseVestingAccount *struct {
+				BaseAccount baseAccount `json:"base_account"`
+			} `json:"base_vesting_account"`
+		} `json:"account"`
+	}
+
+	statusCode, body, err := v.getREST(ctx, chain, "/cosmos/auth/v1beta1/accounts/"+address, "Querying account sequence")
+	if err != nil {
+		return 0, 0, err
+	}
+	if statusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("account query failed: status %d - body: %s", statusCode, string(body))
+	}
+
+	var ar accountResponse
+	if err := json.Unmarshal(body, &ar); err != nil {
+		return 0, 0, fmt.Errorf("failed to parse account response: %w - body: %s", err, string(body))
+	}
+
+	account := ar.Account.baseAccount
+	switch {
+	case ar.Account.BaseAccount != nil:
+		account = *ar.Account.BaseAccount
+	case ar.Account.BaseVestingAccount != nil:
+		account = ar.Account.BaseVestingAccount.BaseAccount
+	}
+	if account.Sequence == "" {
+		return 0, 0, fmt.Errorf("no sequence in account response - body: %s", string(body))
+	}
+
+	if sequence, err = strconv.ParseUint(account.Sequence, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid account sequence %q", account.Sequence)
+	}
+	// Treat a missing account number as zero rather than failing
+	if account.AccountNumber != "" {
+		if accountNumber, err = strconv.ParseUint(account.AccountNumber, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid account number %q", account.AccountNumber)
+		}
+	}
+	return accountNumber, sequence, nil
+}
+
+// sequenceArgs returns --sequence when re-signing with a known account sequence
+func sequenceArgs(opts TxOptions) []string {
+	if opts.sequence == "" {
+		return nil
+	}
+	return []string{"--sequence", opts.sequence}
+}
+
+// newTxWorkDir creates a private (0700) temp directory for the tx files of one vote so
+// concurrent votes never share files; cleanup removes it and must run on every path
+func (v *Voter) newTxWorkDir() (dir string, cleanup func(), err error) {
+	dir, err = os.MkdirTemp("", "prop-voter-tx-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create tx work directory: %w", err)
+	}
+	return dir, func() {
+		if err := os.RemoveAll(dir); err != nil {
+			v.logger.Debug("Failed to remove tx work directory", zap.String("dir", dir), zap.Error(err))
+		}
+	}, nil
+}
+
+// execToFileWithContext runs a CLI command for the chain and writes its output to a file
+func (v *Voter) execToFileWithContext(ctx context.Context, chain *config.ChainConfig, args []string, outPath string) error {
+	cliPath := v.getBinaryPath(chain.GetCLIName())
 	cmd := exec.CommandContext(ctx, cliPath, args...)
-	fullCmd := strings.Join(cmd.Args, " ")
-	v.logger.Info("Executing CLI command", zap.String("command", fullCmd))
-	output, err := cmd.CombinedOutput()
+	v.logger.Info("Executing CLI command", zap.String("command", v.redactCommand(cmd.Args)))
+	output, err := v.runKeyringCommand(cmd, chain)
 	if err != nil {
 		return fmt.Errorf("command failed: %w - output: %s", err, string(output))
 	}
-	if err := os.WriteFile(outPath, output, 0644); err != nil {
+	if err := os.WriteFile(outPath, output, 0600); err != nil {
 		return fmt.Errorf("failed writing output file: %w", err)
 	}
 	return nil
 }
 
 // encodeTxFileToBase64WithContext encodes a signed tx JSON file to base64 using CLI
-func (v *Voter) encodeTxFileToBase64WithContext(ctx context.Context, cli string, signedFile string) (string, error) {
-	cliPath := v.getBinaryPath(cli)
-	cmd := exec.CommandContext(ctx, cliPath, "tx", "encode", signedFile)
-	fullCmd := strings.Join(cmd.Args, " ")
-	v.logger.Info("Encoding tx to base64", zap.String("command", fullCmd))
+func (v *Voter) encodeTxFileToBase64WithContext(ctx context.Context, chain *config.ChainConfig, signedFile string) (string, error) {
+	cliPath := v.getBinaryPath(chain.GetCLIName())
+	args := append([]string{"tx", "encode", signedFile}, v.homeArgs(chain)...)
+	cmd := exec.CommandContext(ctx, cliPath, args...)
+	v.logger.Info("Encoding tx to base64", zap.String("command", v.redactCommand(cmd.Args)))
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return "", fmt.Errorf("encode failed: %w - output: %s", err, string(output))
@@ -392,66 +1051,339 @@ func (v *Voter) broadcastTxBytesREST(ctx context.Context, chain *config.ChainCon
 		TxResponse TxResponse `json:"tx_response"`
 	}
 
-	urlBase := strings.TrimRight(chain.REST, "/")
-	url := strings.TrimRight(v.appendAPIKeyIfEnabled(urlBase+"/cosmos/tx/v1beta1/txs"), "/")
-	v.logger.Info("Broadcasting via REST", zap.String("url", strings.Split(url, "?")[0]))
-	reqBody := broadcastRequest{TxBytes: txBytesBase64, Mode: "BROADCAST_MODE_SYNC"}
+	reqBody := broadcastRequest{TxBytes: txBytesBase64, Mode: v.broadcastMode()}
 	data, _ := json.Marshal(reqBody)
 
-	httpClient := &http.Client{Timeout: 30 * time.Second}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	statusCode, body, err := v.postREST(ctx, chain, "/cosmos/tx/v1beta1/txs", data, "Broadcasting via REST")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		return nil, err
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return nil, fmt.Errorf("REST broadcast failed: status %d - body: %s", statusCode, string(body))
+	}
+
+	var br broadcastResponse
+	if err := json.Unmarshal(body, &br); err != nil {
+		return nil, fmt.Errorf("failed to parse broadcast response: %w - body: %s", err, string(body))
+	}
+	return &br.TxResponse, nil
+}
+
+// broadcastMode returns the REST broadcast mode for voting.broadcast_mode
+func (v *Voter) broadcastMode() string {
+	switch v.config.Voting.BroadcastMode {
+	case config.BroadcastModeAsync:
+		return "BROADCAST_MODE_ASYNC"
+	case config.BroadcastModeBlock:
+		return "BROADCAST_MODE_BLOCK"
+	default:
+		return "BROADCAST_MODE_SYNC"
+	}
+}
+
+// defaultConfirmTimeout bounds the wait for inclusion when voting.confirm_timeout is unset
+const defaultConfirmTimeout = time.Minute
+
+// txPollInterval is how often a broadcast tx is looked up while waiting for inclusion
+var txPollInterval = 2 * time.Second
+
+// inclusionTimeout returns how long a vote may wait for its tx to be committed
+func (v *Voter) inclusionTimeout() time.Duration {
+	if !v.config.Voting.ConfirmInclusion || v.config.Voting.BroadcastMode == config.BroadcastModeBlock {
+		return 0
+	}
+	if v.config.Voting.ConfirmTimeout > 0 {
+		return v.config.Voting.ConfirmTimeout
+	}
+	return defaultConfirmTimeout
+}
+
+// confirmInclusion waits for a sync/async broadcast to be committed when voting.confirm_inclusion
+// is set, returning the committed tx response with its final code and height
+func (v *Voter) confirmInclusion(ctx context.Context, chain *config.ChainConfig, txResp *TxResponse) (*TxResponse, error) {
+	timeout := v.inclusionTimeout()
+	if timeout == 0 {
+		return txResp, nil
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := httpClient.Do(req)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(txPollInterval)
+	defer ticker.Stop()
+
+	for {
+		committed, err := v.queryTx(ctx, chain, txResp.TxHash)
+		if err == nil && committed != nil {
+			return committed, nil
+		}
+		if err != nil {
+			v.logger.Debug("Transaction lookup failed, retrying",
+				zap.String("chain", chain.GetName()),
+				zap.String("tx_hash", txResp.TxHash),
+				zap.Error(err),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction %s was broadcast but not included within %s; check the explorer before voting again", txResp.TxHash, timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// queryTx looks up a tx by hash, returning nil until it has been committed
+func (v *Voter) queryTx(ctx context.Context, chain *config.ChainConfig, txHash string) (*TxResponse, error) {
+	type txLookupResponse struct {
+		TxResponse *TxResponse `json:"tx_response"`
+	}
+
+	statusCode, body, err := v.getREST(ctx, chain, "/cosmos/tx/v1beta1/txs/"+txHash, "Checking transaction inclusion")
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+		return nil, err
 	}
-	defer func(body io.ReadCloser) { _ = body.Close() }(resp.Body)
+	if statusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("tx query failed: status %d - body: %s", statusCode, string(body))
+	}
+
+	var lr txLookupResponse
+	if err := json.Unmarshal(body, &lr); err != nil {
+		return nil, fmt.Errorf("failed to parse tx response: %w - body: %s", err, string(body))
+	}
+	if lr.TxResponse == nil || lr.TxResponse.BlockHeight() == 0 {
+		return nil, nil
+	}
+	return lr.TxResponse, nil
+}
+
+// SimulationResult holds the outcome of a simulated transaction
+type SimulationResult struct {
+	GasWanted    uint64
+	GasUsed      uint64
+	EstimatedGas uint64 // GasUsed with the standard 1.3 gas adjustment applied
+	Fees         string
+}
+
+// simulateTxBytesREST posts tx_bytes to the REST simulate endpoint
+func (v *Voter) simulateTxBytesREST(ctx context.Context, chain *config.ChainConfig, txBytesBase64 string) (*SimulationResult, error) {
+	type simulateRequest struct {
+		TxBytes string `json:"tx_bytes"`
+	}
+	type simulateResponse struct {
+		GasInfo struct {
+			GasWanted string `json:"gas_wanted"`
+			GasUsed   string `json:"gas_used"`
+		} `json:"gas_info"`
+	}
+
+	data, _ := json.Marshal(simulateRequest{TxBytes: txBytesBase64})
 
-	body, err := io.ReadAll(resp.Body)
+	statusCode, body, err := v.postREST(ctx, chain, "/cosmos/tx/v1beta1/simulate", data, "Simulating via REST")
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("REST broadcast failed: status %d - body: %s", resp.StatusCode, string(body))
+	if statusCode < 200 || statusCode >= 300 {
+		return nil, fmt.Errorf("REST simulation failed: status %d - body: %s", statusCode, string(body))
 	}
 
-	var br broadcastResponse
-	if err := json.Unmarshal(body, &br); err != nil {
-		return nil, fmt.Errorf("failed to parse broadcast response: %w - body: %s", err, string(body))
+	var sr simulateResponse
+	if err := json.Unmarshal(body, &sr); err != nil {
+		return nil, fmt.Errorf("failed to parse simulate response: %w - body: %s", err, string(body))
 	}
-	return &br.TxResponse, nil
+
+	gasUsed, err := strconv.ParseUint(sr.GasInfo.GasUsed, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas_used in simulate response: %q", sr.GasInfo.GasUsed)
+	}
+	gasWanted, _ := strconv.ParseUint(sr.GasInfo.GasWanted, 10, 64)
+
+	return &SimulationResult{
+		GasWanted:    gasWanted,
+		GasUsed:      gasUsed,
+		EstimatedGas: uint64(float64(gasUsed) * 1.3),
+	}, nil
+}
+
+// postREST POSTs a JSON body to path on the chain's REST endpoints in order, moving to the
+// next endpoint on connection errors or 5xx responses. The last response is returned if
+// every endpoint answered with a server error.
+func (v *Voter) postREST(ctx context.Context, chain *config.ChainConfig, path string, data []byte, action string) (int, []byte, error) {
+	return v.doREST(ctx, chain, http.MethodPost, path, data, action)
+}
+
+// getREST GETs path from the chain's REST endpoints with the same failover as postREST
+func (v *Voter) getREST(ctx context.Context, chain *config.ChainConfig, path string, action string) (int, []byte, error) {
+	return v.doREST(ctx, chain, http.MethodGet, path, nil, action)
+}
+
+// doREST sends a request to each of the chain's REST endpoints until one answers without a server error
+func (v *Voter) doREST(ctx context.Context, chain *config.ChainConfig, method, path string, data []byte, action string) (int, []byte, error) {
+	endpoints := chain.GetRESTEndpoints()
+	if len(endpoints) == 0 {
+		return 0, nil, fmt.Errorf("no REST endpoint configured for chain %s", chain.GetName())
+	}
+
+	var lastErr error
+	var lastStatus int
+	var lastBody []byte
+	for _, endpoint := range endpoints {
+		url := strings.TrimRight(v.appendAPIKeyIfEnabled(strings.TrimRight(endpoint, "/")+path), "/")
+		v.logger.Info(action, zap.String("url", v.redact(url)))
+
+		var reqBody io.Reader
+		if data != nil {
+			reqBody = bytes.NewReader(data)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+		if data != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		req.Header.Set("User-Agent", version.UserAgent())
+		v.config.AuthEndpoints.SetHeader(req.Header)
+
+		resp, err := v.httpClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("HTTP request failed: %w", err)
+			v.logger.Warn("REST endpoint unreachable, trying next",
+				zap.String("chain", chain.GetName()),
+				zap.String("endpoint", endpoint),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		if resp.StatusCode >= 500 {
+			lastErr, lastStatus, lastBody = nil, resp.StatusCode, body
+			v.logger.Warn("REST endpoint returned server error, trying next",
+				zap.String("chain", chain.GetName()),
+				zap.String("endpoint", endpoint),
+				zap.Int("status", resp.StatusCode),
+			)
+			continue
+		}
+
+		return resp.StatusCode, body, nil
+	}
+
+	if lastErr != nil {
+		return 0, nil, lastErr
+	}
+	return lastStatus, lastBody, nil
+}
+
+// selectRPC returns the first RPC endpoint answering /status, falling back to the primary
+func (v *Voter) selectRPC(ctx context.Context, chain *config.ChainConfig) string {
+	endpoints := chain.GetRPCEndpoints()
+	if len(endpoints) <= 1 {
+		return chain.RPC
+	}
+
+	for _, endpoint := range endpoints {
+		status, err := v.probeEndpoint(ctx, v.appendAPIKeyForRPC(strings.TrimRight(endpoint, "/")+"/status"), v.config.AuthEndpoints.ApplyToRPC)
+		if err == nil && status < 500 {
+			v.logger.Info("Using RPC endpoint",
+				zap.String("chain", chain.GetName()),
+				zap.String("endpoint", endpoint),
+			)
+			return endpoint
+		}
+		v.logger.Warn("RPC endpoint unavailable, trying next",
+			zap.String("chain", chain.GetName()),
+			zap.String("endpoint", endpoint),
+			zap.Int("status", status),
+			zap.Error(err),
+		)
+	}
+
+	return chain.RPC
 }
 
-// defaultGasLimit returns a conservative gas limit for simple messages
+// defaultGasLimit returns the chain's configured gas_limit, or a conservative limit for simple messages
 func (v *Voter) defaultGasLimit(chain *config.ChainConfig) string {
+	if chain.GasLimit > 0 {
+		return strconv.FormatUint(chain.GasLimit, 10)
+	}
 	// Conservative default; adjust per-chain here if needed
 	return "200000"
 }
 
-// appendAPIKeyIfEnabled appends the api_key query parameter to a base URL if configured
-func (v *Voter) appendAPIKeyIfEnabled(base string) string {
-	if v.config.AuthEndpoints.Enabled && v.config.AuthEndpoints.APIKey != "" {
-		if strings.Contains(base, "?") {
-			return base + "&api_key=" + v.config.AuthEndpoints.APIKey
+// gasArgs returns the --gas flags for a vote tx: a per-vote override, then the chain's
+// gas_limit, then simulation with --gas auto
+func (v *Voter) gasArgs(chain *config.ChainConfig, opts TxOptions) []string {
+	switch {
+	case opts.Gas == GasFixed:
+		return []string{"--gas", v.defaultGasLimit(chain)}
+	case opts.Gas != "":
+		return []string{"--gas", opts.Gas}
+	case chain.GasLimit > 0:
+		return []string{"--gas", v.defaultGasLimit(chain)}
+	default:
+		return []string{"--gas", "auto", "--gas-adjustment", "1.3"}
+	}
+}
+
+// memoArgs returns the --note flag for a vote tx; nil when there is no memo
+func (v *Voter) memoArgs(chain *config.ChainConfig, proposalID, option string, opts TxOptions) []string {
+	memo := voteMemo(chain, proposalID, option, opts)
+	if memo == "" {
+		return nil
+	}
+	return []string{"--note", memo}
+}
+
+// voteMemo returns a vote tx's memo: the per-vote memo, else the chain's default_memo
+// template, truncated to MaxMemoLength
+func voteMemo(chain *config.ChainConfig, proposalID, option string, opts TxOptions) string {
+	memo := opts.Memo
+	if memo == "" {
+		memo = chain.GetDefaultMemo(proposalID, option)
+	}
+	if len(memo) > MaxMemoLength {
+		// The limit is in bytes; cut on a rune boundary so the memo stays valid UTF-8
+		cut := MaxMemoLength
+		for cut > 0 && !utf8.RuneStart(memo[cut]) {
+			cut--
 		}
-		return base + "?api_key=" + v.config.AuthEndpoints.APIKey
+		memo = memo[:cut]
 	}
-	return base
+	return memo
+}
+
+// redact masks configured secrets, API keys, tokens and mnemonics in text before it is logged
+func (v *Voter) redact(s string) string {
+	return redact.String(s, v.config.SecretValues()...)
+}
+
+// redactCommand joins a command's arguments for logging with secrets masked
+func (v *Voter) redactCommand(args []string) string {
+	return redact.Command(args, v.config.SecretValues()...)
+}
+
+// appendAPIKeyIfEnabled appends the api_key query parameter to a base URL if configured
+// for query-style auth; header-style keys are set on each request instead
+func (v *Voter) appendAPIKeyIfEnabled(base string) string {
+	return v.config.AuthEndpoints.AppendQuery(base)
 }
 
 // appendAPIKeyForRPC appends API key only if configuration allows applying to RPC
 func (v *Voter) appendAPIKeyForRPC(base string) string {
-	if v.config.AuthEndpoints.Enabled && v.config.AuthEndpoints.ApplyToRPC && v.config.AuthEndpoints.APIKey != "" {
-		if strings.Contains(base, "?") {
-			return base + "&api_key=" + v.config.AuthEndpoints.APIKey
-		}
-		return base + "?api_key=" + v.config.AuthEndpoints.APIKey
+	if !v.config.AuthEndpoints.ApplyToRPC {
+		return base
 	}
-	return base
+	return v.config.AuthEndpoints.AppendQuery(base)
 }
 
 // mapVoteOption maps user-friendly vote options to the format expected by governance
@@ -472,21 +1404,22 @@ func (v *Voter) mapVoteOption(option string) string {
 
 // getBinaryPath returns the path to the CLI binary (managed or system)
 func (v *Voter) getBinaryPath(cliName string) string {
-	// Check if we have a managed binary
-	managedPath := filepath.Join("./bin", cliName)
-	if _, err := os.Stat(managedPath); err == nil {
-		return managedPath
-	}
-
-	// Fall back to system PATH
-	return cliName
+	// Use the binary manager's copy when present, the same file binmgr updates
+	return v.config.BinaryManager.ResolveBinary(cliName)
 }
 
-// getAddressForKey returns the bech32 address for the configured key (required in generate-only)
+// getAddressForKey returns the bech32 address for the configured key (required in generate-only);
+// native signing chains derive it from the stored mnemonic instead of asking the CLI
 func (v *Voter) getAddressForKey(ctx context.Context, chain *config.ChainConfig) (string, error) {
+	if chain.UsesNativeSigning() {
+		_, address, err := v.nativeKey(chain)
+		return address, err
+	}
+
 	cliPath := v.getBinaryPath(chain.GetCLIName())
-	cmd := exec.CommandContext(ctx, cliPath, "keys", "show", chain.WalletKey, "--address", "--keyring-backend", "test")
-	output, err := cmd.CombinedOutput()
+	args := append([]string{"keys", "show", chain.WalletKey, "--address"}, v.keyringArgs(chain)...)
+	cmd := exec.CommandContext(ctx, cliPath, args...)
+	output, err := v.runKeyringCommand(cmd, chain)
 	if err != nil {
 		return "", fmt.Errorf("failed to get address for key %s: %w - output: %s", chain.WalletKey, err, string(output))
 	}
@@ -497,6 +1430,99 @@ func (v *Voter) getAddressForKey(ctx context.Context, chain *config.ChainConfig)
 	return addr, nil
 }
 
+// LedgerVoteTimeout bounds a vote on a Ledger-backed chain, leaving time for the operator
+// to confirm the transaction on the device
+const LedgerVoteTimeout = 3 * time.Minute
+
+// voteTimeout returns how long a single vote attempt may take for the chain
+func voteTimeout(chain *config.ChainConfig) time.Duration {
+	if chain.UseLedger {
+		return LedgerVoteTimeout
+	}
+	return 60 * time.Second
+}
+
+// VoteTimeout returns how long a vote on the chain may take in total, including the wait for
+// inclusion when voting.confirm_inclusion is set
+func (v *Voter) VoteTimeout(chainID string) time.Duration {
+	chainConfig := v.chains[chainID]
+	if chainConfig == nil {
+		return 0
+	}
+	return voteTimeout(chainConfig) + v.inclusionTimeout()
+}
+
+// keyringBackend returns the keyring backend for the chain, falling back to the global default
+func (v *Voter) keyringBackend(chain *config.ChainConfig) string {
+	return chain.GetKeyringBackend(v.config.KeyManager.KeyringBackend)
+}
+
+// homeArgs returns --home for chains whose CLI home is configured, isolating their keyrings
+func (v *Voter) homeArgs(chain *config.ChainConfig) []string {
+	if home := chain.GetHome(v.config.KeyManager.HomeDir); home != "" {
+		return []string{"--home", home}
+	}
+	return nil
+}
+
+// keyringArgs returns the keyring flags for commands that read the chain's key
+func (v *Voter) keyringArgs(chain *config.ChainConfig) []string {
+	return append([]string{"--keyring-backend", v.keyringBackend(chain)}, v.homeArgs(chain)...)
+}
+
+// runKeyringCommand runs a command that may open the chain's keyring. Backends that need a
+// passphrase get it on stdin, and only stdout is returned so the prompts (written to stderr)
+// don't end up in parsed output; otherwise combined output is returned as before
+func (v *Voter) runKeyringCommand(cmd *exec.Cmd, chain *config.ChainConfig) ([]byte, error) {
+	input, err := config.KeyringInput(v.keyringBackend(chain))
+	if err != nil {
+		return nil, err
+	}
+	if input == "" {
+		return cmd.CombinedOutput()
+	}
+
+	cmd.Stdin = strings.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		output = append(output, stderr.Bytes()...)
+	}
+	return output, err
+}
+
+// signerArgs returns the keyring flags for commands that sign, adding --ledger for hardware wallets
+func (v *Voter) signerArgs(chain *config.ChainConfig) []string {
+	args := v.keyringArgs(chain)
+	if chain.UseLedger {
+		args = append(args, "--ledger")
+	}
+	return args
+}
+
+// logLedgerWait tells the operator that signing is blocked on the device
+func (v *Voter) logLedgerWait(chain *config.ChainConfig) {
+	if chain.UseLedger {
+		v.logger.Info("Waiting for Ledger confirmation - approve the transaction on the device",
+			zap.String("chain", chain.GetName()),
+			zap.String("key", chain.WalletKey),
+		)
+	}
+}
+
+// ledgerSignError explains signing failures on Ledger-backed chains, which are usually a
+// disconnected or locked device or an unconfirmed prompt
+func (v *Voter) ledgerSignError(ctx context.Context, chain *config.ChainConfig, err error) error {
+	if !chain.UseLedger {
+		return err
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out waiting for Ledger confirmation; check the device is connected, unlocked and the app is open: %w", err)
+	}
+	return fmt.Errorf("ledger signing failed; check the device is connected, unlocked and the app is open: %w", err)
+}
+
 // calculateFees calculates appropriate fees for the transaction
 func (v *Voter) calculateFees(chain *config.ChainConfig) string {
 	// Default fee amounts for different chains
@@ -514,36 +1540,249 @@ func (v *Voter) calculateFees(chain *config.ChainConfig) string {
 	return fmt.Sprintf("5000%s", chain.GetDenom())
 }
 
+// lowBalanceFeeMultiple is how many vote fees the wallet should hold before it is reported
+// as low, when the chain sets no min_balance
+const lowBalanceFeeMultiple = 10
+
+// WalletBalance is the voting wallet's balance of the chain's fee denom
+type WalletBalance struct {
+	Address   string
+	Denom     string
+	Amount    string // Base units
+	Threshold string // Base units below which the balance is reported as low
+}
+
+// Low reports whether the balance is below the fee threshold
+func (b *WalletBalance) Low() bool {
+	amount, ok := new(big.Int).SetString(b.Amount, 10)
+	if !ok {
+		return false
+	}
+	threshold, ok := new(big.Int).SetString(b.Threshold, 10)
+	if !ok {
+		return false
+	}
+	return amount.Cmp(threshold) < 0
+}
+
+// QueryBalance returns the configured wallet's balance of the chain's fee denom
+func (v *Voter) QueryBalance(chainID string) (*WalletBalance, error) {
+	chainConfig := v.chains[chainID]
+
+	if chainConfig == nil {
+		return nil, fmt.Errorf("chain %s not found in configuration", chainID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	address, err := v.getAddressForKey(ctx, chainConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	feeAmount, denom, err := parseCoin(v.calculateFees(chainConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fee: %w", err)
+	}
+
+	amount, err := v.fetchBalance(ctx, chainConfig, address, denom)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := chainConfig.MinBalance
+	if threshold == "" {
+		threshold = new(big.Int).Mul(feeAmount, big.NewInt(lowBalanceFeeMultiple)).String()
+	}
+
+	return &WalletBalance{
+		Address:   address,
+		Denom:     denom,
+		Amount:    amount,
+		Threshold: threshold,
+	}, nil
+}
+
+// fetchBalance queries the bank module for address's balance of denom in base units
+func (v *Voter) fetchBalance(ctx context.Context, chain *config.ChainConfig, address, denom string) (string, error) {
+	type balancesResponse struct {
+		Balances []struct {
+			Denom  string `json:"denom"`
+			Amount string `json:"amount"`
+		} `json:"balances"`
+	}
+
+	statusCode, body, err := v.getREST(ctx, chain, "/cosmos/bank/v1beta1/balances/"+address, "Querying wallet balance")
+	if err != nil {
+		return "", err
+	}
+	if statusCode != http.StatusOK {
+		return "", fmt.Errorf("balance query failed: status %d - body: %s", statusCode, string(body))
+	}
+
+	var br balancesResponse
+	if err := json.Unmarshal(body, &br); err != nil {
+		return "", fmt.Errorf("failed to parse balance response: %w - body: %s", err, string(body))
+	}
+
+	for _, coin := range br.Balances {
+		if coin.Denom == denom {
+			return coin.Amount, nil
+		}
+	}
+	return "0", nil
+}
+
+// checkFeeBalance fails when voting.check_balance is set and the wallet can't cover the vote
+// fee. A failed balance query is only logged so it never blocks a vote that might succeed.
+func (v *Voter) checkFeeBalance(ctx context.Context, chain *config.ChainConfig, address string) error {
+	if !v.config.Voting.CheckBalance {
+		return nil
+	}
+
+	fee := v.calculateFees(chain)
+	feeAmount, denom, err := parseCoin(fee)
+	if err != nil {
+		v.logger.Warn("Skipping balance check: failed to parse fee",
+			zap.String("chain", chain.GetName()),
+			zap.String("fee", fee),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	amount, err := v.fetchBalance(ctx, chain, address, denom)
+	if err != nil {
+		v.logger.Warn("Skipping balance check: failed to query balance",
+			zap.String("chain", chain.GetName()),
+			zap.String("address", address),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	balance, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		v.logger.Warn("Skipping balance check: invalid balance amount",
+			zap.String("chain", chain.GetName()),
+			zap.String("amount", amount),
+		)
+		return nil
+	}
+
+	if balance.Cmp(feeAmount) < 0 {
+		return fmt.Errorf("insufficient balance: %s holds %s%s but the vote fee is %s", address, amount, denom, fee)
+	}
+	return nil
+}
+
+// coinPattern matches an amount followed by a denom, e.g. 5000uatom or 10ibc/27394F...
+var coinPattern = regexp.MustCompile(`^([0-9]+)([a-zA-Z][a-zA-Z0-9/:._-]*)$`)
+
+// parseCoin splits a coin string such as 5000uatom into its amount and denom
+func parseCoin(coin string) (*big.Int, string, error) {
+	match := coinPattern.FindStringSubmatch(strings.TrimSpace(coin))
+	if match == nil {
+		return nil, "", fmt.Errorf("invalid coin %q", coin)
+	}
+	amount, _ := new(big.Int).SetString(match[1], 10)
+	return amount, match[2], nil
+}
+
 // TxResponse represents the essential fields from CLI transaction output
 type TxResponse struct {
 	TxHash    string `json:"txhash"`
 	Code      int    `json:"code"`
 	Codespace string `json:"codespace"`
+	RawLog    string `json:"raw_log"`
+	Height    string `json:"height"` // Set once the tx is committed
+}
+
+// BlockHeight returns the height the tx was committed at, or 0 if it isn't known
+func (r *TxResponse) BlockHeight() int64 {
+	height, _ := strconv.ParseInt(r.Height, 10, 64)
+	return height
+}
+
+// codeSequenceMismatch is the SDK error code for an account sequence mismatch
+const codeSequenceMismatch = 32
+
+// IsSequenceMismatch reports whether the tx was rejected for a wrong account sequence
+func (r *TxResponse) IsSequenceMismatch() bool {
+	return r.Code == codeSequenceMismatch && (r.Codespace == "sdk" || r.Codespace == "")
 }
 
-// parseTxResponse parses the CLI JSON output to extract transaction details
+// parseTxResponse parses the CLI JSON output to extract transaction details. The JSON may be
+// preceded or followed by other text (gas estimates, warnings), pretty-printed, or wrapped
+// across lines by the terminal
 func (v *Voter) parseTxResponse(output string) (*TxResponse, error) {
-	// Try to find JSON in the output (might have other text before/after)
-	lines := strings.Split(output, "\n")
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "{") && strings.HasSuffix(line, "}") {
-			var txResp TxResponse
-			if err := json.Unmarshal([]byte(line), &txResp); err == nil {
-				// Validate we got the essential fields
-				if txResp.TxHash != "" {
-					return &txResp, nil
-				}
+	for start := strings.IndexByte(output, '{'); start >= 0; {
+		if span, ok := jsonObjectSpan(output[start:]); ok {
+			if txResp, ok := unmarshalTxResponse(span); ok {
+				return txResp, nil
 			}
 		}
+
+		// Try the next object, including any nested inside a wrapper
+		next := strings.IndexByte(output[start+1:], '{')
+		if next < 0 {
+			break
+		}
+		start += 1 + next
 	}
 
 	return nil, fmt.Errorf("no valid JSON transaction response found in output")
 }
 
-// ValidateChainCLI validates that the CLI tool for a chain is available
+// jsonObjectSpan returns the balanced {...} span at the start of s, ignoring braces inside
+// JSON strings
+func jsonObjectSpan(s string) (string, bool) {
+	depth := 0
+	inString, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return s[:i+1], true
+			}
+		}
+	}
+	return "", false
+}
+
+// unmarshalTxResponse decodes span as a tx response with a tx hash. Line breaks a terminal
+// inserted inside string values make the JSON invalid, so it retries without them
+func unmarshalTxResponse(span string) (*TxResponse, bool) {
+	for _, candidate := range []string{span, strings.NewReplacer("\r", "", "\n", "").Replace(span)} {
+		var txResp TxResponse
+		if err := json.Unmarshal([]byte(candidate), &txResp); err == nil {
+			// Validate we got the essential fields
+			return &txResp, txResp.TxHash != ""
+		}
+	}
+	return nil, false
+}
+
+// ValidateChainCLI validates that the CLI tool for a chain is available; chains that sign
+// natively never run it to vote
 func (v *Voter) ValidateChainCLI(chain config.ChainConfig) error {
+	if chain.UsesNativeSigning() {
+		v.logger.Debug("CLI validation skipped for native signing", zap.String("chain", chain.GetName()))
+		return nil
+	}
+
 	cliPath := v.getBinaryPath(chain.GetCLIName())
 	cmd := exec.Command(cliPath, "version")
 	output, err := cmd.CombinedOutput()
@@ -562,15 +1801,11 @@ func (v *Voter) ValidateChainCLI(chain config.ChainConfig) error {
 
 // ValidateWalletKey validates that the wallet key exists for a chain
 func (v *Voter) ValidateWalletKey(chain config.ChainConfig) error {
-	cliPath := v.getBinaryPath(chain.GetCLIName())
-	cmd := exec.Command(cliPath, "keys", "show", chain.WalletKey, "--address", "--keyring-backend", "test")
-	output, err := cmd.CombinedOutput()
+	address, err := v.walletKeyAddress(chain)
 	if err != nil {
-		return fmt.Errorf("wallet key %s not found for chain %s: %w - output: %s",
-			chain.WalletKey, chain.GetName(), err, string(output))
+		return err
 	}
 
-	address := strings.TrimSpace(string(output))
 	if !strings.HasPrefix(address, chain.GetPrefix()) {
 		return fmt.Errorf("wallet address %s does not match expected prefix %s for chain %s",
 			address, chain.GetPrefix(), chain.GetName())
@@ -585,6 +1820,28 @@ func (v *Voter) ValidateWalletKey(chain config.ChainConfig) error {
 	return nil
 }
 
+// walletKeyAddress returns the wallet key's address: derived from the stored mnemonic for
+// native signing, otherwise read from the CLI keyring
+func (v *Voter) walletKeyAddress(chain config.ChainConfig) (string, error) {
+	if chain.UsesNativeSigning() {
+		_, address, err := v.nativeKey(&chain)
+		if err != nil {
+			return "", fmt.Errorf("wallet key %s not found for chain %s: %w", chain.WalletKey, chain.GetName(), err)
+		}
+		return address, nil
+	}
+
+	cliPath := v.getBinaryPath(chain.GetCLIName())
+	args := append([]string{"keys", "show", chain.WalletKey, "--address"}, v.keyringArgs(&chain)...)
+	cmd := exec.Command(cliPath, args...)
+	output, err := v.runKeyringCommand(cmd, &chain)
+	if err != nil {
+		return "", fmt.Errorf("wallet key %s not found for chain %s: %w - output: %s",
+			chain.WalletKey, chain.GetName(), err, string(output))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // ValidateAllChains validates CLI tools and wallet keys for all configured chains
 func (v *Voter) ValidateAllChains() error {
 	for _, chain := range v.config.Chains {
@@ -600,3 +1857,99 @@ func (v *Voter) ValidateAllChains() error {
 	v.logger.Info("All chains validated successfully")
 	return nil
 }
+
+// EndpointCheck holds the reachability result of a chain's REST and RPC endpoints, fallbacks
+// included
+type EndpointCheck struct {
+	Chain          string
+	RESTErr        error // Every REST endpoint that failed, or nil if all responded
+	RPCErr         error // Every RPC endpoint that failed, or nil if all responded
+	APIKeyRejected bool  // An endpoint answered 401/403 while api_key auth is enabled
+}
+
+// OK reports whether every endpoint responded successfully
+func (c EndpointCheck) OK() bool {
+	return c.RESTErr == nil && c.RPCErr == nil
+}
+
+// CheckEndpoints verifies that each of a chain's REST (node_info) and RPC (status) endpoints,
+// primary and fallbacks, responds
+func (v *Voter) CheckEndpoints(ctx context.Context, chain config.ChainConfig) EndpointCheck {
+	authEnabled := v.config.AuthEndpoints.IsActive()
+
+	restRejected, restErr := v.checkEndpointList(ctx, "REST", "node_info", chain.GetRESTEndpoints(), true,
+		func(endpoint string) string {
+			return v.appendAPIKeyIfEnabled(strings.TrimRight(endpoint, "/") + "/cosmos/base/tendermint/v1beta1/node_info")
+		})
+	rpcRejected, rpcErr := v.checkEndpointList(ctx, "RPC", "status", chain.GetRPCEndpoints(), v.config.AuthEndpoints.ApplyToRPC,
+		func(endpoint string) string {
+			return v.appendAPIKeyForRPC(strings.TrimRight(endpoint, "/") + "/status")
+		})
+
+	return EndpointCheck{
+		Chain:          chain.GetName(),
+		RESTErr:        restErr,
+		RPCErr:         rpcErr,
+		APIKeyRejected: authEnabled && (restRejected || (v.config.AuthEndpoints.ApplyToRPC && rpcRejected)),
+	}
+}
+
+// checkEndpointList probes each endpoint at the URL built by probeURL, returning the failures
+// whether any endpoint answered 401/403 and the failures joined into one error
+func (v *Voter) checkEndpointList(ctx context.Context, kind, query string, endpoints []string, withKey bool, probeURL func(string) string) (bool, error) {
+	if len(endpoints) == 0 {
+		return false, fmt.Errorf("no %s endpoint configured", kind)
+	}
+
+	var failures []string
+	rejected := false
+	for _, endpoint := range endpoints {
+		status, err := v.probeEndpoint(ctx, probeURL(endpoint), withKey)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", endpoint, err))
+		} else if status < 200 || status >= 300 {
+			failures = append(failures, fmt.Sprintf("%s: %s %s returned status %d", endpoint, kind, query, status))
+			if status == http.StatusUnauthorized || status == http.StatusForbidden {
+				rejected = true
+			}
+		}
+	}
+
+	if len(failures) == 0 {
+		return false, nil
+	}
+	return rejected, errors.New(strings.Join(failures, "; "))
+}
+
+// CheckAllEndpoints verifies endpoint reachability for all configured chains
+func (v *Voter) CheckAllEndpoints(ctx context.Context) []EndpointCheck {
+	results := make([]EndpointCheck, 0, len(v.config.Chains))
+	for _, chain := range v.config.Chains {
+		results = append(results, v.CheckEndpoints(ctx, chain))
+	}
+	return results
+}
+
+// probeEndpoint performs a GET request and returns the response status code; withKey sends
+// the API key header when header-style auth is configured
+func (v *Voter) probeEndpoint(ctx context.Context, url string, withKey bool) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("User-Agent", version.UserAgent())
+	if withKey {
+		v.config.AuthEndpoints.SetHeader(req.Header)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("endpoint unreachable: %w", err)
+	}
+	defer func(body io.ReadCloser) { _ = body.Close() }(resp.Body)
+
+	return resp.StatusCode, nil
+}
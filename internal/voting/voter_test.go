@@ -2,13 +2,26 @@ package voting
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"prop-voter/config"
+	"prop-voter/internal/models"
 
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestNewVoter(t *testing.T) {
@@ -142,6 +155,27 @@ func TestParseTxResponse(t *testing.T) {
 			expectedCode: 0,
 			expectError:  false,
 		},
+		{
+			name:         "Pretty-printed JSON after warnings",
+			output:       "WARNING: config file not found, using defaults\n{\n  \"height\": \"0\",\n  \"txhash\": \"9C1A4F00D6B3E1C24C5BB4A2F8E4F7B1D0C0A7B2E1F6C4D3A2B1C0D9E8F7A6B5\",\n  \"codespace\": \"gov\",\n  \"code\": 3,\n  \"raw_log\": \"inactive proposal {id: 7}\"\n}\n",
+			expectedHash: "9C1A4F00D6B3E1C24C5BB4A2F8E4F7B1D0C0A7B2E1F6C4D3A2B1C0D9E8F7A6B5",
+			expectedCode: 3,
+			expectError:  false,
+		},
+		{
+			name:         "JSON wrapped across lines",
+			output:       "{\"height\":\"0\",\"txhash\":\"F07BDD31E6CF3D3BCF0C0BCCB0ECA10802548F8E4\nDB052ACA1BE4C074FE34295\",\"code\":0,\n\"codespace\":\"\"}",
+			expectedHash: "F07BDD31E6CF3D3BCF0C0BCCB0ECA10802548F8E4DB052ACA1BE4C074FE34295",
+			expectedCode: 0,
+			expectError:  false,
+		},
+		{
+			name:         "Other JSON before the response",
+			output:       "{\"gas_estimate\":116065}\n{\"height\":\"0\",\"txhash\":\"ABCDEF1234567890ABCDEF1234567890ABCDEF1234567890ABCDEF1234567890\",\"code\":0} trailing text",
+			expectedHash: "ABCDEF1234567890ABCDEF1234567890ABCDEF1234567890ABCDEF1234567890",
+			expectedCode: 0,
+			expectError:  false,
+		},
 		{
 			name:        "No valid JSON found",
 			output:      "Some other output without valid JSON",
@@ -198,7 +232,7 @@ func TestVoteChainNotFound(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	voter := NewVoter(cfg, logger)
 
-	_, err := voter.Vote("non-existent-chain", "123", "yes")
+	_, err := voter.Vote("non-existent-chain", "123", "yes", TxOptions{})
 	if err == nil {
 		t.Error("Expected error for non-existent chain")
 	}
@@ -209,6 +243,115 @@ func TestVoteChainNotFound(t *testing.T) {
 	}
 }
 
+func TestSimulateVoteChainNotFound(t *testing.T) {
+	cfg := &config.Config{}
+	logger := zaptest.NewLogger(t)
+	voter := NewVoter(cfg, logger)
+
+	_, err := voter.SimulateVote("non-existent-chain", "123", "yes", TxOptions{})
+	if err == nil {
+		t.Fatal("Expected error for non-existent chain")
+	}
+	if !strings.Contains(err.Error(), "chain non-existent-chain not found in configuration") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestSimulateTxBytesREST(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/tx/v1beta1/simulate" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"gas_info":{"gas_wanted":"0","gas_used":"100000"},"result":{}}`))
+	}))
+	defer server.Close()
+
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+	chain := &config.ChainConfig{REST: server.URL}
+
+	result, err := voter.simulateTxBytesREST(context.Background(), chain, "dHhieXRlcw==")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.GasUsed != 100000 {
+		t.Errorf("Expected gas used 100000, got %d", result.GasUsed)
+	}
+	if result.EstimatedGas != 130000 {
+		t.Errorf("Expected estimated gas 130000, got %d", result.EstimatedGas)
+	}
+}
+
+func TestBroadcastTxBytesRESTFailover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tx_response":{"txhash":"ABC123","code":0}}`))
+	}))
+	defer up.Close()
+
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+	chain := &config.ChainConfig{REST: down.URL, RESTFallbacks: []string{"http://127.0.0.1:1", up.URL}}
+
+	txResp, err := voter.broadcastTxBytesREST(context.Background(), chain, "dHhieXRlcw==")
+	if err != nil {
+		t.Fatalf("Expected failover to succeed, got: %v", err)
+	}
+	if txResp.TxHash != "ABC123" {
+		t.Errorf("Expected tx hash 'ABC123', got '%s'", txResp.TxHash)
+	}
+}
+
+func TestGetRESTContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+	if voter.httpClient.Timeout != 0 {
+		t.Errorf("Expected the shared client to leave timeouts to the caller's context, got %s", voter.httpClient.Timeout)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, _, err := voter.getREST(ctx, &config.ChainConfig{REST: server.URL}, "/cosmos/gov/v1/proposals/1", "Querying proposal"); err == nil {
+		t.Fatal("Expected the request to fail once the context deadline passed")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the context deadline to end the request, took %s", elapsed)
+	}
+}
+
+func TestSimulateTxBytesRESTError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":5,"message":"insufficient funds"}`))
+	}))
+	defer server.Close()
+
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+	chain := &config.ChainConfig{REST: server.URL}
+
+	_, err := voter.simulateTxBytesREST(context.Background(), chain, "dHhieXRlcw==")
+	if err == nil {
+		t.Fatal("Expected error for failed simulation")
+	}
+	if !strings.Contains(err.Error(), "insufficient funds") {
+		t.Errorf("Expected error to include response body, got: %v", err)
+	}
+}
+
 // Note: Testing actual CLI execution would require the CLI tools to be installed
 // and configured, so we'll test the command building logic instead
 
@@ -323,7 +466,7 @@ func TestVoteAuthzChainNotFound(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	voter := NewVoter(cfg, logger)
 
-	_, err := voter.VoteAuthz("non-existent-chain", "123", "yes")
+	_, err := voter.VoteAuthz("non-existent-chain", "123", "yes", "", TxOptions{})
 	if err == nil {
 		t.Error("Expected error for non-existent chain")
 	}
@@ -351,7 +494,7 @@ func TestVoteAuthzNotEnabled(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	voter := NewVoter(cfg, logger)
 
-	_, err := voter.VoteAuthz("test-1", "123", "yes")
+	_, err := voter.VoteAuthz("test-1", "123", "yes", "", TxOptions{})
 	if err == nil {
 		t.Error("Expected error when authz is not enabled")
 	}
@@ -379,7 +522,7 @@ func TestVoteAuthzAuthzEnabledButNoGranterAddr(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	voter := NewVoter(cfg, logger)
 
-	_, err := voter.VoteAuthz("test-1", "123", "yes")
+	_, err := voter.VoteAuthz("test-1", "123", "yes", "", TxOptions{})
 	if err == nil {
 		t.Error("Expected error when authz is enabled but no granter address")
 	}
@@ -410,7 +553,11 @@ func TestBuildAuthzVoteCommand(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	cmd := voter.buildAuthzVoteCommandWithContext(ctx, chain, "123", "yes")
+	cmd, cleanup, err := voter.buildAuthzVoteCommandWithContext(ctx, chain, "123", "yes")
+	if err != nil {
+		t.Fatalf("Failed to build authz vote command: %v", err)
+	}
+	defer cleanup()
 
 	expectedCommand := "testd"
 	if cmd.Args[0] != expectedCommand {
@@ -419,7 +566,7 @@ func TestBuildAuthzVoteCommand(t *testing.T) {
 
 	expectedArgs := []string{
 		"tx", "authz", "exec",
-		"/tmp/vote_msg_test-1_123.json", // message file path
+		cmd.Args[4], // message file path, checked below
 		"--from", "grantee-key",
 		"--chain-id", "test-1",
 		"--node", "http://localhost:26657",
@@ -441,9 +588,89 @@ func TestBuildAuthzVoteCommand(t *testing.T) {
 		}
 	}
 
-	// Clean up the temporary file that might have been created
-	msgFile := "/tmp/vote_msg_test-1_123.json"
-	_ = os.Remove(msgFile) // Ignore error in test cleanup
+	// The message file is private and lives in a per-operation directory
+	msgFile := cmd.Args[4]
+	if filepath.Base(msgFile) != "vote_msg.json" || !strings.HasPrefix(filepath.Base(filepath.Dir(msgFile)), "prop-voter-tx-") {
+		t.Errorf("Unexpected message file path %s", msgFile)
+	}
+	info, err := os.Stat(msgFile)
+	if err != nil {
+		t.Fatalf("Expected message file to exist: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("Expected message file mode 0600, got %o", perm)
+	}
+	dirInfo, err := os.Stat(filepath.Dir(msgFile))
+	if err != nil {
+		t.Fatalf("Expected work directory to exist: %v", err)
+	}
+	if perm := dirInfo.Mode().Perm(); perm != 0700 {
+		t.Errorf("Expected work directory mode 0700, got %o", perm)
+	}
+
+	cleanup()
+	if _, err := os.Stat(filepath.Dir(msgFile)); !os.IsNotExist(err) {
+		t.Errorf("Expected cleanup to remove the work directory, got: %v", err)
+	}
+}
+
+func TestGetBinaryPathCustomBinDir(t *testing.T) {
+	binDir := t.TempDir()
+	managed := filepath.Join(binDir, "testd")
+	if err := os.WriteFile(managed, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("Failed to create managed binary: %v", err)
+	}
+
+	voter := NewVoter(&config.Config{BinaryManager: config.BinaryMgrConfig{BinDir: binDir}}, zaptest.NewLogger(t))
+
+	if got := voter.getBinaryPath("testd"); got != managed {
+		t.Errorf("Expected the managed binary %s, got %s", managed, got)
+	}
+
+	// Binaries binmgr doesn't manage still come from PATH
+	if got := voter.getBinaryPath("otherd"); got != "otherd" {
+		t.Errorf("Expected PATH fallback 'otherd', got %s", got)
+	}
+}
+
+func TestLoggedCommandsRedactSecrets(t *testing.T) {
+	const apiKey = "node-secret-123"
+
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "testd"), []byte("#!/bin/sh\necho '{}'\n"), 0755); err != nil {
+		t.Fatalf("Failed to create fake CLI: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	cfg := &config.Config{
+		AuthEndpoints: config.AuthEndpointsConfig{Enabled: true, APIKey: apiKey},
+		BinaryManager: config.BinaryMgrConfig{BinDir: binDir},
+	}
+	voter := NewVoter(cfg, zap.New(core))
+	chain := &config.ChainConfig{Name: "Test", ChainID: "test-1", CLIName: "testd", RPC: server.URL, REST: server.URL}
+
+	args := []string{"tx", "gov", "vote", "1", "yes", "--node", voter.appendAPIKeyIfEnabled(chain.RPC)}
+	if err := voter.execToFileWithContext(context.Background(), chain, args, filepath.Join(t.TempDir(), "out.json")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, _, err := voter.getREST(context.Background(), chain, "/cosmos/gov/v1/proposals/1", "Querying proposal"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if logs.Len() == 0 {
+		t.Fatal("Expected the command and URL to be logged")
+	}
+	for _, entry := range logs.All() {
+		for key, value := range entry.ContextMap() {
+			if strings.Contains(fmt.Sprint(value), apiKey) {
+				t.Errorf("Log %q leaked the API key in %s: %v", entry.Message, key, value)
+			}
+		}
+	}
 }
 
 func TestMapVoteOption(t *testing.T) {
@@ -497,10 +724,14 @@ func TestAuthzVoteMessageGeneration(t *testing.T) {
 	option := "yes"
 
 	// Build the command which should create the message file
-	cmd := voter.buildAuthzVoteCommandWithContext(ctx, chain, proposalID, option)
+	cmd, cleanup, err := voter.buildAuthzVoteCommandWithContext(ctx, chain, proposalID, option)
+	if err != nil {
+		t.Fatalf("Failed to build authz vote command: %v", err)
+	}
+	defer cleanup()
 
 	// Check that the temporary file was created and has correct content
-	msgFile := "/tmp/vote_msg_test-1_123.json"
+	msgFile := cmd.Args[4]
 
 	// The file should exist after building the command
 	if _, err := os.Stat(msgFile); os.IsNotExist(err) {
@@ -528,15 +759,101 @@ func TestAuthzVoteMessageGeneration(t *testing.T) {
 		}
 	}
 
-	// Clean up
-	_ = os.Remove(msgFile) // Ignore error in test cleanup
-
 	// Verify command args
 	if cmd.Args[0] != "testd" { // Default CLI name from getBinaryPath
 		t.Errorf("Expected command to use CLI tool, got: %s", cmd.Args[0])
 	}
 }
 
+func TestAuthzVoteMsg(t *testing.T) {
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+
+	type message struct {
+		Type       string  `json:"@type"`
+		ProposalID string  `json:"proposal_id"`
+		Voter      string  `json:"voter"`
+		Option     string  `json:"option"`
+		Metadata   *string `json:"metadata"`
+	}
+	decode := func(t *testing.T, data []byte) message {
+		var tx struct {
+			Body struct {
+				Messages []message `json:"messages"`
+			} `json:"body"`
+		}
+		if err := json.Unmarshal(data, &tx); err != nil {
+			t.Fatalf("Failed to parse message: %v\n%s", err, data)
+		}
+		if len(tx.Body.Messages) != 1 {
+			t.Fatalf("Expected one message, got %d", len(tx.Body.Messages))
+		}
+		return tx.Body.Messages[0]
+	}
+
+	data, err := voter.authzVoteMsg(MsgVoteV1TypeURL, "42", "cosmos1granter", "no_with_veto")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	msg := decode(t, data)
+	if msg.Type != MsgVoteV1TypeURL || msg.ProposalID != "42" || msg.Voter != "cosmos1granter" || msg.Option != "VOTE_OPTION_NO_WITH_VETO" {
+		t.Errorf("Unexpected v1 message: %+v", msg)
+	}
+	if msg.Metadata == nil || *msg.Metadata != "" {
+		t.Errorf("Expected an empty metadata field on the v1 message, got %v", msg.Metadata)
+	}
+
+	data, err = voter.authzVoteMsg(MsgVoteV1Beta1TypeURL, "42", "cosmos1granter", "yes")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	msg = decode(t, data)
+	if msg.Type != MsgVoteV1Beta1TypeURL || msg.Option != "VOTE_OPTION_YES" {
+		t.Errorf("Unexpected v1beta1 message: %+v", msg)
+	}
+	if msg.Metadata != nil {
+		t.Errorf("Expected no metadata field on the v1beta1 message, got %q", *msg.Metadata)
+	}
+}
+
+func TestAuthzVoteMsgType(t *testing.T) {
+	v1Supported := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/gov/v1/proposals/42" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		if !v1Supported {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		w.Write([]byte(`{"proposal":{"id":"42"}}`))
+	}))
+	defer server.Close()
+
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+	ctx := context.Background()
+
+	chain := &config.ChainConfig{Name: "Test Chain", REST: server.URL}
+	if got := voter.authzVoteMsgType(ctx, chain, "42", "", ""); got != MsgVoteV1TypeURL {
+		t.Errorf("Expected v1 when the chain serves gov v1, got %s", got)
+	}
+
+	v1Supported = false
+	if got := voter.authzVoteMsgType(ctx, chain, "42", "", ""); got != MsgVoteV1Beta1TypeURL {
+		t.Errorf("Expected v1beta1 fallback, got %s", got)
+	}
+
+	// A configured version skips the probe
+	chain.Authz.MsgVersion = config.GovVersionV1
+	if got := voter.authzVoteMsgType(ctx, chain, "42", "", ""); got != MsgVoteV1TypeURL {
+		t.Errorf("Expected the configured v1, got %s", got)
+	}
+	chain.Authz.MsgVersion = config.GovVersionV1Beta1
+	chain.REST = ""
+	if got := voter.authzVoteMsgType(ctx, chain, "42", "", ""); got != MsgVoteV1Beta1TypeURL {
+		t.Errorf("Expected the configured v1beta1, got %s", got)
+	}
+}
+
 func BenchmarkParseTxResponse(b *testing.B) {
 	cfg := &config.Config{}
 	logger := zaptest.NewLogger(b)
@@ -549,3 +866,923 @@ func BenchmarkParseTxResponse(b *testing.B) {
 		_, _ = voter.parseTxResponse(output) // Benchmark doesn't need error checking
 	}
 }
+
+func TestCheckEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/base/tendermint/v1beta1/node_info", "/status":
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+	check := voter.CheckEndpoints(context.Background(), config.ChainConfig{Name: "Test", REST: server.URL, RPC: server.URL})
+	if !check.OK() {
+		t.Errorf("Expected endpoints to be reachable, got REST: %v, RPC: %v", check.RESTErr, check.RPCErr)
+	}
+}
+
+func TestCheckEndpointsAPIKeyRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("api_key") != "good-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AuthEndpoints: config.AuthEndpointsConfig{Enabled: true, APIKey: "bad-key"}}
+	voter := NewVoter(cfg, zaptest.NewLogger(t))
+	check := voter.CheckEndpoints(context.Background(), config.ChainConfig{Name: "Test", REST: server.URL, RPC: server.URL})
+
+	if check.RESTErr == nil {
+		t.Error("Expected REST check to fail with a rejected API key")
+	}
+	if !check.APIKeyRejected {
+		t.Error("Expected API key to be reported as rejected")
+	}
+}
+
+func TestCheckEndpointsAuthHeader(t *testing.T) {
+	var restKey, rpcKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("Expected no query string with header auth, got %q", r.URL.RawQuery)
+		}
+		if r.URL.Path == "/status" {
+			rpcKey = r.Header.Get("Authorization")
+		} else {
+			restKey = r.Header.Get("Authorization")
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AuthEndpoints: config.AuthEndpointsConfig{
+		Enabled: true, APIKey: "Bearer good-key", Style: config.AuthStyleHeader, HeaderName: "Authorization",
+	}}
+	voter := NewVoter(cfg, zaptest.NewLogger(t))
+	if check := voter.CheckEndpoints(context.Background(), config.ChainConfig{Name: "Test", REST: server.URL, RPC: server.URL}); !check.OK() {
+		t.Fatalf("Expected endpoints to be reachable, got REST: %v, RPC: %v", check.RESTErr, check.RPCErr)
+	}
+
+	if restKey != "Bearer good-key" {
+		t.Errorf("Expected the REST probe to send the key header, got %q", restKey)
+	}
+	if rpcKey != "" {
+		t.Errorf("Expected no key on RPC without apply_to_rpc, got %q", rpcKey)
+	}
+}
+
+func TestCheckEndpointsFallbacks(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer healthy.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer broken.Close()
+
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+	check := voter.CheckEndpoints(context.Background(), config.ChainConfig{
+		Name:          "Test",
+		REST:          healthy.URL,
+		RESTFallbacks: []string{broken.URL},
+		RPC:           healthy.URL,
+		RPCFallbacks:  []string{healthy.URL + "/"},
+	})
+
+	if check.RESTErr == nil || !strings.Contains(check.RESTErr.Error(), broken.URL) {
+		t.Errorf("Expected the broken REST fallback to be reported, got %v", check.RESTErr)
+	}
+	if check.RPCErr != nil {
+		t.Errorf("Expected every RPC endpoint to be reachable, got %v", check.RPCErr)
+	}
+}
+
+func TestCheckEndpointsUnreachable(t *testing.T) {
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+	check := voter.CheckEndpoints(context.Background(), config.ChainConfig{Name: "Test", REST: "http://127.0.0.1:1", RPC: "http://127.0.0.1:1"})
+
+	if check.RESTErr == nil || check.RPCErr == nil {
+		t.Error("Expected both endpoint checks to fail for an unreachable host")
+	}
+	if check.APIKeyRejected {
+		t.Error("Expected API key not to be reported as rejected for connection errors")
+	}
+}
+
+func TestGasArgs(t *testing.T) {
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+
+	auto := &config.ChainConfig{ChainID: "test-1"}
+	fixed := &config.ChainConfig{ChainID: "test-1", GasLimit: 350000}
+
+	tests := []struct {
+		name     string
+		chain    *config.ChainConfig
+		opts     TxOptions
+		expected []string
+	}{
+		{"simulate by default", auto, TxOptions{}, []string{"--gas", "auto", "--gas-adjustment", "1.3"}},
+		{"chain gas limit", fixed, TxOptions{}, []string{"--gas", "350000"}},
+		{"per-vote override beats chain limit", fixed, TxOptions{Gas: "500000"}, []string{"--gas", "500000"}},
+		{"fixed uses chain limit", fixed, TxOptions{Gas: GasFixed}, []string{"--gas", "350000"}},
+		{"fixed falls back to default limit", auto, TxOptions{Gas: GasFixed}, []string{"--gas", "200000"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := voter.gasArgs(tt.chain, tt.opts)
+			if strings.Join(got, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateGas(t *testing.T) {
+	for _, gas := range []string{"", GasFixed, "250000"} {
+		if err := ValidateGas(gas); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", gas, err)
+		}
+	}
+	for _, gas := range []string{"0", "-1", "lots", "1.5"} {
+		if err := ValidateGas(gas); err == nil {
+			t.Errorf("Expected %q to be rejected", gas)
+		}
+	}
+
+	voter := NewVoter(&config.Config{Chains: []config.ChainConfig{{Name: "Test Chain", ChainID: "test-1"}}}, zaptest.NewLogger(t))
+	if _, err := voter.Vote("test-1", "123", "yes", TxOptions{Gas: "lots"}); err == nil || !strings.Contains(err.Error(), "invalid gas override") {
+		t.Errorf("Expected invalid gas override error, got %v", err)
+	}
+}
+
+func TestMemoArgs(t *testing.T) {
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+
+	plain := &config.ChainConfig{ChainID: "test-1"}
+	templated := &config.ChainConfig{ChainID: "test-1", DefaultMemo: "Voted {option} on {chain_id} #{proposal_id} via prop-voter"}
+
+	if got := voter.memoArgs(plain, "123", "yes", TxOptions{}); got != nil {
+		t.Errorf("Expected no memo flag, got %v", got)
+	}
+
+	got := voter.memoArgs(templated, "123", "yes", TxOptions{})
+	if strings.Join(got, " ") != "--note Voted yes on test-1 #123 via prop-voter" {
+		t.Errorf("Expected rendered default memo, got %v", got)
+	}
+
+	got = voter.memoArgs(templated, "123", "yes", TxOptions{Memo: "Custom reason"})
+	if strings.Join(got, " ") != "--note Custom reason" {
+		t.Errorf("Expected per-vote memo to override default, got %v", got)
+	}
+
+	if err := (TxOptions{Memo: strings.Repeat("x", MaxMemoLength+1)}).Validate(); err == nil {
+		t.Error("Expected error for memo exceeding max length")
+	}
+	// Over-long templates are cut on a rune boundary, never mid-character
+	accented := &config.ChainConfig{ChainID: "test-1", DefaultMemo: "x" + strings.Repeat("é", MaxMemoLength)}
+	memo := voteMemo(accented, "123", "yes", TxOptions{})
+	if !utf8.ValidString(memo) || len(memo) != MaxMemoLength-1 {
+		t.Errorf("Expected a valid UTF-8 memo of %d bytes, got %d bytes (valid %v)", MaxMemoLength-1, len(memo), utf8.ValidString(memo))
+	}
+}
+
+func TestSignerArgsLedger(t *testing.T) {
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+
+	software := &config.ChainConfig{ChainID: "test-1"}
+	ledger := &config.ChainConfig{ChainID: "test-1", UseLedger: true}
+
+	if got := strings.Join(voter.signerArgs(software), " "); got != "--keyring-backend test" {
+		t.Errorf("Expected test keyring for software key, got %q", got)
+	}
+	if got := strings.Join(voter.keyringArgs(ledger), " "); got != "--keyring-backend os" {
+		t.Errorf("Expected os keyring for Ledger key lookups, got %q", got)
+	}
+	if got := strings.Join(voter.signerArgs(ledger), " "); got != "--keyring-backend os --ledger" {
+		t.Errorf("Expected --ledger when signing with a Ledger, got %q", got)
+	}
+
+	if voteTimeout(ledger) != LedgerVoteTimeout || voteTimeout(software) >= LedgerVoteTimeout {
+		t.Error("Expected Ledger chains to allow longer for on-device confirmation")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+	if err := voter.ledgerSignError(ctx, ledger, context.DeadlineExceeded); !strings.Contains(err.Error(), "timed out waiting for Ledger confirmation") {
+		t.Errorf("Expected Ledger timeout message, got %v", err)
+	}
+}
+
+func TestRunKeyringCommandFileBackend(t *testing.T) {
+	voter := NewVoter(&config.Config{KeyManager: config.KeyMgrConfig{KeyringBackend: config.FileKeyringBackend}}, zaptest.NewLogger(t))
+	chain := &config.ChainConfig{ChainID: "test-1"}
+
+	// Prompts go to stderr and must not leak into the parsed output
+	script := "echo 'Enter keyring passphrase:' >&2; read pass; echo \"address-for-$pass\""
+
+	t.Setenv(config.KeyringPassphraseEnvVar, "")
+	if _, err := voter.runKeyringCommand(exec.Command("sh", "-c", script), chain); err == nil {
+		t.Error("Expected error without a keyring passphrase")
+	}
+
+	t.Setenv(config.KeyringPassphraseEnvVar, "hunter2")
+	output, err := voter.runKeyringCommand(exec.Command("sh", "-c", script), chain)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(string(output)) != "address-for-hunter2" {
+		t.Errorf("Expected only stdout with the passphrase applied, got %q", output)
+	}
+
+	if got := strings.Join(voter.keyringArgs(chain), " "); got != "--keyring-backend file" {
+		t.Errorf("Expected global keyring backend to apply, got %q", got)
+	}
+}
+
+func TestKeyringArgsHome(t *testing.T) {
+	voter := NewVoter(&config.Config{KeyManager: config.KeyMgrConfig{HomeDir: "/data/homes"}}, zaptest.NewLogger(t))
+
+	shared := &config.ChainConfig{ChainID: "osmosis-1"}
+	node := &config.ChainConfig{ChainID: "cosmoshub-4", Home: "/root/.gaia"}
+
+	if got := strings.Join(voter.keyringArgs(shared), " "); got != "--keyring-backend test --home /data/homes/osmosis-1" {
+		t.Errorf("Expected per-chain home under home_dir, got %q", got)
+	}
+	if got := strings.Join(voter.keyringArgs(node), " "); got != "--keyring-backend test --home /root/.gaia" {
+		t.Errorf("Expected chain home override, got %q", got)
+	}
+
+	cmd := voter.buildVoteCommand(node, "1", "yes")
+	if !strings.Contains(strings.Join(cmd.Args, " "), "--home /root/.gaia") {
+		t.Errorf("Expected tx command to pass --home, got %v", cmd.Args)
+	}
+}
+
+func TestFetchBalance(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/cosmos/bank/v1beta1/balances/cosmos1abc" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"balances":[{"denom":"ibc/27394FB092D2ECCD","amount":"42"},{"denom":"uatom","amount":"12345"}]}`))
+	}))
+	defer server.Close()
+
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+	chain := &config.ChainConfig{REST: server.URL}
+
+	amount, err := voter.fetchBalance(context.Background(), chain, "cosmos1abc", "uatom")
+	if err != nil {
+		t.Fatalf("Expected balance query to succeed, got: %v", err)
+	}
+	if amount != "12345" {
+		t.Errorf("Expected amount '12345', got '%s'", amount)
+	}
+
+	amount, err = voter.fetchBalance(context.Background(), chain, "cosmos1abc", "uosmo")
+	if err != nil {
+		t.Fatalf("Expected balance query to succeed, got: %v", err)
+	}
+	if amount != "0" {
+		t.Errorf("Expected a missing denom to report '0', got '%s'", amount)
+	}
+}
+
+func TestFetchVoteGrants(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/authz/v1beta1/grants" {
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("granter") != "cosmos1granter" || r.URL.Query().Get("grantee") != "cosmos1bot" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"grants":[
+			{"authorization":{"@type":"/cosmos.authz.v1beta1.GenericAuthorization","msg":"/cosmos.bank.v1beta1.MsgSend"},"expiration":null},
+			{"authorization":{"@type":"/cosmos.authz.v1beta1.GenericAuthorization","msg":"/cosmos.gov.v1.MsgVote"},"expiration":"2024-06-01T00:00:00Z"},
+			{"authorization":{"@type":"/cosmos.authz.v1beta1.GenericAuthorization","msg":"/cosmos.gov.v1beta1.MsgVote"},"expiration":null}
+		]}`))
+	}))
+	defer server.Close()
+
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+	chain := &config.ChainConfig{REST: server.URL}
+
+	grants, err := voter.fetchVoteGrants(context.Background(), chain, "cosmos1granter", "cosmos1bot")
+	if err != nil {
+		t.Fatalf("Expected grants query to succeed, got: %v", err)
+	}
+	if len(grants) != 2 {
+		t.Fatalf("Expected 2 vote grants, got %+v", grants)
+	}
+	if grants[0].MsgTypeURL != MsgVoteV1TypeURL || grants[0].Expiration == nil || !grants[0].Expiration.Equal(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected v1 grant: %+v", grants[0])
+	}
+	if grants[1].MsgTypeURL != MsgVoteV1Beta1TypeURL || grants[1].Expiration != nil {
+		t.Errorf("Unexpected v1beta1 grant: %+v", grants[1])
+	}
+}
+
+func TestAuthzVoteMsgTypeFromGrants(t *testing.T) {
+	grants := `{"authorization":{"@type":"/cosmos.authz.v1beta1.GenericAuthorization","msg":"/cosmos.gov.v1beta1.MsgVote"},"expiration":null}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/authz/v1beta1/grants":
+			w.Write([]byte(`{"grants":[` + grants + `]}`))
+		case "/cosmos/gov/v1/proposals/42":
+			w.Write([]byte(`{"proposal":{"id":"42"}}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+	ctx := context.Background()
+	chain := &config.ChainConfig{Name: "Test Chain", REST: server.URL}
+
+	// A legacy v1beta1 grant on a chain serving gov v1 still needs the v1beta1 type
+	if got := voter.authzVoteMsgType(ctx, chain, "42", "cosmos1granter", "cosmos1bot"); got != MsgVoteV1Beta1TypeURL {
+		t.Errorf("Expected the granted v1beta1 type, got %s", got)
+	}
+
+	// An expired grant doesn't count, so the API is probed
+	grants = `{"authorization":{"@type":"/cosmos.authz.v1beta1.GenericAuthorization","msg":"/cosmos.gov.v1beta1.MsgVote"},"expiration":"2020-01-01T00:00:00Z"}`
+	if got := voter.authzVoteMsgType(ctx, chain, "42", "cosmos1granter", "cosmos1bot"); got != MsgVoteV1TypeURL {
+		t.Errorf("Expected the probed v1 type without an unexpired grant, got %s", got)
+	}
+
+	// Both types granted: the probe decides
+	grants = `{"authorization":{"@type":"/cosmos.authz.v1beta1.GenericAuthorization","msg":"/cosmos.gov.v1beta1.MsgVote"},"expiration":null},
+		{"authorization":{"@type":"/cosmos.authz.v1beta1.GenericAuthorization","msg":"/cosmos.gov.v1.MsgVote"},"expiration":null}`
+	if got := voter.authzVoteMsgType(ctx, chain, "42", "cosmos1granter", "cosmos1bot"); got != MsgVoteV1TypeURL {
+		t.Errorf("Expected the probed v1 type with both granted, got %s", got)
+	}
+}
+
+func TestAuthzGrantStatusActiveGrant(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	past, soon, later := now.Add(-time.Hour), now.Add(time.Hour), now.Add(48*time.Hour)
+
+	status := &AuthzGrantStatus{Grants: []AuthzGrant{{Expiration: &past}}}
+	if status.ActiveGrant(now) != nil {
+		t.Error("Expected an expired grant not to be active")
+	}
+
+	status.Grants = append(status.Grants, AuthzGrant{Expiration: &soon}, AuthzGrant{Expiration: &later})
+	if grant := status.ActiveGrant(now); grant == nil || grant.Expiration != &later {
+		t.Errorf("Expected the longest-lasting grant, got %+v", grant)
+	}
+
+	status.Grants = append(status.Grants, AuthzGrant{})
+	if grant := status.ActiveGrant(now); grant == nil || grant.Expiration != nil {
+		t.Errorf("Expected the grant without expiration, got %+v", grant)
+	}
+
+	// Only grants for the type votes are sent as count
+	status = &AuthzGrantStatus{MsgTypeURL: MsgVoteV1TypeURL, Grants: []AuthzGrant{{MsgTypeURL: MsgVoteV1Beta1TypeURL}}}
+	if grant := status.ActiveGrant(now); grant != nil {
+		t.Errorf("Expected a v1beta1 grant not to authorize v1 votes, got %+v", grant)
+	}
+	if got := grantedVoteMsgType(status.Grants, now); got != MsgVoteV1Beta1TypeURL {
+		t.Errorf("Expected the granted type v1beta1, got %s", got)
+	}
+}
+
+func TestParseCoin(t *testing.T) {
+	amount, denom, err := parseCoin("5000uatom")
+	if err != nil {
+		t.Fatalf("Expected coin to parse, got: %v", err)
+	}
+	if amount.String() != "5000" || denom != "uatom" {
+		t.Errorf("Expected 5000 uatom, got %s %s", amount, denom)
+	}
+
+	for _, coin := range []string{"", "uatom", "5000", "-5uatom"} {
+		if _, _, err := parseCoin(coin); err == nil {
+			t.Errorf("Expected error parsing %q", coin)
+		}
+	}
+}
+
+func TestWalletBalanceLow(t *testing.T) {
+	tests := []struct {
+		amount    string
+		threshold string
+		low       bool
+	}{
+		{"49999", "50000", true},
+		{"50000", "50000", false},
+		{"100000000000000000000", "50000", false},
+		{"0", "50000", true},
+	}
+
+	for _, tt := range tests {
+		balance := &WalletBalance{Amount: tt.amount, Threshold: tt.threshold}
+		if got := balance.Low(); got != tt.low {
+			t.Errorf("Low() with amount %s and threshold %s = %v, want %v", tt.amount, tt.threshold, got, tt.low)
+		}
+	}
+}
+
+func TestCheckFeeBalance(t *testing.T) {
+	balance := "4999"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"balances":[{"denom":"uatom","amount":"%s"}]}`, balance)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	voter := NewVoter(cfg, zaptest.NewLogger(t))
+	chain := &config.ChainConfig{ChainID: "cosmoshub-4", REST: server.URL}
+
+	if err := voter.checkFeeBalance(context.Background(), chain, "cosmos1abc"); err != nil {
+		t.Errorf("Expected no check when check_balance is disabled, got: %v", err)
+	}
+
+	cfg.Voting.CheckBalance = true
+	err := voter.checkFeeBalance(context.Background(), chain, "cosmos1abc")
+	if err == nil || !strings.Contains(err.Error(), "insufficient balance") {
+		t.Errorf("Expected insufficient balance error, got: %v", err)
+	}
+
+	balance = "5000"
+	if err := voter.checkFeeBalance(context.Background(), chain, "cosmos1abc"); err != nil {
+		t.Errorf("Expected a balance covering the fee to pass, got: %v", err)
+	}
+
+	// An unreachable endpoint must not block the vote
+	chain.REST = "http://127.0.0.1:1"
+	if err := voter.checkFeeBalance(context.Background(), chain, "cosmos1abc"); err != nil {
+		t.Errorf("Expected failed balance query to be skipped, got: %v", err)
+	}
+}
+
+func TestBroadcastWithSequenceRetry(t *testing.T) {
+	broadcasts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		broadcasts++
+		if broadcasts == 1 {
+			w.Write([]byte(`{"tx_response":{"txhash":"","code":32,"codespace":"sdk","raw_log":"account sequence mismatch, expected 7, got 6: incorrect account sequence"}}`))
+			return
+		}
+		w.Write([]byte(`{"tx_response":{"txhash":"ABC123","code":0}}`))
+	}))
+	defer server.Close()
+
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+	chain := &config.ChainConfig{REST: server.URL}
+
+	var sequences []string
+	build := func(opts TxOptions) (string, error) {
+		sequences = append(sequences, opts.sequence)
+		return "dHhieXRlcw==", nil
+	}
+
+	txResp, err := voter.broadcastWithSequenceRetry(context.Background(), chain, TxOptions{}, build)
+	if err != nil {
+		t.Fatalf("Expected retry to succeed, got: %v", err)
+	}
+	if txResp.TxHash != "ABC123" {
+		t.Errorf("Expected tx hash 'ABC123', got '%s'", txResp.TxHash)
+	}
+	if len(sequences) != 2 || sequences[0] != "" || sequences[1] != "7" {
+		t.Errorf("Expected a rebuild with sequence 7, got %q", sequences)
+	}
+
+	// Other failures are returned without retrying
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tx_response":{"txhash":"","code":5,"codespace":"sdk","raw_log":"insufficient funds"}}`))
+	}))
+	defer failing.Close()
+
+	sequences = nil
+	chain.REST = failing.URL
+	txResp, err = voter.broadcastWithSequenceRetry(context.Background(), chain, TxOptions{}, build)
+	if err != nil {
+		t.Fatalf("Expected tx response, got error: %v", err)
+	}
+	if txResp.Code != 5 || len(sequences) != 1 {
+		t.Errorf("Expected a single attempt returning code 5, got code %d after %d builds", txResp.Code, len(sequences))
+	}
+}
+
+func TestQueryAccountSequence(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want uint64
+	}{
+		{"base account", `{"account":{"@type":"/cosmos.auth.v1beta1.BaseAccount","sequence":"12"}}`, 12},
+		{"module account", `{"account":{"@type":"/cosmos.auth.v1beta1.ModuleAccount","base_account":{"sequence":"3"}}}`, 3},
+		{"vesting account", `{"account":{"@type":"/cosmos.vesting.v1beta1.DelayedVestingAccount","base_vesting_account":{"base_account":{"sequence":"40"}}}}`, 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/cosmos/auth/v1beta1/accounts/cosmos1abc" {
+					t.Errorf("Unexpected path %s", r.URL.Path)
+				}
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+			chain := &config.ChainConfig{REST: server.URL}
+
+			got, err := voter.queryAccountSequence(context.Background(), chain, "cosmos1abc")
+			if err != nil {
+				t.Fatalf("Expected sequence, got error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected sequence %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConfirmInclusion(t *testing.T) {
+	previous := txPollInterval
+	txPollInterval = 10 * time.Millisecond
+	defer func() { txPollInterval = previous }()
+
+	lookups := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cosmos/tx/v1beta1/txs/ABC123" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		lookups++
+		if lookups < 3 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":5,"message":"tx not found: ABC123"}`))
+			return
+		}
+		w.Write([]byte(`{"tx_response":{"txhash":"ABC123","height":"1234567","code":0}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	voter := NewVoter(cfg, zaptest.NewLogger(t))
+	chain := &config.ChainConfig{REST: server.URL}
+	broadcast := &TxResponse{TxHash: "ABC123"}
+
+	// Disabled: the broadcast response is returned untouched
+	txResp, err := voter.confirmInclusion(context.Background(), chain, broadcast)
+	if err != nil || txResp != broadcast || lookups != 0 {
+		t.Fatalf("Expected no polling when confirm_inclusion is off, got %+v, %v after %d lookups", txResp, err, lookups)
+	}
+
+	cfg.Voting.ConfirmInclusion = true
+	cfg.Voting.ConfirmTimeout = 5 * time.Second
+	txResp, err = voter.confirmInclusion(context.Background(), chain, broadcast)
+	if err != nil {
+		t.Fatalf("Expected tx to be confirmed, got: %v", err)
+	}
+	if txResp.BlockHeight() != 1234567 {
+		t.Errorf("Expected height 1234567, got %d", txResp.BlockHeight())
+	}
+
+	// Block mode already waits for inclusion
+	cfg.Voting.BroadcastMode = config.BroadcastModeBlock
+	if voter.inclusionTimeout() != 0 {
+		t.Error("Expected no inclusion polling in block mode")
+	}
+}
+
+func TestConfirmInclusionTimeout(t *testing.T) {
+	previous := txPollInterval
+	txPollInterval = 10 * time.Millisecond
+	defer func() { txPollInterval = previous }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{Voting: config.VotingConfig{ConfirmInclusion: true, ConfirmTimeout: 50 * time.Millisecond}}
+	voter := NewVoter(cfg, zaptest.NewLogger(t))
+	chain := &config.ChainConfig{REST: server.URL}
+
+	_, err := voter.confirmInclusion(context.Background(), chain, &TxResponse{TxHash: "ABC123"})
+	if err == nil || !strings.Contains(err.Error(), "not included within") {
+		t.Errorf("Expected inclusion timeout error, got: %v", err)
+	}
+}
+
+func TestVoteTimeout(t *testing.T) {
+	cfg := &config.Config{
+		Voting: config.VotingConfig{ConfirmInclusion: true, ConfirmTimeout: 30 * time.Second},
+		Chains: []config.ChainConfig{
+			{ChainID: "cosmoshub-4"},
+			{ChainID: "osmosis-1", UseLedger: true},
+		},
+	}
+	voter := NewVoter(cfg, zaptest.NewLogger(t))
+
+	if got := voter.VoteTimeout("cosmoshub-4"); got != 90*time.Second {
+		t.Errorf("Expected the vote and inclusion timeouts combined, got %s", got)
+	}
+	if got := voter.VoteTimeout("osmosis-1"); got != LedgerVoteTimeout+30*time.Second {
+		t.Errorf("Expected the Ledger timeout for a Ledger chain, got %s", got)
+	}
+	if got := voter.VoteTimeout("unknown-1"); got != 0 {
+		t.Errorf("Expected no timeout for an unknown chain, got %s", got)
+	}
+}
+
+func TestBroadcastMode(t *testing.T) {
+	tests := map[string]string{
+		"":                        "BROADCAST_MODE_SYNC",
+		config.BroadcastModeSync:  "BROADCAST_MODE_SYNC",
+		config.BroadcastModeAsync: "BROADCAST_MODE_ASYNC",
+		config.BroadcastModeBlock: "BROADCAST_MODE_BLOCK",
+	}
+
+	for mode, want := range tests {
+		voter := NewVoter(&config.Config{Voting: config.VotingConfig{BroadcastMode: mode}}, zaptest.NewLogger(t))
+		if got := voter.broadcastMode(); got != want {
+			t.Errorf("broadcastMode() for %q = %s, want %s", mode, got, want)
+		}
+	}
+}
+
+// fakeWalletStore serves one stored wallet for native signing tests
+type fakeWalletStore struct {
+	address  string
+	mnemonic string
+}
+
+func (f *fakeWalletStore) GetWallet(chainID string) (*models.WalletInfo, string, error) {
+	if f.mnemonic == "" {
+		return nil, "", fmt.Errorf("wallet not found for chain %s", chainID)
+	}
+	return &models.WalletInfo{ChainID: chainID, Address: f.address}, f.mnemonic, nil
+}
+
+func TestVoteNativeSigning(t *testing.T) {
+	const (
+		mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+		address  = "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4"
+	)
+
+	var simulated, broadcast []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			TxBytes string `json:"tx_bytes"`
+		}
+		switch r.URL.Path {
+		case "/cosmos/auth/v1beta1/accounts/" + address:
+			w.Write([]byte(`{"account":{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"` + address + `","account_number":"42","sequence":"7"}}`))
+		case "/cosmos/gov/v1/proposals/12":
+			w.Write([]byte(`{"proposal":{"id":"12"}}`))
+		case "/cosmos/tx/v1beta1/simulate":
+			json.NewDecoder(r.Body).Decode(&req)
+			simulated = append(simulated, req.TxBytes)
+			w.Write([]byte(`{"gas_info":{"gas_wanted":"200000","gas_used":"100000"}}`))
+		case "/cosmos/tx/v1beta1/txs":
+			json.NewDecoder(r.Body).Decode(&req)
+			broadcast = append(broadcast, req.TxBytes)
+			w.Write([]byte(`{"tx_response":{"txhash":"NATIVE123","code":0}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Chains: []config.ChainConfig{{
+			Name: "Cosmos Hub", ChainID: "cosmoshub-4", Denom: "uatom", Prefix: "cosmos",
+			CLIName: "missing-binaryd", REST: server.URL, WalletKey: "validator",
+			SigningMode: config.SigningModeNative,
+		}},
+	}
+	voter := NewVoter(cfg, zaptest.NewLogger(t))
+	voter.wallets = &fakeWalletStore{address: address, mnemonic: mnemonic}
+
+	result, err := voter.Vote("cosmoshub-4", "12", "yes", TxOptions{})
+	if err != nil {
+		t.Fatalf("Expected the vote to be signed without a CLI, got error: %v", err)
+	}
+	if result.TxHash != "NATIVE123" {
+		t.Errorf("Expected tx hash NATIVE123, got %s", result.TxHash)
+	}
+	if len(simulated) != 1 || len(broadcast) != 1 {
+		t.Fatalf("Expected one simulation and one broadcast, got %d and %d", len(simulated), len(broadcast))
+	}
+	if simulated[0] == broadcast[0] {
+		t.Error("Expected the broadcast tx to be re-signed with the estimated gas")
+	}
+	txBytes, err := base64.StdEncoding.DecodeString(broadcast[0])
+	if err != nil || !strings.Contains(string(txBytes), address) || !strings.Contains(string(txBytes), "/cosmos.gov.v1.MsgVote") {
+		t.Errorf("Expected a gov v1 vote from %s in the broadcast tx, got err %v", address, err)
+	}
+
+	// A mnemonic that no longer matches the address recorded at import is refused
+	voter.wallets = &fakeWalletStore{address: "cosmos1otheraddress", mnemonic: mnemonic}
+	if _, err := voter.Vote("cosmoshub-4", "12", "yes", TxOptions{}); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("Expected an address mismatch error, got %v", err)
+	}
+
+	voter.wallets = &fakeWalletStore{}
+	if _, err := voter.Vote("cosmoshub-4", "12", "yes", TxOptions{}); err == nil || !strings.Contains(err.Error(), "mnemonic") {
+		t.Errorf("Expected a missing mnemonic error, got %v", err)
+	}
+}
+
+func TestNativeGasLimit(t *testing.T) {
+	voter := NewVoter(&config.Config{}, zaptest.NewLogger(t))
+
+	tests := []struct {
+		name     string
+		chain    config.ChainConfig
+		gas      string
+		limit    uint64
+		simulate bool
+	}{
+		{"simulate by default", config.ChainConfig{}, "", 200000, true},
+		{"chain gas limit", config.ChainConfig{GasLimit: 300000}, "", 300000, false},
+		{"fixed", config.ChainConfig{}, GasFixed, 200000, false},
+		{"override", config.ChainConfig{GasLimit: 300000}, "150000", 150000, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, simulate, err := voter.nativeGasLimit(&tt.chain, TxOptions{Gas: tt.gas})
+			if err != nil || limit != tt.limit || simulate != tt.simulate {
+				t.Errorf("Expected %d (simulate %v), got %d (simulate %v), err %v", tt.limit, tt.simulate, limit, simulate, err)
+			}
+		})
+	}
+}
+
+func TestValidateDeposit(t *testing.T) {
+	cfg := &config.Config{
+		Chains: []config.ChainConfig{
+			{Name: "Cosmos Hub", ChainID: "cosmoshub-4", Denom: "uatom", MaxDeposit: "5000000"},
+			{Name: "Osmosis", ChainID: "osmosis-1", Denom: "uosmo"},
+		},
+	}
+	voter := NewVoter(cfg, zaptest.NewLogger(t))
+
+	tests := []struct {
+		name       string
+		chainID    string
+		amount     string
+		exceedsCap bool
+		wantErr    string
+	}{
+		{"within cap", "cosmoshub-4", "1000000uatom", false, ""},
+		{"at cap", "cosmoshub-4", "5000000uatom", false, ""},
+		{"above cap", "cosmoshub-4", "5000001uatom", true, ""},
+		{"no cap", "osmosis-1", "999999999999uosmo", false, ""},
+		{"wrong denom", "cosmoshub-4", "1000000uosmo", false, "does not match"},
+		{"display units", "cosmoshub-4", "1.5atom", false, "invalid deposit amount"},
+		{"missing denom", "cosmoshub-4", "1000000", false, "invalid deposit amount"},
+		{"zero", "cosmoshub-4", "0uatom", false, "must be positive"},
+		{"unknown chain", "juno-1", "1000000ujuno", false, "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exceedsCap, err := voter.ValidateDeposit(tt.chainID, tt.amount)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if exceedsCap != tt.exceedsCap {
+				t.Errorf("Expected exceedsCap %v, got %v", tt.exceedsCap, exceedsCap)
+			}
+		})
+	}
+}
+
+func TestDepositNativeSigning(t *testing.T) {
+	const (
+		mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+		address  = "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4"
+	)
+
+	var broadcast []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/auth/v1beta1/accounts/" + address:
+			w.Write([]byte(`{"account":{"account_number":"42","sequence":"7"}}`))
+		case "/cosmos/gov/v1/proposals/12":
+			// An older chain without gov v1
+			w.WriteHeader(http.StatusNotImplemented)
+		case "/cosmos/tx/v1beta1/txs":
+			var req struct {
+				TxBytes string `json:"tx_bytes"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			broadcast = append(broadcast, req.TxBytes)
+			w.Write([]byte(`{"tx_response":{"txhash":"DEPOSIT123","code":0}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Chains: []config.ChainConfig{{
+			Name: "Cosmos Hub", ChainID: "cosmoshub-4", Denom: "uatom", Prefix: "cosmos",
+			CLIName: "missing-binaryd", REST: server.URL, WalletKey: "validator",
+			SigningMode: config.SigningModeNative, GasLimit: 250000,
+		}},
+	}
+	voter := NewVoter(cfg, zaptest.NewLogger(t))
+	voter.wallets = &fakeWalletStore{address: address, mnemonic: mnemonic}
+
+	if _, err := voter.Deposit("cosmoshub-4", "12", "1000000uosmo"); err == nil {
+		t.Error("Expected a deposit in another denom to be rejected")
+	}
+
+	result, err := voter.Deposit("cosmoshub-4", "12", "1000000uatom")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.TxHash != "DEPOSIT123" || len(broadcast) != 1 {
+		t.Fatalf("Expected one broadcast returning DEPOSIT123, got %s after %d broadcasts", result.TxHash, len(broadcast))
+	}
+	txBytes, err := base64.StdEncoding.DecodeString(broadcast[0])
+	if err != nil || !strings.Contains(string(txBytes), "/cosmos.gov.v1beta1.MsgDeposit") || !strings.Contains(string(txBytes), "1000000") {
+		t.Errorf("Expected a v1beta1 deposit of 1000000uatom in the broadcast tx, got err %v", err)
+	}
+}
+
+func TestCancelProposalNativeSigning(t *testing.T) {
+	const (
+		mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+		address  = "cosmos19rl4cm2hmr8afy4kldpxz3fka4jguq0auqdal4"
+	)
+
+	var broadcast []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/auth/v1beta1/accounts/" + address:
+			w.Write([]byte(`{"account":{"account_number":"42","sequence":"7"}}`))
+		case "/cosmos/gov/v1/proposals/12":
+			w.Write([]byte(`{"proposal":{"id":"12","proposer":"` + address + `"}}`))
+		case "/cosmos/gov/v1/proposals/13":
+			w.Write([]byte(`{"proposal":{"id":"13","proposer":"cosmos1someoneelse"}}`))
+		case "/cosmos/gov/v1/proposals/14":
+			// Chains before v0.50 don't report the proposer
+			w.Write([]byte(`{"proposal":{"id":"14"}}`))
+		case "/cosmos/tx/v1beta1/txs":
+			var req struct {
+				TxBytes string `json:"tx_bytes"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			broadcast = append(broadcast, req.TxBytes)
+			w.Write([]byte(`{"tx_response":{"txhash":"CANCEL123","code":0}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Chains: []config.ChainConfig{{
+			Name: "Cosmos Hub", ChainID: "cosmoshub-4", Denom: "uatom", Prefix: "cosmos",
+			CLIName: "missing-binaryd", REST: server.URL, WalletKey: "validator",
+			SigningMode: config.SigningModeNative, GasLimit: 250000,
+		}},
+	}
+	voter := NewVoter(cfg, zaptest.NewLogger(t))
+	voter.wallets = &fakeWalletStore{address: address, mnemonic: mnemonic}
+
+	result, err := voter.CancelProposal("cosmoshub-4", "12")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.TxHash != "CANCEL123" || len(broadcast) != 1 {
+		t.Fatalf("Expected one broadcast returning CANCEL123, got %s after %d broadcasts", result.TxHash, len(broadcast))
+	}
+	txBytes, err := base64.StdEncoding.DecodeString(broadcast[0])
+	if err != nil || !strings.Contains(string(txBytes), "/cosmos.gov.v1.MsgCancelProposal") {
+		t.Errorf("Expected a gov v1 MsgCancelProposal in the broadcast tx, got err %v", err)
+	}
+
+	for proposalID, want := range map[string]string{"13": "only the proposer", "14": "does not report", "15": "not found"} {
+		if _, err := voter.CancelProposal("cosmoshub-4", proposalID); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected proposal %s to be refused with %q, got %v", proposalID, want, err)
+		}
+	}
+	if len(broadcast) != 1 {
+		t.Errorf("Expected refused cancellations not to broadcast, got %d broadcasts", len(broadcast))
+	}
+}
@@ -27,15 +27,9 @@ type Manager struct {
 // NewManager creates a new wallet manager
 func NewManager(db *gorm.DB, config *config.Config, logger *zap.Logger) (*Manager, error) {
 	// Create AES cipher for encryption
-	key := sha256.Sum256([]byte(config.Security.EncryptionKey))
-	block, err := aes.NewCipher(key[:])
-	if err != nil {
-		return nil, fmt.Errorf("failed to create cipher: %w", err)
-	}
-
-	gcm, err := cipher.NewGCM(block)
+	gcm, err := newGCM(config.Security.EncryptionKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GCM: %w", err)
+		return nil, err
 	}
 
 	return &Manager{
@@ -159,31 +153,113 @@ func (m *Manager) ValidateWalletExists(chainID string) error {
 	return nil
 }
 
+// RotateEncryptionKey re-encrypts every stored wallet under newKey
+// All rows are rewritten in a single transaction; if any row can't be decrypted with
+// oldKey nothing is changed
+func (m *Manager) RotateEncryptionKey(oldKey, newKey string) error {
+	if newKey == "" {
+		return fmt.Errorf("new encryption key must not be empty")
+	}
+
+	oldGCM, err := newGCM(oldKey)
+	if err != nil {
+		return err
+	}
+	rotatedGCM, err := newGCM(newKey)
+	if err != nil {
+		return err
+	}
+
+	var rotated int
+	err = m.db.Transaction(func(tx *gorm.DB) error {
+		var wallets []models.WalletInfo
+		if err := tx.Find(&wallets).Error; err != nil {
+			return fmt.Errorf("failed to load wallets: %w", err)
+		}
+
+		for _, wallet := range wallets {
+			privateData, err := decryptWith(oldGCM, wallet.EncryptedKey)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt wallet for chain %s with old key: %w", wallet.ChainID, err)
+			}
+
+			encryptedData, err := encryptWith(rotatedGCM, privateData)
+			if err != nil {
+				return fmt.Errorf("failed to re-encrypt wallet for chain %s: %w", wallet.ChainID, err)
+			}
+
+			if err := tx.Model(&models.WalletInfo{}).Where("id = ?", wallet.ID).
+				Update("encrypted_key", encryptedData).Error; err != nil {
+				return fmt.Errorf("failed to update wallet for chain %s: %w", wallet.ChainID, err)
+			}
+			rotated++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Keep using the new key for the rest of this process
+	m.gcm = rotatedGCM
+	m.config.Security.EncryptionKey = newKey
+
+	m.logger.Info("Encryption key rotated", zap.Int("wallets", rotated))
+	return nil
+}
+
+// newGCM derives an AES-GCM cipher from an encryption key
+func newGCM(encryptionKey string) (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(encryptionKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return gcm, nil
+}
+
 // encrypt encrypts data using AES-GCM
 func (m *Manager) encrypt(data string) (string, error) {
-	nonce := make([]byte, m.gcm.NonceSize())
+	return encryptWith(m.gcm, data)
+}
+
+// decrypt decrypts data using AES-GCM
+func (m *Manager) decrypt(encryptedData string) (string, error) {
+	return decryptWith(m.gcm, encryptedData)
+}
+
+// encryptWith encrypts data with the given cipher, prefixing the random nonce
+func encryptWith(gcm cipher.AEAD, data string) (string, error) {
+	nonce := make([]byte, gcm.NonceSize())
 	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
 		return "", fmt.Errorf("failed to generate nonce: %w", err)
 	}
 
-	ciphertext := m.gcm.Seal(nonce, nonce, []byte(data), nil)
+	ciphertext := gcm.Seal(nonce, nonce, []byte(data), nil)
 	return base64.StdEncoding.EncodeToString(ciphertext), nil
 }
 
-// decrypt decrypts data using AES-GCM
-func (m *Manager) decrypt(encryptedData string) (string, error) {
+// decryptWith decrypts nonce-prefixed data with the given cipher
+func decryptWith(gcm cipher.AEAD, encryptedData string) (string, error) {
 	data, err := base64.StdEncoding.DecodeString(encryptedData)
 	if err != nil {
 		return "", fmt.Errorf("failed to decode base64: %w", err)
 	}
 
-	nonceSize := m.gcm.NonceSize()
+	nonceSize := gcm.NonceSize()
 	if len(data) < nonceSize {
 		return "", fmt.Errorf("ciphertext too short")
 	}
 
 	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
-	plaintext, err := m.gcm.Open(nil, nonce, ciphertext, nil)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to decrypt: %w", err)
 	}
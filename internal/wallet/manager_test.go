@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"encoding/json"
 	"testing"
 
 	"prop-voter/config"
@@ -371,6 +372,104 @@ func TestExportWallet(t *testing.T) {
 	}
 }
 
+func TestImportWalletExport(t *testing.T) {
+	source, _ := setupTestManager(t)
+	if err := source.StoreWallet("test-chain-1", "test-key", "test1abc123", "sensitive-private-key-data"); err != nil {
+		t.Fatalf("Failed to store wallet: %v", err)
+	}
+
+	exported, err := source.ExportWallet("test-chain-1")
+	if err != nil {
+		t.Fatalf("Failed to export wallet: %v", err)
+	}
+
+	// Round-trip through JSON as the CLI does, so created_at becomes a string
+	data, err := json.Marshal(exported)
+	if err != nil {
+		t.Fatalf("Failed to encode export: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode export: %v", err)
+	}
+
+	target, _ := setupTestManager(t)
+	if err := target.ImportWalletExport(decoded); err != nil {
+		t.Fatalf("Failed to import wallet: %v", err)
+	}
+
+	wallet, privateData, err := target.GetWallet("test-chain-1")
+	if err != nil {
+		t.Fatalf("Failed to get restored wallet: %v", err)
+	}
+	if wallet.KeyName != "test-key" || wallet.Address != "test1abc123" || privateData != "sensitive-private-key-data" {
+		t.Errorf("Unexpected restored wallet: %+v (private data %q)", wallet, privateData)
+	}
+}
+
+func TestImportWalletExportInvalid(t *testing.T) {
+	manager, _ := setupTestManager(t)
+
+	tests := []map[string]interface{}{
+		{"key_name": "k", "private_data": "p"},
+		{"chain_id": "c", "private_data": "p"},
+		{"chain_id": "c", "key_name": "k"},
+		{"chain_id": "c", "key_name": "k", "private_data": ""},
+		{"chain_id": "c", "key_name": "k", "private_data": 42.0},
+		{"chain_id": "c", "key_name": "k", "private_data": "p", "address": true},
+	}
+
+	for i, data := range tests {
+		if err := manager.ImportWalletExport(data); err == nil {
+			t.Errorf("Case %d: expected an error for %v", i, data)
+		}
+	}
+
+	wallets, _ := manager.ListWallets()
+	if len(wallets) != 0 {
+		t.Errorf("Expected no wallets to be stored, got %d", len(wallets))
+	}
+}
+
+func TestImportWalletExports(t *testing.T) {
+	manager, _ := setupTestManager(t)
+
+	exports := []map[string]interface{}{
+		{"chain_id": "chain-1", "key_name": "key-1", "address": "addr1", "private_data": "data-1"},
+		{"chain_id": "chain-2", "key_name": "key-2", "private_data": "data-2"},
+	}
+
+	count, err := manager.ImportWalletExports(exports)
+	if err != nil {
+		t.Fatalf("Failed to import wallets: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 wallets imported, got %d", count)
+	}
+
+	_, privateData, err := manager.GetWallet("chain-2")
+	if err != nil || privateData != "data-2" {
+		t.Errorf("Expected chain-2 to be restored, got %q, %v", privateData, err)
+	}
+
+	// A bad entry anywhere in the batch means nothing is written
+	fresh, _ := setupTestManager(t)
+	bad := []map[string]interface{}{
+		{"chain_id": "chain-1", "key_name": "key-1", "private_data": "data-1"},
+		{"chain_id": "chain-2", "key_name": "key-2"},
+	}
+	if _, err := fresh.ImportWalletExports(bad); err == nil {
+		t.Error("Expected an error for a batch with a missing private_data")
+	}
+	duplicate := []map[string]interface{}{exports[0], exports[0]}
+	if _, err := fresh.ImportWalletExports(duplicate); err == nil {
+		t.Error("Expected an error for a batch with duplicate chains")
+	}
+	if wallets, _ := fresh.ListWallets(); len(wallets) != 0 {
+		t.Errorf("Expected no wallets after a rejected batch, got %d", len(wallets))
+	}
+}
+
 func TestEncryptDecrypt(t *testing.T) {
 	manager, _ := setupTestManager(t)
 
@@ -490,3 +589,71 @@ func BenchmarkEncryptDecrypt(b *testing.B) {
 		}
 	}
 }
+
+func TestRotateEncryptionKey(t *testing.T) {
+	manager, db := setupTestManager(t)
+	oldKey := manager.config.Security.EncryptionKey
+	newKey := "rotated-encryption-key-32-chars!"
+
+	if err := manager.StoreWallet("cosmoshub-4", "validator", "cosmos1abc", "mnemonic one"); err != nil {
+		t.Fatalf("Failed to store wallet: %v", err)
+	}
+	if err := manager.StoreWallet("osmosis-1", "validator", "osmo1abc", "mnemonic two"); err != nil {
+		t.Fatalf("Failed to store wallet: %v", err)
+	}
+
+	if err := manager.RotateEncryptionKey(oldKey, newKey); err != nil {
+		t.Fatalf("Failed to rotate key: %v", err)
+	}
+
+	// A fresh manager using the new key can decrypt everything
+	rotated, err := NewManager(db, &config.Config{Security: config.SecurityConfig{EncryptionKey: newKey}}, zaptest.NewLogger(t))
+	if err != nil {
+		t.Fatalf("Failed to create manager with new key: %v", err)
+	}
+	for chainID, expected := range map[string]string{"cosmoshub-4": "mnemonic one", "osmosis-1": "mnemonic two"} {
+		_, privateData, err := rotated.GetWallet(chainID)
+		if err != nil {
+			t.Fatalf("Failed to get wallet %s with new key: %v", chainID, err)
+		}
+		if privateData != expected {
+			t.Errorf("Expected '%s' for %s, got '%s'", expected, chainID, privateData)
+		}
+	}
+
+	// The rotating manager switches to the new key too
+	if _, _, err := manager.GetWallet("cosmoshub-4"); err != nil {
+		t.Errorf("Expected rotating manager to use the new key: %v", err)
+	}
+}
+
+func TestRotateEncryptionKeyAtomic(t *testing.T) {
+	manager, db := setupTestManager(t)
+	oldKey := manager.config.Security.EncryptionKey
+
+	if err := manager.StoreWallet("cosmoshub-4", "validator", "cosmos1abc", "mnemonic one"); err != nil {
+		t.Fatalf("Failed to store wallet: %v", err)
+	}
+
+	// Store a second wallet encrypted under a different key
+	other, err := NewManager(db, &config.Config{Security: config.SecurityConfig{EncryptionKey: "some-other-key"}}, zaptest.NewLogger(t))
+	if err != nil {
+		t.Fatalf("Failed to create second manager: %v", err)
+	}
+	if err := other.StoreWallet("osmosis-1", "validator", "osmo1abc", "mnemonic two"); err != nil {
+		t.Fatalf("Failed to store wallet: %v", err)
+	}
+
+	if err := manager.RotateEncryptionKey(oldKey, "new-key"); err == nil {
+		t.Fatal("Expected rotation to fail when a wallet can't be decrypted")
+	}
+
+	// Nothing was changed: the old key still decrypts the first wallet
+	_, privateData, err := manager.GetWallet("cosmoshub-4")
+	if err != nil {
+		t.Fatalf("Expected wallet to remain readable with old key: %v", err)
+	}
+	if privateData != "mnemonic one" {
+		t.Errorf("Expected 'mnemonic one', got '%s'", privateData)
+	}
+}
@@ -0,0 +1,89 @@
+package wallet
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// ImportWalletExport restores a wallet from the map produced by ExportWallet; chain_id,
+// key_name and private_data are required, address is optional and created_at is ignored
+func (m *Manager) ImportWalletExport(data map[string]interface{}) error {
+	chainID, err := exportString(data, "chain_id", true)
+	if err != nil {
+		return err
+	}
+	keyName, err := exportString(data, "key_name", true)
+	if err != nil {
+		return fmt.Errorf("wallet %s: %w", chainID, err)
+	}
+	address, err := exportString(data, "address", false)
+	if err != nil {
+		return fmt.Errorf("wallet %s: %w", chainID, err)
+	}
+	privateData, err := exportString(data, "private_data", true)
+	if err != nil {
+		return fmt.Errorf("wallet %s: %w", chainID, err)
+	}
+
+	if err := m.StoreWallet(chainID, keyName, address, privateData); err != nil {
+		return fmt.Errorf("wallet %s: %w", chainID, err)
+	}
+	return nil
+}
+
+// ImportWalletExports restores a batch of exported wallets and returns how many were
+// stored. Every entry is validated before any is written, so a malformed backup leaves
+// the database untouched
+func (m *Manager) ImportWalletExports(exports []map[string]interface{}) (int, error) {
+	seen := make(map[string]bool, len(exports))
+	for i, data := range exports {
+		chainID, err := exportString(data, "chain_id", true)
+		if err != nil {
+			return 0, fmt.Errorf("entry %d: %w", i, err)
+		}
+		for _, field := range []string{"key_name", "private_data"} {
+			if _, err := exportString(data, field, true); err != nil {
+				return 0, fmt.Errorf("entry %d (%s): %w", i, chainID, err)
+			}
+		}
+		if _, err := exportString(data, "address", false); err != nil {
+			return 0, fmt.Errorf("entry %d (%s): %w", i, chainID, err)
+		}
+		if seen[chainID] {
+			return 0, fmt.Errorf("entry %d: duplicate wallet for chain %s", i, chainID)
+		}
+		seen[chainID] = true
+	}
+
+	imported := 0
+	for _, data := range exports {
+		if err := m.ImportWalletExport(data); err != nil {
+			return imported, err
+		}
+		imported++
+	}
+
+	m.logger.Info("Wallets restored from export", zap.Int("count", imported))
+	return imported, nil
+}
+
+// exportString reads a string field from an exported wallet
+func exportString(data map[string]interface{}, field string, required bool) (string, error) {
+	value, ok := data[field]
+	if !ok || value == nil {
+		if required {
+			return "", fmt.Errorf("missing %s", field)
+		}
+		return "", nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%s must be a string", field)
+	}
+	if required && str == "" {
+		return "", fmt.Errorf("missing %s", field)
+	}
+	return str, nil
+}
@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"prop-voter/config"
+	"prop-voter/internal/version"
+
+	"go.uber.org/zap"
+)
+
+// Payload is the JSON body POSTed to the webhook for each proposal notification
+type Payload struct {
+	Chain      string     `json:"chain"`
+	ProposalID string     `json:"proposal_id"`
+	Title      string     `json:"title"`
+	Status     string     `json:"status"`
+	VotingEnd  *time.Time `json:"voting_end,omitempty"`
+	URL        string     `json:"url,omitempty"`
+}
+
+// Notifier delivers proposal notifications to a generic HTTP webhook
+type Notifier struct {
+	config     config.WebhookConfig
+	logger     *zap.Logger
+	client     *http.Client
+	retryDelay time.Duration
+}
+
+// NewNotifier creates a new webhook notifier
+func NewNotifier(cfg config.WebhookConfig, logger *zap.Logger) *Notifier {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &Notifier{
+		config: cfg,
+		logger: logger,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		retryDelay: 2 * time.Second,
+	}
+}
+
+// Notify POSTs the payload to the webhook, retrying with a linear backoff on failure
+func (n *Notifier) Notify(ctx context.Context, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= n.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * n.retryDelay):
+			}
+		}
+
+		if lastErr = n.post(ctx, body); lastErr == nil {
+			n.logger.Debug("Webhook notification delivered",
+				zap.String("chain", payload.Chain),
+				zap.String("proposal_id", payload.ProposalID),
+			)
+			return nil
+		}
+
+		n.logger.Warn("Webhook delivery failed",
+			zap.String("chain", payload.Chain),
+			zap.String("proposal_id", payload.ProposalID),
+			zap.Int("attempt", attempt+1),
+			zap.Error(lastErr),
+		)
+	}
+
+	return fmt.Errorf("webhook delivery failed after %d attempts: %w", n.config.MaxRetries+1, lastErr)
+}
+
+// post sends a single webhook request
+func (n *Notifier) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", n.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", version.UserAgent())
+	for key, value := range n.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
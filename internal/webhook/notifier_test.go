@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"prop-voter/config"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestNotify(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+		received Payload
+		header   string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+
+		// Fail the first delivery to exercise the retry path
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		header = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(config.WebhookConfig{
+		URL:        server.URL,
+		Headers:    map[string]string{"Authorization": "Bearer test-token"},
+		MaxRetries: 2,
+	}, zaptest.NewLogger(t))
+	notifier.retryDelay = time.Millisecond
+
+	votingEnd := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	payload := Payload{
+		Chain:      "cosmoshub-4",
+		ProposalID: "123",
+		Title:      "Test Proposal",
+		Status:     "PROPOSAL_STATUS_VOTING_PERIOD",
+		VotingEnd:  &votingEnd,
+		URL:        "https://www.mintscan.io/cosmos/proposals/123",
+	}
+
+	if err := notifier.Notify(context.Background(), payload); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if requests != 2 {
+		t.Errorf("Expected 2 requests (1 failure + 1 retry), got %d", requests)
+	}
+	if header != "Bearer test-token" {
+		t.Errorf("Expected Authorization header to be forwarded, got %q", header)
+	}
+	if received.Chain != payload.Chain || received.ProposalID != payload.ProposalID ||
+		received.Title != payload.Title || received.Status != payload.Status || received.URL != payload.URL {
+		t.Errorf("Payload mismatch: got %+v, want %+v", received, payload)
+	}
+	if received.VotingEnd == nil || !received.VotingEnd.Equal(votingEnd) {
+		t.Errorf("Expected voting_end %v, got %v", votingEnd, received.VotingEnd)
+	}
+}
+
+func TestNotifyGivesUp(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(config.WebhookConfig{URL: server.URL, MaxRetries: 1}, zaptest.NewLogger(t))
+	notifier.retryDelay = time.Millisecond
+
+	if err := notifier.Notify(context.Background(), Payload{Chain: "cosmoshub-4", ProposalID: "1"}); err == nil {
+		t.Fatal("Expected error after exhausting retries")
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 attempts, got %d", requests)
+	}
+}
@@ -121,6 +121,10 @@ func TestIntegrationHealthEndpoint(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// Report what the bot and binary setup would publish at startup
+	it.healthServer.Status().SetDiscordConnected(true, time.Now())
+	it.healthServer.Status().SetBinariesReady()
+
 	// Start health server
 	err := it.healthServer.Start(ctx)
 	if err != nil {
@@ -233,7 +237,7 @@ func TestIntegrationVoter(t *testing.T) {
 
 	// Test vote command building (using echo actually succeeds but produces wrong output)
 	// We can test that it returns something (even if not a valid tx hash)
-	_, err = it.voter.Vote("test-1", "123", "yes")
+	_, err = it.voter.Vote("test-1", "123", "yes", voting.TxOptions{})
 	if err != nil {
 		t.Logf("Expected error or success with echo command: %v", err)
 	}
@@ -18,7 +18,7 @@ func TestChainRegistryIntegration(t *testing.T) {
 	}
 
 	logger := zaptest.NewLogger(t)
-	client := registry.NewClient(logger)
+	client := registry.NewClient(logger, "")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -102,7 +102,7 @@ func TestChainRegistryManager(t *testing.T) {
 	}
 
 	logger := zaptest.NewLogger(t)
-	manager := registry.NewManager(logger)
+	manager := registry.NewManager(logger, "")
 
 	// Create test chain configurations
 	chains := []config.ChainConfig{
@@ -207,7 +207,7 @@ func TestChainRegistryManager(t *testing.T) {
 // TestChainRegistryValidation tests configuration validation
 func TestChainRegistryValidation(t *testing.T) {
 	logger := zaptest.NewLogger(t)
-	manager := registry.NewManager(logger)
+	manager := registry.NewManager(logger, "")
 
 	validConfigs := []*config.ChainConfig{
 		// Valid Chain Registry config
@@ -274,7 +274,7 @@ func TestChainRegistryCache(t *testing.T) {
 	}
 
 	logger := zaptest.NewLogger(t)
-	client := registry.NewClient(logger)
+	client := registry.NewClient(logger, "")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -334,7 +334,7 @@ func TestChainRegistryErrorHandling(t *testing.T) {
 	}
 
 	logger := zaptest.NewLogger(t)
-	client := registry.NewClient(logger)
+	client := registry.NewClient(logger, "")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()